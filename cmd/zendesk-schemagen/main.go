@@ -0,0 +1,93 @@
+// Command zendesk-schemagen generates Go types and constants from the schema of a Zendesk account.
+//
+// It reads the schema from a JSON snapshot, or from the account given by the environment
+// variables ZENDESK_SUBDOMAIN, ZENDESK_EMAIL and ZENDESK_API_TOKEN when -snapshot is empty.
+//
+//	//go:generate go run github.com/nukosuke/go-zendesk/cmd/zendesk-schemagen -snapshot schema.json -o zendesk_schema.go
+//
+// Pass -save to write the schema read from the account as a snapshot.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+	"github.com/nukosuke/go-zendesk/zendesk/schemagen"
+)
+
+func main() {
+	snapshot := flag.String("snapshot", "", "JSON snapshot to read the schema from instead of the account")
+	save := flag.String("save", "", "file to write the schema read from the account as a snapshot")
+	out := flag.String("o", "zendesk_schema.go", "output file")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package name of the output file")
+	inactive := flag.Bool("inactive", false, "include inactive fields and custom statuses")
+	flag.Parse()
+
+	if err := run(*snapshot, *save, *out, *pkg, *inactive); err != nil {
+		fmt.Fprintln(os.Stderr, "zendesk-schemagen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(snapshot, save, out, pkg string, inactive bool) error {
+	var s schemagen.Snapshot
+	if snapshot != "" {
+		f, err := os.Open(snapshot)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if s, err = schemagen.ReadSnapshot(f); err != nil {
+			return err
+		}
+	} else {
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		if s, err = schemagen.Fetch(context.Background(), client); err != nil {
+			return err
+		}
+	}
+
+	if save != "" {
+		f, err := os.Create(save)
+		if err != nil {
+			return err
+		}
+		if err := schemagen.WriteSnapshot(f, s); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+
+	src, err := schemagen.Generate(s, schemagen.Options{Package: pkg, Inactive: inactive})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
+
+func newClient() (*zendesk.Client, error) {
+	subdomain, email, token := os.Getenv("ZENDESK_SUBDOMAIN"), os.Getenv("ZENDESK_EMAIL"), os.Getenv("ZENDESK_API_TOKEN")
+	if subdomain == "" || email == "" || token == "" {
+		return nil, errors.New("ZENDESK_SUBDOMAIN, ZENDESK_EMAIL and ZENDESK_API_TOKEN are required without -snapshot")
+	}
+
+	client, err := zendesk.NewClient(nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.SetSubdomain(subdomain); err != nil {
+		return nil, err
+	}
+	client.SetCredential(zendesk.NewAPITokenCredential(email, token))
+	return client, nil
+}
@@ -262,6 +262,45 @@ var funcData []FuncTemplateData = []FuncTemplateData{
 		FileName:    "organization_tickets",
 		ExtraParam:  true,
 	},
+	{
+		FuncName:    "UserRequestedTickets",
+		ObjectName:  "Ticket",
+		ApiEndpoint: "/users/%d/tickets/requested.json",
+		JsonName:    "tickets",
+		FileName:    "user_requested_tickets",
+		ExtraParam:  true,
+	},
+	{
+		FuncName:    "UserCCDTickets",
+		ObjectName:  "Ticket",
+		ApiEndpoint: "/users/%d/tickets/ccd.json",
+		JsonName:    "tickets",
+		FileName:    "user_ccd_tickets",
+		ExtraParam:  true,
+	},
+	{
+		FuncName:    "UserAssignedTickets",
+		ObjectName:  "Ticket",
+		ApiEndpoint: "/users/%d/tickets/assigned.json",
+		JsonName:    "tickets",
+		FileName:    "user_assigned_tickets",
+		ExtraParam:  true,
+	},
+	{
+		FuncName:    "UserFollowedTickets",
+		ObjectName:  "Ticket",
+		ApiEndpoint: "/users/%d/tickets/followed.json",
+		JsonName:    "tickets",
+		FileName:    "user_followed_tickets",
+		ExtraParam:  true,
+	},
+	{
+		FuncName:    "RecentTickets",
+		ObjectName:  "Ticket",
+		ApiEndpoint: "/tickets/recent.json",
+		JsonName:    "tickets",
+		FileName:    "recent_tickets",
+	},
 }
 
 func main() {
@@ -43,6 +43,8 @@ const (
 	ActionFieldCommentModeIsPublic
 	// ActionFieldTicketFormID ticket_form_id
 	ActionFieldTicketFormID
+	// ActionFieldNotificationWebhook notification_webhook
+	ActionFieldNotificationWebhook
 )
 
 var actionFieldText = map[int]string{
@@ -66,6 +68,7 @@ var actionFieldText = map[int]string{
 	ActionFieldCommentValueHTML:    "comment_value_html",
 	ActionFieldCommentModeIsPublic: "comment_mode_is_public",
 	ActionFieldTicketFormID:        "ticket_form_id",
+	ActionFieldNotificationWebhook: "notification_webhook",
 }
 
 // ActionFieldText takes field type and returns field name string
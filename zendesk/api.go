@@ -14,6 +14,7 @@ type API interface {
 	DynamicContentAPI
 	GroupAPI
 	GroupMembershipAPI
+	JobStatusAPI
 	LocaleAPI
 	MacroAPI
 	OrganizationAPI
@@ -21,10 +22,12 @@ type API interface {
 	OrganizationMembershipAPI
 	SearchAPI
 	SLAPolicyAPI
+	SocialChannelAPI
 	TagAPI
 	TargetAPI
 	TicketAuditAPI
 	TicketAPI
+	TicketImportAPI
 	TicketCommentAPI
 	TicketFieldAPI
 	TicketFormAPI
@@ -34,6 +37,12 @@ type API interface {
 	ViewAPI
 	WebhookAPI
 	CustomObjectAPI
+	CustomStatusAPI
+	ObjectTriggerAPI
+	UserSegmentAPI
+	PermissionGroupAPI
+	ContentTagAPI
+	ScheduleAPI
 }
 
 var _ API = (*Client)(nil)
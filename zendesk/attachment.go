@@ -83,7 +83,7 @@ func (wr *writer) open() error {
 	req.URL.RawQuery = q.Encode()
 
 	go func() {
-		resp, err := wr.httpClient.Do(req)
+		resp, err := wr.do(req)
 		if err != nil {
 			wr.c <- result{
 				err: err,
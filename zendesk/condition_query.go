@@ -0,0 +1,241 @@
+package zendesk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ConditionQuery is a search query translated from the conditions of a view or a trigger.
+// Unsupported are the conditions which cannot be expressed in the search syntax and are left
+// out of Query, so Query matches a superset of the tickets which the conditions match.
+type ConditionQuery struct {
+	Query       string             `json:"query"`
+	Unsupported []TriggerCondition `json:"unsupported,omitempty"`
+}
+
+// Exact reports whether all the conditions are expressed in Query
+func (q ConditionQuery) Exact() bool {
+	return len(q.Unsupported) == 0
+}
+
+// ticket properties of the search syntax by the condition fields which refer to a user, group or organization
+var conditionSearchKeywords = map[string]string{
+	"assignee_id":     "assignee",
+	"requester_id":    "requester",
+	"group_id":        "group",
+	"organization_id": "organization",
+	"brand_id":        "brand",
+	"recipient":       "recipient",
+}
+
+// ticket properties of the search syntax by the condition fields of hours since an event
+var conditionSearchTimes = map[string]string{
+	"NEW":        "created",
+	"SOLVED":     "solved",
+	"updated_at": "updated",
+	"due_date":   "due_date",
+}
+
+// ticket properties of the search syntax by the condition fields which match words
+var conditionSearchWords = map[string]string{
+	"subject_includes_word":     "subject",
+	"description_includes_word": "description",
+}
+
+// ViewSearchQuery translates the conditions of the view into a ticket search query
+func ViewSearchQuery(v View) ConditionQuery {
+	return TranslateConditions(v.Conditions.All, v.Conditions.Any)
+}
+
+// TriggerSearchQuery translates the conditions of the trigger into a ticket search query
+// matching the tickets whose current state meets them. Conditions on the update itself,
+// such as changes and comments, cannot be expressed.
+func TriggerSearchQuery(t Trigger) ConditionQuery {
+	return TranslateConditions(t.Conditions.All, t.Conditions.Any)
+}
+
+// TranslateConditions translates conditions which must all be met and conditions of which at
+// least one must be met into a ticket search query. The search syntax has no OR across
+// different properties, so the any conditions are expressed only when there is one of them.
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/ticket-management/search/
+func TranslateConditions(all, any []TriggerCondition) ConditionQuery {
+	var q ConditionQuery
+	terms := []string{"type:ticket"}
+
+	for _, c := range all {
+		if t, ok := conditionSearchTerms(c); ok {
+			terms = append(terms, t...)
+		} else {
+			q.Unsupported = append(q.Unsupported, c)
+		}
+	}
+
+	if len(any) == 1 {
+		if t, ok := conditionSearchTerms(any[0]); ok {
+			terms = append(terms, t...)
+		} else {
+			q.Unsupported = append(q.Unsupported, any[0])
+		}
+	} else {
+		q.Unsupported = append(q.Unsupported, any...)
+	}
+
+	q.Query = strings.Join(terms, " ")
+	return q
+}
+
+// conditionSearchTerms returns the search terms which must all match for the condition
+func conditionSearchTerms(c TriggerCondition) ([]string, bool) {
+	values := conditionValues(c.Value)
+
+	switch {
+	case c.Field == "status" || c.Field == "priority":
+		if len(values) != 1 || values[0] == "" {
+			return nil, false
+		}
+		switch c.Operator {
+		case "is":
+			return []string{c.Field + ":" + values[0]}, true
+		case "is_not":
+			return []string{"-" + c.Field + ":" + values[0]}, true
+		case "less_than":
+			return []string{c.Field + "<" + values[0]}, true
+		case "greater_than":
+			return []string{c.Field + ">" + values[0]}, true
+		}
+
+	case c.Field == "type":
+		if len(values) == 1 && values[0] != "" {
+			return negateIf(c.Operator, "ticket_type:"+values[0])
+		}
+
+	case c.Field == "satisfaction_score":
+		if len(values) == 1 && values[0] != "" && c.Operator == "is" {
+			return []string{"satisfaction:" + values[0]}, true
+		}
+
+	case conditionSearchKeywords[c.Field] != "":
+		if len(values) != 1 {
+			return nil, false
+		}
+		value := values[0]
+		switch value {
+		case "":
+			value = "none"
+		case "current_user":
+			if c.Field != "assignee_id" && c.Field != "requester_id" {
+				return nil, false
+			}
+			value = "me"
+		case "current_groups", "requester_id", "assignee_id":
+			return nil, false
+		}
+		return negateIf(c.Operator, conditionSearchKeywords[c.Field]+":"+quoteSearchValue(value))
+
+	case c.Field == "current_tags":
+		tags := strings.Fields(strings.Join(values, " "))
+		switch {
+		case len(tags) == 0:
+			return nil, false
+		case c.Operator == "includes" && len(tags) == 1:
+			return []string{"tags:" + tags[0]}, true
+		case c.Operator == "not_includes":
+			terms := make([]string, len(tags))
+			for i, tag := range tags {
+				terms[i] = "-tags:" + tag
+			}
+			return terms, true
+		}
+
+	case conditionSearchWords[c.Field] != "":
+		keyword := conditionSearchWords[c.Field]
+		words := strings.Fields(strings.Join(values, " "))
+		switch {
+		case len(words) == 0:
+			return nil, false
+		case c.Operator == "includes" && len(words) == 1:
+			return []string{keyword + ":" + quoteSearchValue(words[0])}, true
+		case c.Operator == "not_includes":
+			terms := make([]string, len(words))
+			for i, w := range words {
+				terms[i] = "-" + keyword + ":" + quoteSearchValue(w)
+			}
+			return terms, true
+		case c.Operator == "is":
+			return []string{keyword + ":" + quoteSearchValue(strings.Join(values, " "))}, true
+		}
+
+	case conditionSearchTimes[c.Field] != "":
+		if len(values) != 1 {
+			return nil, false
+		}
+		hours, err := strconv.Atoi(values[0])
+		if err != nil || hours < 0 {
+			return nil, false
+		}
+		// "less than N hours since" is after N hours ago
+		switch c.Operator {
+		case "less_than":
+			return []string{fmt.Sprintf("%s>%dhours", conditionSearchTimes[c.Field], hours)}, true
+		case "greater_than":
+			return []string{fmt.Sprintf("%s<%dhours", conditionSearchTimes[c.Field], hours)}, true
+		}
+
+	case strings.HasPrefix(c.Field, "custom_fields_"):
+		id := strings.TrimPrefix(c.Field, "custom_fields_")
+		if _, err := strconv.ParseInt(id, 10, 64); err != nil || len(values) != 1 || values[0] == "" {
+			return nil, false
+		}
+		return negateIf(c.Operator, "custom_field_"+id+":"+quoteSearchValue(values[0]))
+	}
+
+	return nil, false
+}
+
+// negateIf returns the term for the is operator and the negated term for the is_not operator
+func negateIf(operator, term string) ([]string, bool) {
+	switch operator {
+	case "is":
+		return []string{term}, true
+	case "is_not":
+		return []string{"-" + term}, true
+	}
+	return nil, false
+}
+
+// conditionValues returns the value of the condition as strings
+func conditionValues(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return []string{""}
+	case string:
+		return []string{v}
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	case int:
+		return []string{strconv.Itoa(v)}
+	case int64:
+		return []string{strconv.FormatInt(v, 10)}
+	case bool:
+		return []string{strconv.FormatBool(v)}
+	case []string:
+		return v
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			values = append(values, conditionValues(item)...)
+		}
+		return values
+	}
+	return []string{fmt.Sprint(value)}
+}
+
+// quoteSearchValue quotes the value when it contains spaces
+func quoteSearchValue(value string) string {
+	if strings.ContainsAny(value, " \t\"") {
+		return strconv.Quote(value)
+	}
+	return value
+}
@@ -0,0 +1,104 @@
+package zendesk
+
+import (
+	"testing"
+)
+
+func TestTranslateConditions(t *testing.T) {
+	cases := []struct {
+		name        string
+		all, any    []TriggerCondition
+		query       string
+		unsupported int
+	}{
+		{
+			name: "view fixture",
+			all: []TriggerCondition{
+				{Field: "status", Operator: "less_than", Value: "solved"},
+				{Field: "assignee_id", Operator: "is", Value: "current_user"},
+			},
+			query: "type:ticket status<solved assignee:me",
+		},
+		{
+			name: "references",
+			all: []TriggerCondition{
+				{Field: "group_id", Operator: "is", Value: float64(123)},
+				{Field: "organization_id", Operator: "is_not", Value: ""},
+				{Field: "assignee_id", Operator: "is", Value: nil},
+				{Field: "recipient", Operator: "is", Value: "support@example.com"},
+			},
+			query: "type:ticket group:123 -organization:none assignee:none recipient:support@example.com",
+		},
+		{
+			name: "tags and words",
+			all: []TriggerCondition{
+				{Field: "current_tags", Operator: "includes", Value: "vip"},
+				{Field: "current_tags", Operator: "not_includes", Value: "spam bounce"},
+				{Field: "subject_includes_word", Operator: "is", Value: "order status"},
+				{Field: "description_includes_word", Operator: "not_includes", Value: "test"},
+			},
+			query: `type:ticket tags:vip -tags:spam -tags:bounce subject:"order status" -description:test`,
+		},
+		{
+			name: "times and custom fields",
+			all: []TriggerCondition{
+				{Field: "updated_at", Operator: "less_than", Value: "24"},
+				{Field: "NEW", Operator: "greater_than", Value: "48"},
+				{Field: "custom_fields_360001", Operator: "is", Value: "plan pro"},
+				{Field: "type", Operator: "is_not", Value: "problem"},
+			},
+			query: `type:ticket updated>24hours created<48hours custom_field_360001:"plan pro" -ticket_type:problem`,
+		},
+		{
+			name: "unsupported",
+			all: []TriggerCondition{
+				{Field: "status", Operator: "is", Value: "open"},
+				{Field: "status", Operator: "changed", Value: nil},
+				{Field: "current_tags", Operator: "includes", Value: "a b"},
+				{Field: "comment_includes_word", Operator: "includes", Value: "refund"},
+				{Field: "group_id", Operator: "is", Value: "current_groups"},
+				{Field: "updated_at", Operator: "less_than_business_hours", Value: "2"},
+			},
+			query:       "type:ticket status:open",
+			unsupported: 5,
+		},
+		{
+			name:  "single any",
+			any:   []TriggerCondition{{Field: "priority", Operator: "greater_than", Value: "normal"}},
+			query: "type:ticket priority>normal",
+		},
+		{
+			name: "multiple any",
+			all:  []TriggerCondition{{Field: "status", Operator: "is", Value: "new"}},
+			any: []TriggerCondition{
+				{Field: "priority", Operator: "is", Value: "high"},
+				{Field: "priority", Operator: "is", Value: "urgent"},
+			},
+			query:       "type:ticket status:new",
+			unsupported: 2,
+		},
+	}
+
+	for _, c := range cases {
+		q := TranslateConditions(c.all, c.any)
+		if q.Query != c.query {
+			t.Fatalf("%s: expected query %q, but got %q", c.name, c.query, q.Query)
+		}
+		if len(q.Unsupported) != c.unsupported || q.Exact() != (c.unsupported == 0) {
+			t.Fatalf("%s: expected %d unsupported conditions, but got %+v", c.name, c.unsupported, q.Unsupported)
+		}
+	}
+}
+
+func TestTriggerSearchQuery(t *testing.T) {
+	var trigger Trigger
+	trigger.Conditions.All = []TriggerCondition{
+		{Field: "update_type", Operator: "is", Value: "Create"},
+		{Field: "brand_id", Operator: "is", Value: "7"},
+	}
+
+	q := TriggerSearchQuery(trigger)
+	if q.Query != "type:ticket brand:7" || len(q.Unsupported) != 1 || q.Unsupported[0].Field != "update_type" {
+		t.Fatalf("Unexpected query %+v", q)
+	}
+}
@@ -0,0 +1,137 @@
+package zendesk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ContentTag is a label which can be attached to Help Center articles and community posts
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/content_tags/
+type ContentTag struct {
+	ID        string    `json:"id,omitempty"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// ContentTagListOptions is options for GetContentTags.
+// Sort takes "name", "-name", "updated_at" or "-updated_at".
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/content_tags/#list-content-tags
+type ContentTagListOptions struct {
+	CursorPagination
+	NamePrefix string `url:"filter[name_prefix],omitempty"`
+	Sort       string `url:"sort,omitempty"`
+}
+
+// ContentTagAPI an interface containing all content tag related methods
+type ContentTagAPI interface {
+	GetContentTags(ctx context.Context, opts *ContentTagListOptions) ([]ContentTag, CursorPaginationMeta, error)
+	GetContentTag(ctx context.Context, id string) (ContentTag, error)
+	CreateContentTag(ctx context.Context, tag ContentTag) (ContentTag, error)
+	UpdateContentTag(ctx context.Context, id string, tag ContentTag) (ContentTag, error)
+	DeleteContentTag(ctx context.Context, id string) error
+}
+
+// GetContentTags fetch content tag list
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/content_tags/#list-content-tags
+func (z *Client) GetContentTags(ctx context.Context, opts *ContentTagListOptions) ([]ContentTag, CursorPaginationMeta, error) {
+	var data struct {
+		Records []ContentTag         `json:"records"`
+		Meta    CursorPaginationMeta `json:"meta"`
+	}
+
+	tmp := opts
+	if tmp == nil {
+		tmp = &ContentTagListOptions{}
+	}
+
+	u, err := addOptions("/guide/content_tags", tmp)
+	if err != nil {
+		return nil, CursorPaginationMeta{}, err
+	}
+
+	body, err := z.get(ctx, u)
+	if err != nil {
+		return nil, CursorPaginationMeta{}, err
+	}
+
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, CursorPaginationMeta{}, err
+	}
+	return data.Records, data.Meta, nil
+}
+
+// GetContentTag gets a specified content tag
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/content_tags/#show-content-tag
+func (z *Client) GetContentTag(ctx context.Context, id string) (ContentTag, error) {
+	var result struct {
+		ContentTag ContentTag `json:"content_tag"`
+	}
+
+	body, err := z.get(ctx, fmt.Sprintf("/guide/content_tags/%s", id))
+	if err != nil {
+		return ContentTag{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return ContentTag{}, err
+	}
+	return result.ContentTag, nil
+}
+
+// CreateContentTag creates new content tag
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/content_tags/#create-content-tag
+func (z *Client) CreateContentTag(ctx context.Context, tag ContentTag) (ContentTag, error) {
+	var data, result struct {
+		ContentTag ContentTag `json:"content_tag"`
+	}
+	data.ContentTag = tag
+
+	body, err := z.post(ctx, "/guide/content_tags", data)
+	if err != nil {
+		return ContentTag{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return ContentTag{}, err
+	}
+	return result.ContentTag, nil
+}
+
+// UpdateContentTag renames the specified content tag
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/content_tags/#update-content-tag
+func (z *Client) UpdateContentTag(ctx context.Context, id string, tag ContentTag) (ContentTag, error) {
+	var data, result struct {
+		ContentTag ContentTag `json:"content_tag"`
+	}
+	data.ContentTag = tag
+
+	body, err := z.put(ctx, fmt.Sprintf("/guide/content_tags/%s", id), data)
+	if err != nil {
+		return ContentTag{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return ContentTag{}, err
+	}
+	return result.ContentTag, nil
+}
+
+// DeleteContentTag deletes the specified content tag and removes it from all content
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/content_tags/#delete-content-tag
+func (z *Client) DeleteContentTag(ctx context.Context, id string) error {
+	return z.delete(ctx, fmt.Sprintf("/guide/content_tags/%s", id))
+}
@@ -0,0 +1,91 @@
+package zendesk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetContentTags(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/guide/content_tags" || r.URL.Query().Get("filter[name_prefix]") != "fe" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write(readFixture("GET/content_tags.json"))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	tags, meta, err := client.GetContentTags(ctx, &ContentTagListOptions{NamePrefix: "fe"})
+	if err != nil {
+		t.Fatalf("Failed to get content tags: %s", err)
+	}
+
+	if len(tags) != 2 {
+		t.Fatalf("expected length of content tags is 2, but got %d", len(tags))
+	}
+	if !meta.HasMore || meta.AfterCursor == "" {
+		t.Fatalf("Returned meta does not have the next cursor %+v", meta)
+	}
+}
+
+func TestGetContentTag(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "content_tag.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	tag, err := client.GetContentTag(ctx, "01GFXGBX7YZ9ASWTCVMASTK8ZS")
+	if err != nil {
+		t.Fatalf("Failed to get content tag: %s", err)
+	}
+
+	if tag.Name != "feature request" {
+		t.Fatalf("Returned content tag does not have the expected name %s", tag.Name)
+	}
+}
+
+func TestCreateContentTag(t *testing.T) {
+	mockAPI := newMockAPIWithStatus(http.MethodPost, "content_tag.json", http.StatusCreated)
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	tag, err := client.CreateContentTag(ctx, ContentTag{Name: "feature request"})
+	if err != nil {
+		t.Fatalf("Failed to create content tag: %s", err)
+	}
+
+	if tag.ID == "" {
+		t.Fatal("Created content tag should have an ID")
+	}
+}
+
+func TestUpdateContentTag(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodPut, "content_tag.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	tag, err := client.UpdateContentTag(ctx, "01GFXGBX7YZ9ASWTCVMASTK8ZS", ContentTag{Name: "feature request"})
+	if err != nil {
+		t.Fatalf("Failed to update content tag: %s", err)
+	}
+
+	if tag.Name != "feature request" {
+		t.Fatalf("Updated content tag does not have the expected name %s", tag.Name)
+	}
+}
+
+func TestDeleteContentTag(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/guide/content_tags/01GFXGBX7YZ9ASWTCVMASTK8ZS" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	err := client.DeleteContentTag(ctx, "01GFXGBX7YZ9ASWTCVMASTK8ZS")
+	if err != nil {
+		t.Fatalf("Failed to delete content tag: %s", err)
+	}
+}
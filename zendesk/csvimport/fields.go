@@ -0,0 +1,191 @@
+package csvimport
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// prefixes of the targets of custom fields
+const (
+	userFieldPrefix         = "user_fields."
+	organizationFieldPrefix = "organization_fields."
+)
+
+// TargetOrganization is the target of users which refers to an organization by external ID or name
+const TargetOrganization = "organization"
+
+// targets of users which add memberships. Their values are lists of organizations by
+// external ID or name and of groups by name or ID.
+const (
+	TargetOrganizations = "organizations"
+	TargetGroups        = "groups"
+)
+
+var userSetters = map[string]func(u *zendesk.User, value, sep string) error{
+	"name":        func(u *zendesk.User, v, _ string) error { u.Name = v; return nil },
+	"email":       setUserEmail,
+	"external_id": func(u *zendesk.User, v, _ string) error { u.ExternalID = v; return nil },
+	"phone":       func(u *zendesk.User, v, _ string) error { u.Phone = v; return nil },
+	"alias":       func(u *zendesk.User, v, _ string) error { u.Alias = v; return nil },
+	"details":     func(u *zendesk.User, v, _ string) error { u.Details = v; return nil },
+	"notes":       func(u *zendesk.User, v, _ string) error { u.Notes = v; return nil },
+	"locale":      func(u *zendesk.User, v, _ string) error { u.Locale = v; return nil },
+	"time_zone":   func(u *zendesk.User, v, _ string) error { u.Timezone = v; return nil },
+	"role":        setUserRole,
+	"verified":    func(u *zendesk.User, v, _ string) (err error) { u.Verified, err = parseBool(v); return },
+	"tags":        func(u *zendesk.User, v, sep string) error { u.Tags = splitList(v, sep); return nil },
+	"organization_id": func(u *zendesk.User, v, _ string) (err error) {
+		u.OrganizationID, err = strconv.ParseInt(v, 10, 64)
+		return
+	},
+	"default_group_id": func(u *zendesk.User, v, _ string) (err error) {
+		u.DefaultGroupID, err = strconv.ParseInt(v, 10, 64)
+		return
+	},
+}
+
+var organizationSetters = map[string]func(o *zendesk.Organization, value, sep string) error{
+	"name":         func(o *zendesk.Organization, v, _ string) error { o.Name = v; return nil },
+	"external_id":  func(o *zendesk.Organization, v, _ string) error { o.ExternalID = v; return nil },
+	"details":      func(o *zendesk.Organization, v, _ string) error { o.Details = v; return nil },
+	"notes":        func(o *zendesk.Organization, v, _ string) error { o.Notes = v; return nil },
+	"domain_names": func(o *zendesk.Organization, v, sep string) error { o.DomainNames = splitList(v, sep); return nil },
+	"tags":         func(o *zendesk.Organization, v, sep string) error { o.Tags = splitList(v, sep); return nil },
+	"group_id": func(o *zendesk.Organization, v, _ string) (err error) {
+		o.GroupID, err = strconv.ParseInt(v, 10, 64)
+		return
+	},
+	"shared_tickets": func(o *zendesk.Organization, v, _ string) (err error) {
+		o.SharedTickets, err = parseBool(v)
+		return
+	},
+	"shared_comments": func(o *zendesk.Organization, v, _ string) (err error) {
+		o.SharedComments, err = parseBool(v)
+		return
+	},
+}
+
+func setUserEmail(u *zendesk.User, v, _ string) error {
+	if i := strings.Index(v, "@"); i <= 0 || i == len(v)-1 || strings.ContainsAny(v, " \t") {
+		return fmt.Errorf("invalid email %q", v)
+	}
+	u.Email = v
+	return nil
+}
+
+func setUserRole(u *zendesk.User, v, _ string) error {
+	switch v {
+	case "end-user", "agent", "admin":
+		u.Role = v
+		return nil
+	}
+	return fmt.Errorf("invalid role %q", v)
+}
+
+// field is the definition of a custom field used to validate and convert the values
+type field struct {
+	key     string
+	typ     string
+	active  bool
+	pattern *regexp.Regexp
+	options []zendesk.CustomFieldOption
+}
+
+func newField(key, typ string, active bool, pattern string, options []zendesk.CustomFieldOption) (field, error) {
+	f := field{key: key, typ: typ, active: active, options: options}
+	if typ == "regexp" && pattern != "" {
+		var err error
+		if f.pattern, err = regexp.Compile(pattern); err != nil {
+			return f, fmt.Errorf("field %s has invalid regexp: %w", key, err)
+		}
+	}
+	return f, nil
+}
+
+// convert validates the value of the cell and converts it to the value of the field
+func (f field) convert(value, sep string) (interface{}, error) {
+	switch f.typ {
+	case "checkbox":
+		b, err := parseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.key, err)
+		}
+		return b, nil
+	case "integer", "lookup":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid integer %q", f.key, value)
+		}
+		return n, nil
+	case "decimal":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid decimal %q", f.key, value)
+		}
+		return n, nil
+	case "date":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return nil, fmt.Errorf("%s: invalid date %q, expected YYYY-MM-DD", f.key, value)
+		}
+		return value, nil
+	case "regexp":
+		if f.pattern != nil && !f.pattern.MatchString(value) {
+			return nil, fmt.Errorf("%s: %q does not match %s", f.key, value, f.pattern)
+		}
+		return value, nil
+	case "dropdown", "tagger":
+		return f.option(value)
+	case "multiselect":
+		var values []string
+		for _, v := range splitList(value, sep) {
+			o, err := f.option(v)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, o)
+		}
+		return values, nil
+	}
+	return value, nil
+}
+
+// option returns the value of the option whose value or name matches
+func (f field) option(value string) (string, error) {
+	for _, o := range f.options {
+		if o.Value == value {
+			return o.Value, nil
+		}
+	}
+	for _, o := range f.options {
+		if strings.EqualFold(o.Name, value) {
+			return o.Value, nil
+		}
+	}
+	return "", fmt.Errorf("%s: %q is not an option", f.key, value)
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true", "yes", "y", "1":
+		return true, nil
+	case "false", "no", "n", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid boolean %q", value)
+}
+
+// splitList splits the value by the separator and drops empty items
+func splitList(value, sep string) []string {
+	var items []string
+	for _, item := range strings.Split(value, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
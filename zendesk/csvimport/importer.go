@@ -0,0 +1,746 @@
+// Package csvimport imports users and organizations from CSV files.
+// The columns are mapped onto the fields of zendesk.User and zendesk.Organization,
+// their custom fields, tags and the organization and group memberships of users.
+// Rows are validated against the custom field definitions of the account, upserted
+// in bulk by email or external ID, and the result of every row is written as CSV.
+package csvimport
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// Kind is the kind of the imported resources
+type Kind string
+
+// kinds of the imported resources
+const (
+	KindUser         Kind = "user"
+	KindOrganization Kind = "organization"
+)
+
+// statuses of the rows in the result CSV
+const (
+	StatusCreated = "created"
+	StatusUpdated = "updated"
+	StatusValid   = "valid"
+	StatusInvalid = "invalid"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+// maxBatchSize is the maximum number of items of a bulk request
+const maxBatchSize = 100
+
+// Config is configuration of the Importer
+type Config struct {
+	Kind Kind
+
+	// Columns maps the CSV column headers to their targets, which are the JSON names of
+	// the fields of zendesk.User or zendesk.Organization, such as "email" or "tags",
+	// "user_fields.<key>" and "organization_fields.<key>" for custom fields,
+	// "organization" for the organization of users by external ID or name, and
+	// "organizations" and "groups" for lists of organization and group memberships
+	// of users. Columns which are not mapped are ignored.
+	Columns map[string]string
+
+	// ListSeparator separates the items of tags, domain names and multiselect fields. Defaults to ",".
+	ListSeparator string
+
+	// DryRun only validates the rows
+	DryRun bool
+
+	// BatchSize is the number of items of a bulk request. Defaults to and is capped at 100.
+	BatchSize int
+
+	// PollInterval is the interval to poll the job statuses of bulk requests. Defaults to 1 second.
+	PollInterval time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.ListSeparator == "" {
+		c.ListSeparator = ","
+	}
+	if c.BatchSize <= 0 || c.BatchSize > maxBatchSize {
+		c.BatchSize = maxBatchSize
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	return c
+}
+
+// Result is the number of rows by status
+type Result struct {
+	Created int
+	Updated int
+	Valid   int
+	Invalid int
+	Failed  int
+	Skipped int
+}
+
+// Importer imports users or organizations from CSV
+type Importer struct {
+	api zendesk.API
+	cfg Config
+}
+
+// New returns an Importer. It fails when the kind or a target of the columns is unknown.
+func New(api zendesk.API, cfg Config) (*Importer, error) {
+	cfg = cfg.withDefaults()
+	if cfg.Kind != KindUser && cfg.Kind != KindOrganization {
+		return nil, fmt.Errorf("csvimport: unknown kind %q", cfg.Kind)
+	}
+
+	for column, target := range cfg.Columns {
+		var ok bool
+		switch cfg.Kind {
+		case KindUser:
+			_, ok = userSetters[target]
+			ok = ok || target == TargetOrganization || target == TargetOrganizations || target == TargetGroups ||
+				strings.HasPrefix(target, userFieldPrefix)
+		case KindOrganization:
+			_, ok = organizationSetters[target]
+			ok = ok || strings.HasPrefix(target, organizationFieldPrefix)
+		}
+		if !ok {
+			return nil, fmt.Errorf("csvimport: column %q has unknown target %q", column, target)
+		}
+	}
+
+	return &Importer{api: api, cfg: cfg}, nil
+}
+
+// row is a data row of the CSV
+type row struct {
+	line   int
+	values map[string]string
+
+	user zendesk.User
+	org  zendesk.Organization
+
+	// memberships added to the user
+	orgIDs   []int64
+	groupIDs []int64
+
+	key    string
+	status string
+	id     int64
+	err    string
+}
+
+func (r *row) fail(status string, err error) {
+	r.status = status
+	r.err = err.Error()
+}
+
+// Import reads the CSV from in, upserts the valid rows and writes the result of every row to out
+// with the columns row (line number), key, status, id and error. Empty cells are ignored, and
+// lists such as tags replace the existing values. Memberships are added to the users after
+// they are upserted, and memberships which are not in the CSV are kept.
+// An error is returned when the CSV or the configuration is invalid or a request fails.
+// The rows which were not processed because of a failed request have the status skipped.
+func (im *Importer) Import(ctx context.Context, in io.Reader, out io.Writer) (Result, error) {
+	rows, err := im.read(in)
+	if err != nil {
+		return Result{}, err
+	}
+
+	fields, err := im.fields(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var orgs *orgIndex
+	if im.cfg.Kind == KindOrganization || im.mapped(TargetOrganization) || im.mapped(TargetOrganizations) {
+		if orgs, err = im.organizations(ctx); err != nil {
+			return Result{}, err
+		}
+	}
+	var groups map[string]int64
+	if im.mapped(TargetGroups) {
+		if groups, err = im.groups(ctx); err != nil {
+			return Result{}, err
+		}
+	}
+
+	keys := map[string]int{}
+	var valid []*row
+	for _, r := range rows {
+		if err := im.build(r, fields, orgs, groups); err != nil {
+			for _, target := range []string{"email", "external_id", "name"} {
+				if r.key = r.values[target]; r.key != "" {
+					break
+				}
+			}
+			r.fail(StatusInvalid, err)
+			continue
+		}
+		if first, ok := keys[r.key]; ok {
+			r.fail(StatusInvalid, fmt.Errorf("duplicate of row %d", first))
+			continue
+		}
+		keys[r.key] = r.line
+		r.status = StatusValid
+		valid = append(valid, r)
+	}
+
+	var importErr error
+	if !im.cfg.DryRun {
+		importErr = im.upsert(ctx, valid)
+		if importErr == nil {
+			importErr = im.addMemberships(ctx, valid)
+		}
+		if importErr != nil {
+			for _, r := range valid {
+				if r.status == StatusValid {
+					r.fail(StatusSkipped, importErr)
+				}
+			}
+		}
+	}
+
+	result, err := writeResults(out, rows)
+	if importErr != nil {
+		return result, importErr
+	}
+	return result, err
+}
+
+// read reads the rows and maps their values to the targets
+func (im *Importer) read(in io.Reader) ([]*row, error) {
+	cr := csv.NewReader(in)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("csvimport: failed to read header: %w", err)
+	}
+	targets := make([]string, len(header))
+	found := map[string]bool{}
+	for i, column := range header {
+		column = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		targets[i] = im.cfg.Columns[column]
+		found[column] = true
+	}
+	for column := range im.cfg.Columns {
+		if !found[column] {
+			return nil, fmt.Errorf("csvimport: column %q is not in the CSV", column)
+		}
+	}
+
+	var rows []*row
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("csvimport: %w", err)
+		}
+
+		line, _ := cr.FieldPos(0)
+		r := &row{line: line, values: map[string]string{}}
+		for i, value := range record {
+			if i < len(targets) && targets[i] != "" {
+				if value = strings.TrimSpace(value); value != "" {
+					r.values[targets[i]] = value
+				}
+			}
+		}
+		rows = append(rows, r)
+	}
+}
+
+// mapped reports whether a column is mapped to the target
+func (im *Importer) mapped(target string) bool {
+	for _, t := range im.cfg.Columns {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+// fields fetches the definitions of the custom fields and checks the mapped ones exist and are active
+func (im *Importer) fields(ctx context.Context) (map[string]field, error) {
+	prefix := userFieldPrefix
+	if im.cfg.Kind == KindOrganization {
+		prefix = organizationFieldPrefix
+	}
+
+	var keys []string
+	for _, target := range im.cfg.Columns {
+		if strings.HasPrefix(target, prefix) {
+			keys = append(keys, strings.TrimPrefix(target, prefix))
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	fields := map[string]field{}
+	add := func(key, typ string, active bool, pattern string, options []zendesk.CustomFieldOption) error {
+		f, err := newField(key, typ, active, pattern, options)
+		fields[key] = f
+		return err
+	}
+
+	if im.cfg.Kind == KindUser {
+		it := im.api.GetUserFieldsIterator(ctx, zendesk.NewPaginationOptions())
+		for it.HasMore() {
+			page, err := it.GetNext()
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range page {
+				if err := add(f.Key, f.Type, f.Active, f.RegexpForValidation, f.CustomFieldOptions); err != nil {
+					return nil, fmt.Errorf("csvimport: %w", err)
+				}
+			}
+		}
+	} else {
+		it := im.api.GetOrganizationFieldsIterator(ctx, zendesk.NewPaginationOptions())
+		for it.HasMore() {
+			page, err := it.GetNext()
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range page {
+				if err := add(f.Key, f.Type, f.Active, f.RegexpForValidation, f.CustomFieldOptions); err != nil {
+					return nil, fmt.Errorf("csvimport: %w", err)
+				}
+			}
+		}
+	}
+
+	for _, key := range keys {
+		f, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("csvimport: field %s does not exist", key)
+		}
+		if !f.active {
+			return nil, fmt.Errorf("csvimport: field %s is not active", key)
+		}
+	}
+	return fields, nil
+}
+
+// orgIndex finds organizations by external ID or name
+type orgIndex struct {
+	byExternalID map[string]zendesk.Organization
+	byName       map[string]zendesk.Organization
+}
+
+func (idx *orgIndex) find(externalID, name string) (zendesk.Organization, bool) {
+	if o, ok := idx.byExternalID[externalID]; ok && externalID != "" {
+		return o, true
+	}
+	o, ok := idx.byName[strings.ToLower(name)]
+	return o, ok && name != ""
+}
+
+// organizations fetches the organizations of the account
+func (im *Importer) organizations(ctx context.Context) (*orgIndex, error) {
+	idx := &orgIndex{byExternalID: map[string]zendesk.Organization{}, byName: map[string]zendesk.Organization{}}
+
+	it := im.api.GetOrganizationsIterator(ctx, zendesk.NewPaginationOptions())
+	for it.HasMore() {
+		page, err := it.GetNext()
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range page {
+			if o.ExternalID != "" {
+				idx.byExternalID[o.ExternalID] = o
+			}
+			idx.byName[strings.ToLower(o.Name)] = o
+		}
+	}
+	return idx, nil
+}
+
+// groups fetches the groups of the account keyed by lower case name and by ID
+func (im *Importer) groups(ctx context.Context) (map[string]int64, error) {
+	groups := map[string]int64{}
+	it := im.api.GetGroupsIterator(ctx, zendesk.NewPaginationOptions())
+	for it.HasMore() {
+		page, err := it.GetNext()
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range page {
+			groups[strings.ToLower(g.Name)] = g.ID
+			groups[strconv.FormatInt(g.ID, 10)] = g.ID
+		}
+	}
+	return groups, nil
+}
+
+// build validates the values of the row and builds its user or organization
+func (im *Importer) build(r *row, fields map[string]field, orgs *orgIndex, groups map[string]int64) error {
+	targets := make([]string, 0, len(r.values))
+	for target := range r.values {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	sep := im.cfg.ListSeparator
+	if im.cfg.Kind == KindUser {
+		u := &r.user
+		u.UserFields = zendesk.UserFields{}
+		for _, target := range targets {
+			value := r.values[target]
+			switch {
+			case strings.HasPrefix(target, userFieldPrefix):
+				key := strings.TrimPrefix(target, userFieldPrefix)
+				v, err := fields[key].convert(value, sep)
+				if err != nil {
+					return err
+				}
+				u.UserFields[key] = v
+			case target == TargetOrganization:
+				o, ok := orgs.find(value, value)
+				if !ok {
+					return fmt.Errorf("organization %q does not exist", value)
+				}
+				u.OrganizationID = o.ID
+			case target == TargetOrganizations:
+				for _, item := range splitList(value, sep) {
+					o, ok := orgs.find(item, item)
+					if !ok {
+						return fmt.Errorf("organization %q does not exist", item)
+					}
+					r.orgIDs = append(r.orgIDs, o.ID)
+				}
+			case target == TargetGroups:
+				for _, item := range splitList(value, sep) {
+					id, ok := groups[strings.ToLower(item)]
+					if !ok {
+						return fmt.Errorf("group %q does not exist", item)
+					}
+					r.groupIDs = append(r.groupIDs, id)
+				}
+			default:
+				if err := userSetters[target](u, value, sep); err != nil {
+					return fmt.Errorf("%s: %w", target, err)
+				}
+			}
+		}
+
+		if u.Email == "" && u.ExternalID == "" {
+			return errors.New("email or external_id is required")
+		}
+		if u.Name == "" {
+			return errors.New("name is required")
+		}
+		r.key = u.Email
+		if r.key == "" {
+			r.key = u.ExternalID
+		}
+		r.key = strings.ToLower(r.key)
+		return nil
+	}
+
+	// existing organizations are updated with only the mapped fields, see organizationUpdate
+	o := &r.org
+	if existing, ok := orgs.find(r.values["external_id"], r.values["name"]); ok {
+		o.ID = existing.ID
+	}
+	for _, target := range targets {
+		value := r.values[target]
+		if strings.HasPrefix(target, organizationFieldPrefix) {
+			key := strings.TrimPrefix(target, organizationFieldPrefix)
+			v, err := fields[key].convert(value, sep)
+			if err != nil {
+				return err
+			}
+			if o.OrganizationFields == nil {
+				o.OrganizationFields = map[string]interface{}{}
+			}
+			o.OrganizationFields[key] = v
+			continue
+		}
+		if err := organizationSetters[target](o, value, sep); err != nil {
+			return fmt.Errorf("%s: %w", target, err)
+		}
+	}
+
+	if o.Name == "" && o.ID == 0 {
+		return errors.New("name is required")
+	}
+	r.key = strings.ToLower(o.ExternalID)
+	if r.key == "" {
+		r.key = strings.ToLower(o.Name)
+	}
+	return nil
+}
+
+// organizationUpdate returns the payload which updates the organization of
+// the row. It has only the ID and the mapped fields, as zendesk.Organization
+// always sends its domains, tags and sharing settings, and update_many would
+// overwrite them.
+func organizationUpdate(r *row) (map[string]interface{}, error) {
+	b, err := json.Marshal(r.org)
+	if err != nil {
+		return nil, err
+	}
+	var org map[string]interface{}
+	if err := json.Unmarshal(b, &org); err != nil {
+		return nil, err
+	}
+
+	update := map[string]interface{}{"id": r.org.ID}
+	for target := range r.values {
+		if !strings.HasPrefix(target, organizationFieldPrefix) {
+			update[target] = org[target]
+		}
+	}
+	if len(r.org.OrganizationFields) > 0 {
+		update["organization_fields"] = r.org.OrganizationFields
+	}
+	return update, nil
+}
+
+// upsert creates or updates the users or organizations of the valid rows in batches
+func (im *Importer) upsert(ctx context.Context, rows []*row) error {
+	if im.cfg.Kind == KindUser {
+		return im.inBatches(ctx, rows, "", func(batch []*row) (zendesk.JobStatus, error) {
+			users := make([]zendesk.User, len(batch))
+			for i, r := range batch {
+				users[i] = r.user
+			}
+			return im.api.CreateOrUpdateManyUsers(ctx, users)
+		})
+	}
+
+	var creates, updates []*row
+	for _, r := range rows {
+		if r.org.ID == 0 {
+			creates = append(creates, r)
+		} else {
+			updates = append(updates, r)
+		}
+	}
+	err := im.inBatches(ctx, creates, StatusCreated, func(batch []*row) (zendesk.JobStatus, error) {
+		orgs := make([]zendesk.Organization, len(batch))
+		for i, r := range batch {
+			orgs[i] = r.org
+		}
+		return im.api.CreateManyOrganizations(ctx, orgs)
+	})
+	if err != nil {
+		return err
+	}
+	return im.inBatches(ctx, updates, StatusUpdated, func(batch []*row) (zendesk.JobStatus, error) {
+		orgs := make([]map[string]interface{}, len(batch))
+		for i, r := range batch {
+			if orgs[i], err = organizationUpdate(r); err != nil {
+				return zendesk.JobStatus{}, err
+			}
+		}
+		body, err := im.api.Put(ctx, "/organizations/update_many.json", map[string]interface{}{"organizations": orgs})
+		if err != nil {
+			return zendesk.JobStatus{}, err
+		}
+		var data struct {
+			JobStatus zendesk.JobStatus `json:"job_status"`
+		}
+		err = json.Unmarshal(body, &data)
+		return data.JobStatus, err
+	})
+}
+
+// addMemberships adds the organization and group memberships of the upserted rows
+// which their users do not have yet. A membership which is rejected fails its row.
+func (im *Importer) addMemberships(ctx context.Context, rows []*row) error {
+	for _, r := range rows {
+		if r.status != StatusCreated && r.status != StatusUpdated || r.id == 0 {
+			continue
+		}
+		if len(r.orgIDs) == 0 && len(r.groupIDs) == 0 {
+			continue
+		}
+
+		err := im.addUserMemberships(ctx, r)
+		var zerr zendesk.Error
+		if errors.As(err, &zerr) && zerr.Status() < http.StatusInternalServerError {
+			r.fail(StatusFailed, fmt.Errorf("user %d was saved, but its memberships failed: %w", r.id, err))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (im *Importer) addUserMemberships(ctx context.Context, r *row) error {
+	opts := zendesk.NewPaginationOptions()
+	opts.UserID = r.id
+
+	if len(r.orgIDs) > 0 {
+		member := map[int64]bool{}
+		it := im.api.GetOrganizationMembershipsIterator(ctx, opts)
+		for it.HasMore() {
+			page, err := it.GetNext()
+			if err != nil {
+				return err
+			}
+			for _, m := range page {
+				member[m.OrganizationID] = true
+			}
+		}
+		for _, id := range r.orgIDs {
+			if member[id] {
+				continue
+			}
+			_, err := im.api.CreateOrganizationMembership(ctx, zendesk.OrganizationMembershipOptions{
+				OrganizationID: id,
+				UserID:         r.id,
+			})
+			if err != nil {
+				return err
+			}
+			member[id] = true
+		}
+	}
+
+	if len(r.groupIDs) > 0 {
+		member := map[int64]bool{}
+		it := im.api.GetGroupMembershipsIterator(ctx, opts)
+		for it.HasMore() {
+			page, err := it.GetNext()
+			if err != nil {
+				return err
+			}
+			for _, m := range page {
+				member[m.GroupID] = true
+			}
+		}
+		for _, id := range r.groupIDs {
+			if member[id] {
+				continue
+			}
+			if _, err := im.api.CreateGroupMembership(ctx, zendesk.GroupMembership{UserID: r.id, GroupID: id}); err != nil {
+				return err
+			}
+			member[id] = true
+		}
+	}
+	return nil
+}
+
+// inBatches sends each batch of the rows, waits for its job and records the results in the rows.
+// The status of succeeded rows is status, or derived from the action of the job result when it is empty.
+func (im *Importer) inBatches(ctx context.Context, rows []*row, status string, send func(batch []*row) (zendesk.JobStatus, error)) error {
+	for from := 0; from < len(rows); from += im.cfg.BatchSize {
+		to := from + im.cfg.BatchSize
+		if to > len(rows) {
+			to = len(rows)
+		}
+		batch := rows[from:to]
+
+		job, err := send(batch)
+		if err != nil {
+			return err
+		}
+		job, err = zendesk.WaitJobStatus(ctx, im.api, job, im.cfg.PollInterval)
+		if err != nil {
+			return err
+		}
+		if job.Status != zendesk.JobStatusCompleted {
+			for _, r := range batch {
+				r.fail(StatusFailed, fmt.Errorf("job %s is %s: %s", job.ID, job.Status, job.Message))
+			}
+			continue
+		}
+
+		for _, res := range job.Results {
+			r := resultRow(batch, res)
+			if r == nil {
+				continue
+			}
+			if res.Error != "" {
+				r.fail(StatusFailed, errors.New(strings.TrimSpace(res.Error+" "+res.Details)))
+				continue
+			}
+			r.id = res.ID
+			r.status = status
+			if r.status == "" {
+				r.status = StatusUpdated
+				if strings.Contains(strings.ToLower(res.Action+res.Status), "create") {
+					r.status = StatusCreated
+				}
+			}
+		}
+		for _, r := range batch {
+			if r.status == StatusValid {
+				r.fail(StatusFailed, fmt.Errorf("job %s has no result", job.ID))
+			}
+		}
+	}
+	return nil
+}
+
+// resultRow returns the row of a job result. Results have the index of the
+// item in the batch, or only identify it by its ID, email or external ID.
+func resultRow(batch []*row, res zendesk.JobStatusResult) *row {
+	if res.Index != nil {
+		if *res.Index < 0 || *res.Index >= len(batch) {
+			return nil
+		}
+		return batch[*res.Index]
+	}
+
+	for _, r := range batch {
+		switch {
+		case res.ID != 0 && res.ID == r.org.ID:
+			return r
+		case res.Email != "" && strings.EqualFold(res.Email, r.user.Email):
+			return r
+		case res.ExternalID != "" && (res.ExternalID == r.user.ExternalID || res.ExternalID == r.org.ExternalID):
+			return r
+		}
+	}
+	return nil
+}
+
+// writeResults writes the result of every row and counts the statuses
+func writeResults(out io.Writer, rows []*row) (Result, error) {
+	var result Result
+	counts := map[string]*int{
+		StatusCreated: &result.Created,
+		StatusUpdated: &result.Updated,
+		StatusValid:   &result.Valid,
+		StatusInvalid: &result.Invalid,
+		StatusFailed:  &result.Failed,
+		StatusSkipped: &result.Skipped,
+	}
+
+	w := csv.NewWriter(out)
+	w.Write([]string{"row", "key", "status", "id", "error"})
+	for _, r := range rows {
+		*counts[r.status]++
+
+		id := ""
+		if r.id != 0 {
+			id = strconv.FormatInt(r.id, 10)
+		}
+		w.Write([]string{strconv.Itoa(r.line), r.key, r.status, id, r.err})
+	}
+	w.Flush()
+	return result, w.Error()
+}
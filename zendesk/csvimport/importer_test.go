@@ -0,0 +1,348 @@
+package csvimport
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+type testServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	users    []map[string]interface{}
+	created  []zendesk.Organization
+	updated  []map[string]interface{}
+	requests map[string]int
+
+	// memberships created, keyed by user ID
+	orgMembers   map[float64][]float64
+	groupMembers map[float64][]float64
+}
+
+// newTestServer is a fake account with an organization and user and organization fields
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	ts := &testServer{requests: map[string]int{}, orgMembers: map[float64][]float64{}, groupMembers: map[float64][]float64{}}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+		ts.requests[r.Method+" "+r.URL.Path]++
+
+		completed := func(results string) {
+			w.Write([]byte(`{"job_status":{"id":"job","status":"completed","results":[` + results + `]}}`))
+		}
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /user_fields.json":
+			w.Write([]byte(`{"user_fields":[
+				{"key":"plan","type":"dropdown","active":true,"custom_field_options":[{"name":"Free","value":"plan_free"},{"name":"Pro","value":"plan_pro"}]},
+				{"key":"seats","type":"integer","active":true},
+				{"key":"legacy","type":"text","active":false}
+			],"meta":{"has_more":false}}`))
+		case "GET /organization_fields.json":
+			w.Write([]byte(`{"organization_fields":[{"key":"vip","type":"checkbox","active":true}],"meta":{"has_more":false}}`))
+		case "GET /organizations.json":
+			w.Write([]byte(`{"organizations":[{"id":10,"name":"Acme","external_id":"acme","group_id":3,"shared_tickets":true,
+				"tags":["enterprise"],"organization_fields":{"vip":false,"tier":"gold"}},
+				{"id":11,"name":"Globex","external_id":"globex"}],"meta":{"has_more":false}}`))
+		case "POST /users/create_or_update_many.json":
+			var data struct {
+				Users []map[string]interface{} `json:"users"`
+			}
+			json.NewDecoder(r.Body).Decode(&data)
+			ts.users = append(ts.users, data.Users...)
+			if len(data.Users) == 2 {
+				completed(`{"id":100,"index":0,"action":"create","status":"Created"},{"index":1,"error":"InvalidValue","details":"Phone is invalid"}`)
+				return
+			}
+			// results of updates are identified by email instead of index
+			completed(fmt.Sprintf(`{"id":101,"email":%q,"action":"update","status":"Updated"}`, data.Users[0]["email"]))
+		case "GET /groups.json":
+			w.Write([]byte(`{"groups":[{"id":3,"name":"Support"},{"id":4,"name":"Billing"}],"meta":{"has_more":false}}`))
+		case "GET /organization_memberships.json":
+			// user 101 is already a member of Acme
+			if r.URL.Query().Get("user_id") != "101" {
+				t.Errorf("Unexpected memberships request %s", r.URL)
+			}
+			w.Write([]byte(`{"organization_memberships":[{"id":1,"user_id":101,"organization_id":10}],"meta":{"has_more":false}}`))
+		case "GET /group_memberships.json":
+			w.Write([]byte(`{"group_memberships":[],"meta":{"has_more":false}}`))
+		case "POST /organization_memberships.json":
+			var data struct {
+				Membership map[string]float64 `json:"organization_membership"`
+			}
+			json.NewDecoder(r.Body).Decode(&data)
+			userID := data.Membership["user_id"]
+			ts.orgMembers[userID] = append(ts.orgMembers[userID], data.Membership["organization_id"])
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"organization_membership":{"id":2}}`))
+		case "POST /group_memberships.json":
+			var data struct {
+				Membership map[string]float64 `json:"group_membership"`
+			}
+			json.NewDecoder(r.Body).Decode(&data)
+			userID := data.Membership["user_id"]
+			ts.groupMembers[userID] = append(ts.groupMembers[userID], data.Membership["group_id"])
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"group_membership":{"id":3}}`))
+		case "POST /organizations/create_many.json":
+			var data struct {
+				Organizations []zendesk.Organization `json:"organizations"`
+			}
+			json.NewDecoder(r.Body).Decode(&data)
+			ts.created = append(ts.created, data.Organizations...)
+			completed(`{"id":20,"index":0}`)
+		case "PUT /organizations/update_many.json":
+			var data struct {
+				Organizations []map[string]interface{} `json:"organizations"`
+			}
+			json.NewDecoder(r.Body).Decode(&data)
+			ts.updated = append(ts.updated, data.Organizations...)
+			// results of updates have no index and are not in the order of the request
+			var results []string
+			for i := len(data.Organizations) - 1; i >= 0; i-- {
+				results = append(results, fmt.Sprintf(`{"id":%v,"status":"Updated"}`, data.Organizations[i]["id"]))
+			}
+			completed(strings.Join(results, ","))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func newTestClient(url string) *zendesk.Client {
+	client, _ := zendesk.NewClient(nil)
+	client.SetEndpointURL(url)
+	return client
+}
+
+func readResults(t *testing.T, out *bytes.Buffer) [][]string {
+	t.Helper()
+	records, err := csv.NewReader(out).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read result CSV: %s", err)
+	}
+	return records[1:]
+}
+
+const usersCSV = `Name,E-mail,Company,Plan,Seats,Labels,Ignored
+Jane,jane@example.com,acme,Pro,5,"vip, beta",x
+John,john@example.com,Acme,plan_free,,,x
+Bad,bad@example.com,Acme,Enterprise,,,x
+Nobody,,Acme,,,,x
+Jane Again,JANE@example.com,,,,,x
+Ghost,ghost@example.com,Umbrella,,,,x
+Max,max@example.com,,,10,,x
+`
+
+func TestImportUsers(t *testing.T) {
+	ts := newTestServer(t)
+	im, err := New(newTestClient(ts.URL), Config{
+		Kind: KindUser,
+		Columns: map[string]string{
+			"Name":    "name",
+			"E-mail":  "email",
+			"Company": "organization",
+			"Plan":    "user_fields.plan",
+			"Seats":   "user_fields.seats",
+			"Labels":  "tags",
+		},
+		BatchSize: 2,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create importer: %s", err)
+	}
+
+	var out bytes.Buffer
+	result, err := im.Import(context.Background(), strings.NewReader(usersCSV), &out)
+	if err != nil {
+		t.Fatalf("Failed to import users: %s", err)
+	}
+
+	if result.Created != 1 || result.Updated != 1 || result.Failed != 1 || result.Invalid != 4 {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+
+	expected := [][]string{
+		{"2", "jane@example.com", StatusCreated, "100", ""},
+		{"3", "john@example.com", StatusFailed, "", "InvalidValue Phone is invalid"},
+		{"4", "bad@example.com", StatusInvalid, "", `plan: "Enterprise" is not an option`},
+		{"5", "Nobody", StatusInvalid, "", "email or external_id is required"},
+		{"6", "jane@example.com", StatusInvalid, "", "duplicate of row 2"},
+		{"7", "ghost@example.com", StatusInvalid, "", `organization "Umbrella" does not exist`},
+		{"8", "max@example.com", StatusUpdated, "101", ""},
+	}
+	rows := readResults(t, &out)
+	if len(rows) != len(expected) {
+		t.Fatalf("Unexpected result rows %v", rows)
+	}
+	for i := range expected {
+		if strings.Join(rows[i], "|") != strings.Join(expected[i], "|") {
+			t.Fatalf("Expected row %v, but got %v", expected[i], rows[i])
+		}
+	}
+
+	jane := ts.users[0]
+	fields, _ := jane["user_fields"].(map[string]interface{})
+	if jane["organization_id"] != float64(10) || fields["plan"] != "plan_pro" || fields["seats"] != float64(5) {
+		t.Fatalf("Unexpected user %v", jane)
+	}
+	if tags, _ := jane["tags"].([]interface{}); len(tags) != 2 || tags[1] != "beta" {
+		t.Fatalf("Tags should be split: %v", jane["tags"])
+	}
+}
+
+func TestImportUserMemberships(t *testing.T) {
+	ts := newTestServer(t)
+	im, err := New(newTestClient(ts.URL), Config{
+		Kind:    KindUser,
+		Columns: map[string]string{"Name": "name", "E-mail": "email", "Orgs": "organizations", "Groups": "groups"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create importer: %s", err)
+	}
+
+	csvData := "Name,E-mail,Orgs,Groups\nMax,max@example.com,\"acme, Globex\",\"support, 4\"\nGhost,ghost@example.com,,Sales\n"
+	var out bytes.Buffer
+	result, err := im.Import(context.Background(), strings.NewReader(csvData), &out)
+	if err != nil {
+		t.Fatalf("Failed to import users: %s", err)
+	}
+	if result.Updated != 1 || result.Invalid != 1 {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+
+	// the existing membership of Acme is kept and only Globex is added
+	if orgs := ts.orgMembers[101]; len(orgs) != 1 || orgs[0] != 11 {
+		t.Fatalf("Unexpected organization memberships %v", ts.orgMembers)
+	}
+	if groups := ts.groupMembers[101]; len(groups) != 2 || groups[0] != 3 || groups[1] != 4 {
+		t.Fatalf("Unexpected group memberships %v", ts.groupMembers)
+	}
+	if rows := readResults(t, &out); rows[1][4] != `group "Sales" does not exist` {
+		t.Fatalf("Unexpected result rows %v", rows)
+	}
+}
+
+func TestImportOrganizations(t *testing.T) {
+	ts := newTestServer(t)
+	im, _ := New(newTestClient(ts.URL), Config{
+		Kind: KindOrganization,
+		Columns: map[string]string{
+			"id":      "external_id",
+			"name":    "name",
+			"domains": "domain_names",
+			"vip":     "organization_fields.vip",
+		},
+	})
+
+	csvData := "id,name,domains,vip\nacme,Acme Corp,acme.com,yes\nnew,New Co,\"new.example, new.test\",maybe\nglobex,,,no\n"
+	var out bytes.Buffer
+	result, err := im.Import(context.Background(), strings.NewReader(csvData), &out)
+	if err != nil {
+		t.Fatalf("Failed to import organizations: %s", err)
+	}
+	if result.Invalid != 1 || result.Updated != 2 || result.Created != 0 {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+
+	if len(ts.updated) != 2 {
+		t.Fatalf("Existing organization should be updated: %v", ts.updated)
+	}
+	// only the mapped fields are sent, so update_many keeps the others
+	acme := ts.updated[0]
+	fields, _ := acme["organization_fields"].(map[string]interface{})
+	if acme["id"] != float64(10) || acme["name"] != "Acme Corp" || len(fields) != 1 || fields["vip"] != true {
+		t.Fatalf("Mapped fields should be updated: %v", acme)
+	}
+	for _, key := range []string{"group_id", "shared_tickets", "shared_comments", "tags", "created_at"} {
+		if _, ok := acme[key]; ok {
+			t.Fatalf("Field %s is not mapped and should not be sent: %v", key, acme)
+		}
+	}
+
+	rows := readResults(t, &out)
+	if rows[1][2] != StatusInvalid || rows[1][4] != `vip: invalid boolean "maybe"` {
+		t.Fatalf("Unexpected result rows %v", rows)
+	}
+	if rows[0][3] != "10" || rows[2][2] != StatusUpdated || rows[2][3] != "11" {
+		t.Fatalf("Results should be matched to the rows by ID %v", rows)
+	}
+}
+
+func TestImportOrganizationsWithSeparator(t *testing.T) {
+	ts := newTestServer(t)
+	im, _ := New(newTestClient(ts.URL), Config{
+		Kind:          KindOrganization,
+		Columns:       map[string]string{"name": "name", "domains": "domain_names"},
+		ListSeparator: ";",
+	})
+
+	var out bytes.Buffer
+	result, err := im.Import(context.Background(), strings.NewReader("name,domains\nNew Co,new.example;new.test\n"), &out)
+	if err != nil {
+		t.Fatalf("Failed to import organizations: %s", err)
+	}
+	if result.Created != 1 || len(ts.created) != 1 || len(ts.created[0].DomainNames) != 2 {
+		t.Fatalf("Unexpected result %+v %+v", result, ts.created)
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	ts := newTestServer(t)
+	im, _ := New(newTestClient(ts.URL), Config{
+		Kind:    KindUser,
+		Columns: map[string]string{"Name": "name", "E-mail": "email"},
+		DryRun:  true,
+	})
+
+	var out bytes.Buffer
+	result, err := im.Import(context.Background(), strings.NewReader(usersCSV), &out)
+	if err != nil {
+		t.Fatalf("Failed to validate users: %s", err)
+	}
+	if result.Valid != 5 || result.Invalid != 2 || ts.requests["POST /users/create_or_update_many.json"] != 0 {
+		t.Fatalf("Dry run should only validate: %+v %v", result, ts.requests)
+	}
+}
+
+func TestImportConfigErrors(t *testing.T) {
+	ts := newTestServer(t)
+	client := newTestClient(ts.URL)
+
+	if _, err := New(client, Config{Kind: KindUser, Columns: map[string]string{"a": "domain_names"}}); err == nil {
+		t.Fatal("Unknown target should fail")
+	}
+	if _, err := New(client, Config{Kind: "ticket"}); err == nil {
+		t.Fatal("Unknown kind should fail")
+	}
+
+	cases := map[string]Config{
+		"missing column": {Kind: KindUser, Columns: map[string]string{"Phone": "phone"}},
+		"unknown field":  {Kind: KindUser, Columns: map[string]string{"Name": "user_fields.unknown"}},
+		"inactive field": {Kind: KindUser, Columns: map[string]string{"Name": "user_fields.legacy"}},
+	}
+	for name, cfg := range cases {
+		im, err := New(client, cfg)
+		if err != nil {
+			t.Fatalf("%s: Failed to create importer: %s", name, err)
+		}
+		if _, err := im.Import(context.Background(), strings.NewReader(usersCSV), &bytes.Buffer{}); err == nil {
+			t.Fatalf("%s: Import should fail", name)
+		}
+	}
+}
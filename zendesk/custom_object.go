@@ -20,6 +20,46 @@ type CustomObjectRecord struct {
 	ExternalID         string                 `json:"external_id,omitempty"`
 }
 
+// CustomObject is the schema of a custom object
+//
+// ref: https://developer.zendesk.com/api-reference/custom-data/custom-objects/custom_objects/
+type CustomObject struct {
+	Key                string    `json:"key"`
+	URL                string    `json:"url,omitempty"`
+	Title              string    `json:"title"`
+	RawTitle           string    `json:"raw_title,omitempty"`
+	TitlePluralized    string    `json:"title_pluralized"`
+	RawTitlePluralized string    `json:"raw_title_pluralized,omitempty"`
+	Description        string    `json:"description,omitempty"`
+	RawDescription     string    `json:"raw_description,omitempty"`
+	CreatedByUserID    string    `json:"created_by_user_id,omitempty"`
+	UpdatedByUserID    string    `json:"updated_by_user_id,omitempty"`
+	CreatedAt          time.Time `json:"created_at,omitempty"`
+	UpdatedAt          time.Time `json:"updated_at,omitempty"`
+}
+
+// CustomObjectField is a field of a custom object
+//
+// ref: https://developer.zendesk.com/api-reference/custom-data/custom-objects/custom_object_fields/
+type CustomObjectField struct {
+	ID                     int64               `json:"id,omitempty"`
+	URL                    string              `json:"url,omitempty"`
+	Key                    string              `json:"key"`
+	Type                   string              `json:"type"`
+	Title                  string              `json:"title"`
+	RawTitle               string              `json:"raw_title,omitempty"`
+	Description            string              `json:"description,omitempty"`
+	RawDescription         string              `json:"raw_description,omitempty"`
+	Position               int64               `json:"position,omitempty"`
+	Active                 bool                `json:"active"`
+	System                 bool                `json:"system,omitempty"`
+	RegexpForValidation    string              `json:"regexp_for_validation,omitempty"`
+	RelationshipTargetType string              `json:"relationship_target_type,omitempty"`
+	CustomFieldOptions     []CustomFieldOption `json:"custom_field_options,omitempty"`
+	CreatedAt              *time.Time          `json:"created_at,omitempty"`
+	UpdatedAt              *time.Time          `json:"updated_at,omitempty"`
+}
+
 // CustomObjectAPI an interface containing all custom object related methods
 type CustomObjectAPI interface {
 	CreateCustomObjectRecord(
@@ -40,6 +80,8 @@ type CustomObjectAPI interface {
 	UpdateCustomObjectRecord(
 		ctx context.Context, customObjectKey string, customObjectRecordID string, record CustomObjectRecord,
 	) (*CustomObjectRecord, error)
+	ListCustomObjects(ctx context.Context) ([]CustomObject, error)
+	ListCustomObjectFields(ctx context.Context, customObjectKey string) ([]CustomObjectField, error)
 }
 
 // CustomObjectAutocompleteOptions custom object search options
@@ -213,3 +255,48 @@ func (z *Client) UpdateCustomObjectRecord(
 	}
 	return &result.CustomObjectRecord, nil
 }
+
+// ListCustomObjects lists the custom objects of the account
+// https://developer.zendesk.com/api-reference/custom-data/custom-objects/custom_objects/#list-custom-objects
+func (z *Client) ListCustomObjects(ctx context.Context) ([]CustomObject, error) {
+	var result struct {
+		CustomObjects []CustomObject `json:"custom_objects"`
+	}
+
+	body, err := z.get(ctx, "/custom_objects.json")
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.CustomObjects, nil
+}
+
+// ListCustomObjectFields lists the fields of a custom object including the system fields
+// https://developer.zendesk.com/api-reference/custom-data/custom-objects/custom_object_fields/#list-custom-object-fields
+func (z *Client) ListCustomObjectFields(ctx context.Context, customObjectKey string) ([]CustomObjectField, error) {
+	var result struct {
+		CustomObjectFields []CustomObjectField `json:"custom_object_fields"`
+	}
+
+	u, err := addOptions(fmt.Sprintf("/custom_objects/%s/fields.json", customObjectKey), struct {
+		IncludeStandardFields bool `url:"include_standard_fields"`
+	}{true})
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := z.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.CustomObjectFields, nil
+}
@@ -0,0 +1,47 @@
+package zendesk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListCustomObjects(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"custom_objects":[{"key":"car","title":"Car","title_pluralized":"Cars"}]}`))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	objects, err := client.ListCustomObjects(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list custom objects: %s", err)
+	}
+
+	if len(objects) != 1 || objects[0].Key != "car" {
+		t.Fatalf("Unexpected custom objects %+v", objects)
+	}
+}
+
+func TestListCustomObjectFields(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/custom_objects/car/fields.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(`{"custom_object_fields":[
+			{"id":1,"key":"standard::name","type":"text","title":"Name","active":true,"system":true},
+			{"id":2,"key":"color","type":"dropdown","title":"Color","active":true,"custom_field_options":[{"name":"Red","value":"red"}]}
+		]}`))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	fields, err := client.ListCustomObjectFields(ctx, "car")
+	if err != nil {
+		t.Fatalf("Failed to list custom object fields: %s", err)
+	}
+
+	if len(fields) != 2 || !fields[0].System || fields[1].CustomFieldOptions[0].Value != "red" {
+		t.Fatalf("Unexpected custom object fields %+v", fields)
+	}
+}
@@ -0,0 +1,239 @@
+package zendesk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ObjectTrigger is a trigger which runs when records of a custom object are created or updated
+//
+// ref: https://developer.zendesk.com/api-reference/custom-data/custom-objects/object_triggers/
+type ObjectTrigger struct {
+	ID         int64  `json:"id,omitempty"`
+	URL        string `json:"url,omitempty"`
+	Title      string `json:"title"`
+	RawTitle   string `json:"raw_title,omitempty"`
+	Active     bool   `json:"active"`
+	Default    bool   `json:"default,omitempty"`
+	Position   int64  `json:"position,omitempty"`
+	Conditions struct {
+		All []TriggerCondition `json:"all"`
+		Any []TriggerCondition `json:"any"`
+	} `json:"conditions"`
+	Actions     []TriggerAction `json:"actions"`
+	Description string          `json:"description,omitempty"`
+	CreatedAt   *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time      `json:"updated_at,omitempty"`
+}
+
+// ObjectTriggerListOptions is options for GetObjectTriggers
+//
+// ref: https://developer.zendesk.com/api-reference/custom-data/custom-objects/object_triggers/#list-object-triggers
+type ObjectTriggerListOptions struct {
+	PageOptions
+	Active    bool   `url:"active,omitempty"`
+	SortBy    string `url:"sort_by,omitempty"`
+	SortOrder string `url:"sort_order,omitempty"`
+}
+
+// TriggerDefinition is a condition or action which is available to triggers
+type TriggerDefinition struct {
+	Title      string                      `json:"title"`
+	Subject    string                      `json:"subject"`
+	Type       string                      `json:"type"`
+	Group      string                      `json:"group,omitempty"`
+	Nullable   bool                        `json:"nullable,omitempty"`
+	Repeatable bool                        `json:"repeatable,omitempty"`
+	Operators  []TriggerDefinitionOperator `json:"operators,omitempty"`
+	Values     []TriggerDefinitionValue    `json:"values,omitempty"`
+}
+
+// TriggerDefinitionOperator is an operator of a TriggerDefinition
+type TriggerDefinitionOperator struct {
+	Value    string `json:"value"`
+	Title    string `json:"title"`
+	Terminal bool   `json:"terminal,omitempty"`
+}
+
+// TriggerDefinitionValue is a value of a TriggerDefinition
+type TriggerDefinitionValue struct {
+	Value   string `json:"value"`
+	Title   string `json:"title"`
+	Enabled bool   `json:"enabled,omitempty"`
+}
+
+// TriggerDefinitions are the conditions and actions which are available to triggers
+type TriggerDefinitions struct {
+	ConditionsAll []TriggerDefinition `json:"conditions_all"`
+	ConditionsAny []TriggerDefinition `json:"conditions_any"`
+	Actions       []TriggerDefinition `json:"actions"`
+}
+
+// ObjectTriggerAPI an interface containing all custom object trigger related methods
+type ObjectTriggerAPI interface {
+	GetObjectTriggers(ctx context.Context, customObjectKey string, opts *ObjectTriggerListOptions) ([]ObjectTrigger, Page, error)
+	GetObjectTrigger(ctx context.Context, customObjectKey string, id int64) (ObjectTrigger, error)
+	CreateObjectTrigger(ctx context.Context, customObjectKey string, trigger ObjectTrigger) (ObjectTrigger, error)
+	UpdateObjectTrigger(ctx context.Context, customObjectKey string, id int64, trigger ObjectTrigger) (ObjectTrigger, error)
+	DeleteObjectTrigger(ctx context.Context, customObjectKey string, id int64) error
+	ReorderObjectTriggers(ctx context.Context, customObjectKey string, ids []int64) ([]ObjectTrigger, error)
+	GetObjectTriggerDefinitions(ctx context.Context, customObjectKey string) (TriggerDefinitions, error)
+}
+
+// GetObjectTriggers fetch the triggers of the custom object
+//
+// ref: https://developer.zendesk.com/api-reference/custom-data/custom-objects/object_triggers/#list-object-triggers
+func (z *Client) GetObjectTriggers(
+	ctx context.Context, customObjectKey string, opts *ObjectTriggerListOptions,
+) ([]ObjectTrigger, Page, error) {
+	var data struct {
+		Triggers []ObjectTrigger `json:"triggers"`
+		Page
+	}
+
+	tmp := opts
+	if tmp == nil {
+		tmp = &ObjectTriggerListOptions{}
+	}
+
+	u, err := addOptions(fmt.Sprintf("/custom_objects/%s/triggers.json", customObjectKey), tmp)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	body, err := z.get(ctx, u)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, Page{}, err
+	}
+	return data.Triggers, data.Page, nil
+}
+
+// GetObjectTrigger returns the specified trigger of the custom object
+//
+// ref: https://developer.zendesk.com/api-reference/custom-data/custom-objects/object_triggers/#show-object-trigger
+func (z *Client) GetObjectTrigger(ctx context.Context, customObjectKey string, id int64) (ObjectTrigger, error) {
+	var result struct {
+		Trigger ObjectTrigger `json:"trigger"`
+	}
+
+	body, err := z.get(ctx, fmt.Sprintf("/custom_objects/%s/triggers/%d.json", customObjectKey, id))
+	if err != nil {
+		return ObjectTrigger{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return ObjectTrigger{}, err
+	}
+	return result.Trigger, nil
+}
+
+// CreateObjectTrigger creates new trigger of the custom object
+//
+// ref: https://developer.zendesk.com/api-reference/custom-data/custom-objects/object_triggers/#create-object-trigger
+func (z *Client) CreateObjectTrigger(ctx context.Context, customObjectKey string, trigger ObjectTrigger) (ObjectTrigger, error) {
+	var data, result struct {
+		Trigger ObjectTrigger `json:"trigger"`
+	}
+	data.Trigger = trigger
+
+	body, err := z.post(ctx, fmt.Sprintf("/custom_objects/%s/triggers.json", customObjectKey), data)
+	if err != nil {
+		return ObjectTrigger{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return ObjectTrigger{}, err
+	}
+	return result.Trigger, nil
+}
+
+// UpdateObjectTrigger updates the specified trigger of the custom object and returns the updated one
+//
+// ref: https://developer.zendesk.com/api-reference/custom-data/custom-objects/object_triggers/#update-object-trigger
+func (z *Client) UpdateObjectTrigger(
+	ctx context.Context, customObjectKey string, id int64, trigger ObjectTrigger,
+) (ObjectTrigger, error) {
+	var data, result struct {
+		Trigger ObjectTrigger `json:"trigger"`
+	}
+	data.Trigger = trigger
+
+	body, err := z.put(ctx, fmt.Sprintf("/custom_objects/%s/triggers/%d.json", customObjectKey, id), data)
+	if err != nil {
+		return ObjectTrigger{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return ObjectTrigger{}, err
+	}
+	return result.Trigger, nil
+}
+
+// DeleteObjectTrigger deletes the specified trigger of the custom object
+//
+// ref: https://developer.zendesk.com/api-reference/custom-data/custom-objects/object_triggers/#delete-object-trigger
+func (z *Client) DeleteObjectTrigger(ctx context.Context, customObjectKey string, id int64) error {
+	return z.delete(ctx, fmt.Sprintf("/custom_objects/%s/triggers/%d.json", customObjectKey, id))
+}
+
+// ReorderObjectTriggers sets the positions of the triggers of the custom object to the order of ids
+//
+// ref: https://developer.zendesk.com/api-reference/custom-data/custom-objects/object_triggers/#update-many-object-triggers
+func (z *Client) ReorderObjectTriggers(ctx context.Context, customObjectKey string, ids []int64) ([]ObjectTrigger, error) {
+	type position struct {
+		ID       int64 `json:"id"`
+		Position int64 `json:"position"`
+	}
+	var data struct {
+		Triggers []position `json:"triggers"`
+	}
+	for i, id := range ids {
+		data.Triggers = append(data.Triggers, position{ID: id, Position: int64(i + 1)})
+	}
+
+	var result struct {
+		Triggers []ObjectTrigger `json:"triggers"`
+	}
+
+	body, err := z.put(ctx, fmt.Sprintf("/custom_objects/%s/triggers/update_many.json", customObjectKey), data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Triggers, nil
+}
+
+// GetObjectTriggerDefinitions returns the conditions and actions which are available to
+// the triggers of the custom object
+//
+// ref: https://developer.zendesk.com/api-reference/custom-data/custom-objects/object_triggers/#list-object-trigger-action-and-condition-definitions
+func (z *Client) GetObjectTriggerDefinitions(ctx context.Context, customObjectKey string) (TriggerDefinitions, error) {
+	var result struct {
+		Definitions TriggerDefinitions `json:"definitions"`
+	}
+
+	body, err := z.get(ctx, fmt.Sprintf("/custom_objects/%s/triggers/definitions.json", customObjectKey))
+	if err != nil {
+		return TriggerDefinitions{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return TriggerDefinitions{}, err
+	}
+	return result.Definitions, nil
+}
@@ -0,0 +1,137 @@
+package zendesk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetObjectTriggers(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "object_triggers.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	triggers, _, err := client.GetObjectTriggers(ctx, "car", &ObjectTriggerListOptions{Active: true})
+	if err != nil {
+		t.Fatalf("Failed to get object triggers: %s", err)
+	}
+
+	if len(triggers) != 2 {
+		t.Fatalf("expected length of object triggers is 2, but got %d", len(triggers))
+	}
+	if triggers[0].Conditions.All[0].Field != "custom_object.car.custom_fields.status" {
+		t.Fatalf("Returned trigger does not have the expected conditions %+v", triggers[0].Conditions)
+	}
+}
+
+func TestGetObjectTrigger(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "object_trigger.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	trg, err := client.GetObjectTrigger(ctx, "car", 10001)
+	if err != nil {
+		t.Fatalf("Failed to get object trigger: %s", err)
+	}
+
+	expectedID := int64(10001)
+	if trg.ID != expectedID {
+		t.Fatalf("Returned trigger does not have the expected ID %d. Trigger id is %d", expectedID, trg.ID)
+	}
+}
+
+func TestCreateObjectTrigger(t *testing.T) {
+	mockAPI := newMockAPIWithStatus(http.MethodPost, "object_trigger.json", http.StatusCreated)
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	trg, err := client.CreateObjectTrigger(ctx, "car", ObjectTrigger{Title: "Notify owner when a car is retired"})
+	if err != nil {
+		t.Fatalf("Failed to create object trigger: %s", err)
+	}
+
+	if trg.ID == 0 {
+		t.Fatal("Created trigger should have an ID")
+	}
+}
+
+func TestUpdateObjectTrigger(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodPut, "object_trigger.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	trg, err := client.UpdateObjectTrigger(ctx, "car", 10001, ObjectTrigger{})
+	if err != nil {
+		t.Fatalf("Failed to update object trigger: %s", err)
+	}
+
+	if trg.ID != 10001 {
+		t.Fatalf("Returned trigger does not have the expected ID 10001. Trigger id is %d", trg.ID)
+	}
+}
+
+func TestDeleteObjectTrigger(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/custom_objects/car/triggers/10001.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	err := client.DeleteObjectTrigger(ctx, "car", 10001)
+	if err != nil {
+		t.Fatalf("Failed to delete object trigger: %s", err)
+	}
+}
+
+func TestReorderObjectTriggers(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/custom_objects/car/triggers/update_many.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		var data struct {
+			Triggers []struct {
+				ID       int64 `json:"id"`
+				Position int64 `json:"position"`
+			} `json:"triggers"`
+		}
+		json.NewDecoder(r.Body).Decode(&data)
+		if len(data.Triggers) != 2 || data.Triggers[0].ID != 10002 || data.Triggers[0].Position != 1 || data.Triggers[1].Position != 2 {
+			t.Errorf("Unexpected positions %+v", data.Triggers)
+		}
+		w.Write(readFixture(filepath.Join(http.MethodGet, "object_triggers.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	triggers, err := client.ReorderObjectTriggers(ctx, "car", []int64{10002, 10001})
+	if err != nil {
+		t.Fatalf("Failed to reorder object triggers: %s", err)
+	}
+
+	if len(triggers) != 2 {
+		t.Fatalf("expected length of object triggers is 2, but got %d", len(triggers))
+	}
+}
+
+func TestGetObjectTriggerDefinitions(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "object_trigger_definitions.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	defs, err := client.GetObjectTriggerDefinitions(ctx, "car")
+	if err != nil {
+		t.Fatalf("Failed to get object trigger definitions: %s", err)
+	}
+
+	if len(defs.ConditionsAll) != 1 || len(defs.ConditionsAny) != 1 || len(defs.Actions) != 1 {
+		t.Fatalf("Unexpected definitions %+v", defs)
+	}
+	if ops := defs.ConditionsAll[0].Operators; len(ops) != 2 || ops[1].Value != "is_not" {
+		t.Fatalf("Unexpected operators %+v", ops)
+	}
+}
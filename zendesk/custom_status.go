@@ -0,0 +1,71 @@
+package zendesk
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// CustomStatus is a ticket status defined by the account within a status category
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/custom_ticket_statuses/
+type CustomStatus struct {
+	ID                 int64      `json:"id,omitempty"`
+	URL                string     `json:"url,omitempty"`
+	StatusCategory     string     `json:"status_category"`
+	AgentLabel         string     `json:"agent_label"`
+	RawAgentLabel      string     `json:"raw_agent_label,omitempty"`
+	EndUserLabel       string     `json:"end_user_label,omitempty"`
+	RawEndUserLabel    string     `json:"raw_end_user_label,omitempty"`
+	Description        string     `json:"description,omitempty"`
+	RawDescription     string     `json:"raw_description,omitempty"`
+	EndUserDescription string     `json:"end_user_description,omitempty"`
+	Active             bool       `json:"active"`
+	Default            bool       `json:"default,omitempty"`
+	CreatedAt          *time.Time `json:"created_at,omitempty"`
+	UpdatedAt          *time.Time `json:"updated_at,omitempty"`
+}
+
+// CustomStatusListOptions is options for GetCustomStatuses
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/custom_ticket_statuses/#list-custom-ticket-statuses
+type CustomStatusListOptions struct {
+	Active           bool   `url:"active,omitempty"`
+	Default          bool   `url:"default,omitempty"`
+	StatusCategories string `url:"status_categories,omitempty"`
+}
+
+// CustomStatusAPI an interface containing all custom status related methods
+type CustomStatusAPI interface {
+	GetCustomStatuses(ctx context.Context, opts *CustomStatusListOptions) ([]CustomStatus, error)
+}
+
+// GetCustomStatuses fetch the custom ticket statuses of the account
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/custom_ticket_statuses/#list-custom-ticket-statuses
+func (z *Client) GetCustomStatuses(ctx context.Context, opts *CustomStatusListOptions) ([]CustomStatus, error) {
+	var data struct {
+		CustomStatuses []CustomStatus `json:"custom_statuses"`
+	}
+
+	tmp := opts
+	if tmp == nil {
+		tmp = &CustomStatusListOptions{}
+	}
+
+	u, err := addOptions("/custom_statuses.json", tmp)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := z.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, err
+	}
+	return data.CustomStatuses, nil
+}
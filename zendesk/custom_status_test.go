@@ -0,0 +1,30 @@
+package zendesk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetCustomStatuses(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/custom_statuses.json" || r.URL.Query().Get("active") != "true" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(`{"custom_statuses":[
+			{"id":100,"status_category":"open","agent_label":"Open","active":true,"default":true},
+			{"id":101,"status_category":"open","agent_label":"In progress","active":true}
+		]}`))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	statuses, err := client.GetCustomStatuses(ctx, &CustomStatusListOptions{Active: true})
+	if err != nil {
+		t.Fatalf("Failed to get custom statuses: %s", err)
+	}
+
+	if len(statuses) != 2 || statuses[1].AgentLabel != "In progress" || !statuses[0].Default {
+		t.Fatalf("Unexpected custom statuses %+v", statuses)
+	}
+}
@@ -0,0 +1,160 @@
+package zendesk
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+var dynamicContentPlaceholderRegexp = regexp.MustCompile(`\{\{\s*dc\.([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// DynamicContentResolver renders dynamic content placeholders such as {{dc.title}}
+// locally with the variants of a locale, without asking the API to render them.
+type DynamicContentResolver struct {
+	localeID int64
+	items    map[string]DynamicContentItem
+}
+
+// NewDynamicContentResolver creates a resolver which renders the placeholders of items
+// with their variant for localeID. Items without an active variant for the locale are
+// rendered with their default variant.
+func NewDynamicContentResolver(items []DynamicContentItem, localeID int64) *DynamicContentResolver {
+	r := &DynamicContentResolver{
+		localeID: localeID,
+		items:    make(map[string]DynamicContentItem, len(items)),
+	}
+	for _, item := range items {
+		name := item.Name
+		if m := dynamicContentPlaceholderRegexp.FindStringSubmatch(item.Placeholder); m != nil {
+			name = m[1]
+		}
+		r.items[strings.ToLower(name)] = item
+	}
+	return r
+}
+
+// NewDynamicContentResolverForLocale creates a resolver for a locale such as "ja" or
+// "pt-BR" with all dynamic content items of the account. A locale which is not one of
+// the account locales falls back to its language, and then to the default variants.
+func NewDynamicContentResolverForLocale(ctx context.Context, api API, locale string) (*DynamicContentResolver, error) {
+	locales, err := api.GetLocales(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []DynamicContentItem
+	it := api.GetDynamicContentItemsIterator(ctx, NewPaginationOptions())
+	for it.HasMore() {
+		page, err := it.GetNext()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+	}
+
+	return NewDynamicContentResolver(items, localeID(locales, locale)), nil
+}
+
+// localeID finds the ID of locale in locales, trying its language when there is
+// no exact match. It returns 0 if neither is found.
+func localeID(locales []Locale, locale string) int64 {
+	lang := strings.SplitN(locale, "-", 2)[0]
+	var id int64
+	for _, l := range locales {
+		if strings.EqualFold(l.Locale, locale) {
+			return l.ID
+		}
+		if id == 0 && strings.EqualFold(l.Locale, lang) {
+			id = l.ID
+		}
+	}
+	return id
+}
+
+// Resolve replaces the dynamic content placeholders in s. Placeholders of unknown
+// items are left as they are.
+func (r *DynamicContentResolver) Resolve(s string) string {
+	if !strings.Contains(s, "{{") {
+		return s
+	}
+	return dynamicContentPlaceholderRegexp.ReplaceAllStringFunc(s, func(placeholder string) string {
+		name := dynamicContentPlaceholderRegexp.FindStringSubmatch(placeholder)[1]
+		item, ok := r.items[strings.ToLower(name)]
+		if !ok {
+			return placeholder
+		}
+		content, ok := r.content(item)
+		if !ok {
+			return placeholder
+		}
+		return content
+	})
+}
+
+// content returns the variant of item for the resolver locale, falling back to the
+// default variant of the item
+func (r *DynamicContentResolver) content(item DynamicContentItem) (string, bool) {
+	var fallback *DynamicContentVariant
+	for i, v := range item.Variants {
+		if v.LocaleID == r.localeID && v.Active {
+			return v.Content, true
+		}
+		if fallback == nil && (v.Default || v.LocaleID == item.DefaultLocaleID) {
+			fallback = &item.Variants[i]
+		}
+	}
+	if fallback == nil {
+		return "", false
+	}
+	return fallback.Content, true
+}
+
+// ResolveTicketField renders the title, description and custom field option names of
+// field from their raw values, which keep the dynamic content placeholders.
+func (r *DynamicContentResolver) ResolveTicketField(field TicketField) TicketField {
+	field.Title = r.Resolve(rawOr(field.RawTitle, field.Title))
+	field.Description = r.Resolve(rawOr(field.RawDescription, field.Description))
+	field.TitleInPortal = r.Resolve(rawOr(field.RawTitleInPortal, field.TitleInPortal))
+
+	if field.CustomFieldOptions != nil {
+		options := make([]CustomFieldOption, len(field.CustomFieldOptions))
+		for i, o := range field.CustomFieldOptions {
+			o.Name = r.Resolve(rawOr(o.RawName, o.Name))
+			options[i] = o
+		}
+		field.CustomFieldOptions = options
+	}
+	return field
+}
+
+func rawOr(raw, rendered string) string {
+	if raw != "" {
+		return raw
+	}
+	return rendered
+}
+
+// GetResolvedTicketFields fetches all ticket fields and renders their dynamic content
+// locally in locale, so that titles and option names of every field are localized
+// even where the API leaves placeholders unrendered.
+func GetResolvedTicketFields(ctx context.Context, api API, locale string) ([]TicketField, error) {
+	var fields []TicketField
+	it := api.GetTicketFieldsIterator(WithLocale(ctx, locale), NewPaginationOptions())
+	for it.HasMore() {
+		page, err := it.GetNext()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, page...)
+	}
+
+	resolver, err := NewDynamicContentResolverForLocale(ctx, api, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range fields {
+		fields[i] = resolver.ResolveTicketField(fields[i])
+	}
+	return fields, nil
+}
@@ -0,0 +1,105 @@
+package zendesk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func testDynamicContentItems(t *testing.T) []DynamicContentItem {
+	t.Helper()
+
+	var data struct {
+		Items []DynamicContentItem `json:"items"`
+	}
+	if err := json.Unmarshal(readFixture(filepath.Join(http.MethodGet, "dynamic_content/items.json")), &data); err != nil {
+		t.Fatalf("Failed to decode dynamic content items: %s", err)
+	}
+	return data.Items
+}
+
+func TestDynamicContentResolverResolve(t *testing.T) {
+	items := testDynamicContentItems(t)
+
+	cases := []struct {
+		localeID int64
+		in       string
+		want     string
+	}{
+		{LocaleJA, "{{dc.title}}", "ZendeskのためのTerraform provider"},
+		{LocaleZHTW, "Title: {{ dc.TITLE }}", "Title: Zendesk的Terraform provider"},
+		{LocaleENUS, "{{dc.title}}", "Terraform provider for Zendesk"},
+		{LocaleDE, "{{dc.title}}", "Terraform provider for Zendesk"},
+		{LocaleJA, "{{dc.unknown}} {{ticket.id}}", "{{dc.unknown}} {{ticket.id}}"},
+		{LocaleJA, "plain text", "plain text"},
+	}
+	for _, c := range cases {
+		r := NewDynamicContentResolver(items, c.localeID)
+		if got := r.Resolve(c.in); got != c.want {
+			t.Fatalf("Resolve(%q) in locale %d: expected %q, but got %q", c.in, c.localeID, c.want, got)
+		}
+	}
+}
+
+func TestDynamicContentResolverResolveTicketField(t *testing.T) {
+	r := NewDynamicContentResolver(testDynamicContentItems(t), LocaleJA)
+
+	field := r.ResolveTicketField(TicketField{
+		Title:          "{{dc.title}}",
+		RawTitle:       "{{dc.title}}",
+		Description:    "Rendered description",
+		RawDescription: "{{dc.description}}",
+		CustomFieldOptions: []CustomFieldOption{
+			{Name: "{{dc.title}}", RawName: "{{dc.title}}", Value: "title"},
+			{Name: "Other", Value: "other"},
+		},
+	})
+
+	if field.Title != "ZendeskのためのTerraform provider" {
+		t.Fatalf("Title is not resolved: %s", field.Title)
+	}
+	if field.Description != "exampleを使えばTerraformでZendeskをセットアップすることができます" {
+		t.Fatalf("Description is not resolved from raw description: %s", field.Description)
+	}
+	if field.CustomFieldOptions[0].Name != "ZendeskのためのTerraform provider" || field.CustomFieldOptions[1].Name != "Other" {
+		t.Fatalf("Custom field option names are not resolved: %v", field.CustomFieldOptions)
+	}
+}
+
+func TestGetResolvedTicketFields(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/locales.json":
+			w.Write(readFixture(filepath.Join(http.MethodGet, "locales.json")))
+		case "/dynamic_content/items.json":
+			w.Write(readFixture(filepath.Join(http.MethodGet, "dynamic_content/items.json")))
+		case "/ticket_fields.json":
+			if lang := r.Header.Get("Accept-Language"); lang != "ja-JP" {
+				t.Errorf("Expected ticket fields to be requested in ja-JP, but got %s", lang)
+			}
+			w.Write([]byte(`{"ticket_fields": [{"id": 1, "type": "tagger", "title": "{{dc.title}}", "raw_title": "{{dc.title}}",
+				"custom_field_options": [{"id": 2, "name": "{{dc.description}}", "raw_name": "{{dc.description}}", "value": "desc"}]}]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	fields, err := GetResolvedTicketFields(ctx, client, "ja-JP")
+	if err != nil {
+		t.Fatalf("Failed to get resolved ticket fields: %s", err)
+	}
+
+	if len(fields) != 1 {
+		t.Fatalf("expected length of ticket fields is 1, but got %d", len(fields))
+	}
+	if fields[0].Title != "ZendeskのためのTerraform provider" {
+		t.Fatalf("Title is not resolved in the language of the locale: %s", fields[0].Title)
+	}
+	if name := fields[0].CustomFieldOptions[0].Name; name != "exampleを使えばTerraformでZendeskをセットアップすることができます" {
+		t.Fatalf("Custom field option name is not resolved: %s", name)
+	}
+}
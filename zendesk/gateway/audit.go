@@ -0,0 +1,51 @@
+package gateway
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// AuditEntry is a record of a request handled by the gateway
+type AuditEntry struct {
+	Time      time.Time     `json:"time"`
+	Caller    string        `json:"caller,omitempty"`
+	Operation Operation     `json:"operation,omitempty"`
+	Method    string        `json:"method"`
+	Path      string        `json:"path"`
+	Status    int           `json:"status"`
+	Cached    bool          `json:"cached,omitempty"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// AuditLogger receives audit entries of the gateway
+type AuditLogger interface {
+	Log(entry AuditEntry)
+}
+
+// AuditLoggerFunc is an adapter to use ordinary functions as AuditLogger
+type AuditLoggerFunc func(entry AuditEntry)
+
+// Log calls f(entry)
+func (f AuditLoggerFunc) Log(entry AuditEntry) {
+	f(entry)
+}
+
+// jsonAuditLogger writes audit entries as JSON lines
+type jsonAuditLogger struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONAuditLogger creates AuditLogger which writes one JSON object per line to w
+func NewJSONAuditLogger(w io.Writer) AuditLogger {
+	return &jsonAuditLogger{enc: json.NewEncoder(w)}
+}
+
+// Log writes the entry
+func (l *jsonAuditLogger) Log(entry AuditEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_ = l.enc.Encode(entry)
+}
@@ -0,0 +1,75 @@
+package gateway
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrUnauthenticated is returned by an Authorizer when the caller can not be identified
+	ErrUnauthenticated = errors.New("caller is not authenticated")
+	// ErrForbidden is returned by an Authorizer when the caller may not call the operation
+	ErrForbidden = errors.New("caller is not allowed to call this operation")
+)
+
+// Authorizer identifies the caller of a request and decides whether
+// it may call the operation. The returned caller name is recorded in the
+// audit log even when authorization fails.
+type Authorizer interface {
+	Authorize(r *http.Request, op Operation) (caller string, err error)
+}
+
+// CallerPolicy is the name of an internal caller and the operations it may call
+type CallerPolicy struct {
+	Name       string
+	Operations []Operation
+}
+
+// allows checks if the policy contains the operation
+func (p CallerPolicy) allows(op Operation) bool {
+	for _, o := range p.Operations {
+		if o == op || o == OpAny {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenAuthorizer authorizes callers by the bearer token of the request
+type TokenAuthorizer struct {
+	policies map[string]CallerPolicy
+}
+
+// NewTokenAuthorizer creates TokenAuthorizer from caller policies keyed by bearer token
+func NewTokenAuthorizer(policies map[string]CallerPolicy) *TokenAuthorizer {
+	return &TokenAuthorizer{policies: policies}
+}
+
+// Authorize looks up the caller by the "Authorization: Bearer" header
+func (a *TokenAuthorizer) Authorize(r *http.Request, op Operation) (string, error) {
+	const prefix = "Bearer "
+
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, prefix) {
+		return "", ErrUnauthenticated
+	}
+
+	policy, ok := a.policies[strings.TrimPrefix(header, prefix)]
+	if !ok {
+		return "", ErrUnauthenticated
+	}
+
+	if !policy.allows(op) {
+		return policy.Name, ErrForbidden
+	}
+	return policy.Name, nil
+}
+
+// authStatus maps an authorization error to HTTP status code
+func authStatus(err error) int {
+	if errors.Is(err, ErrUnauthenticated) {
+		return http.StatusUnauthorized
+	}
+	return http.StatusForbidden
+}
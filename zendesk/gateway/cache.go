@@ -0,0 +1,80 @@
+package gateway
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	status  int
+	body    []byte
+	expires time.Time
+}
+
+// cache keeps responses of read operations for a fixed TTL. Expired entries
+// are swept on insert at most once per TTL, so entries which are never read
+// again do not pile up.
+type cache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]cacheEntry
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+func newCache(ttl time.Duration) *cache {
+	return &cache{
+		ttl:     ttl,
+		entries: map[string]cacheEntry{},
+		now:     time.Now,
+	}
+}
+
+func (c *cache) get(key string) (int, []byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return 0, nil, false
+	}
+	if c.now().After(e.expires) {
+		delete(c.entries, key)
+		return 0, nil, false
+	}
+	return e.status, e.body, true
+}
+
+func (c *cache) set(key string, status int, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if !now.Before(c.nextSweep) {
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		c.nextSweep = now.Add(c.ttl)
+	}
+
+	c.entries[key] = cacheEntry{
+		status:  status,
+		body:    body,
+		expires: now.Add(c.ttl),
+	}
+}
+
+// invalidate drops every entry of the resource and its sub resources
+func (c *cache) invalidate(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix+"/") || strings.HasPrefix(key, prefix+"?") {
+			delete(c.entries, key)
+		}
+	}
+}
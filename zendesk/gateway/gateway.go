@@ -0,0 +1,298 @@
+// Package gateway provides an HTTP/JSON server which exposes a subset of
+// zendesk.API to internal callers. The gateway holds the Zendesk credential
+// centrally and applies shared rate limiting, response caching, audit logging
+// and per operation authorization to every call.
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// Operation is a name of an API operation exposed by the gateway
+type Operation string
+
+const (
+	// OpAny matches every operation in a caller policy
+	OpAny Operation = "*"
+	// OpGetTicket GET /tickets/{id}
+	OpGetTicket Operation = "get_ticket"
+	// OpCreateTicket POST /tickets
+	OpCreateTicket Operation = "create_ticket"
+	// OpUpdateTicket PUT /tickets/{id}
+	OpUpdateTicket Operation = "update_ticket"
+	// OpListTicketComments GET /tickets/{id}/comments
+	OpListTicketComments Operation = "list_ticket_comments"
+	// OpCreateTicketComment POST /tickets/{id}/comments
+	OpCreateTicketComment Operation = "create_ticket_comment"
+	// OpGetUser GET /users/{id}
+	OpGetUser Operation = "get_user"
+	// OpSearchUsers GET /users/search
+	OpSearchUsers Operation = "search_users"
+	// OpGetOrganization GET /organizations/{id}
+	OpGetOrganization Operation = "get_organization"
+	// OpSearch GET /search
+	OpSearch Operation = "search"
+)
+
+// DefaultMaxWait is how long requests wait for rate limit budget by default
+const DefaultMaxWait = 10 * time.Second
+
+// Config is configuration of the gateway Server
+type Config struct {
+	// Authorizer identifies callers and decides which operations they may call. Required.
+	Authorizer Authorizer
+
+	// AuditLogger receives an entry for every handled request. Optional.
+	AuditLogger AuditLogger
+
+	// RequestsPerMinute is the budget shared by all callers for calls to Zendesk.
+	// Zero disables rate limiting.
+	RequestsPerMinute int
+
+	// Burst is the number of calls which can be made at once. Defaults to 1.
+	Burst int
+
+	// MaxWait is how long a request may wait for rate limit budget before
+	// the gateway answers 429 Too Many Requests. Defaults to DefaultMaxWait.
+	// A negative value answers 429 at once instead of waiting.
+	MaxWait time.Duration
+
+	// CacheTTL is how long responses of read operations are cached.
+	// Zero disables caching.
+	CacheTTL time.Duration
+}
+
+// Server is an http.Handler which proxies allowed operations to Zendesk
+type Server struct {
+	api     zendesk.API
+	auth    Authorizer
+	audit   AuditLogger
+	limiter *limiter
+	cache   *cache
+	maxWait time.Duration
+	routes  []route
+	now     func() time.Time
+}
+
+type handlerFunc func(s *Server, r *http.Request, id int64) (int, interface{}, error)
+
+type route struct {
+	method    string
+	path      []string
+	operation Operation
+	cacheable bool
+	handler   handlerFunc
+}
+
+// NewServer creates a gateway Server backed by api
+func NewServer(api zendesk.API, cfg Config) (*Server, error) {
+	if api == nil {
+		return nil, errors.New("gateway: api is required")
+	}
+	if cfg.Authorizer == nil {
+		return nil, errors.New("gateway: authorizer is required")
+	}
+
+	s := &Server{
+		api:     api,
+		auth:    cfg.Authorizer,
+		audit:   cfg.AuditLogger,
+		maxWait: cfg.MaxWait,
+		now:     time.Now,
+	}
+	if s.maxWait == 0 {
+		s.maxWait = DefaultMaxWait
+	} else if s.maxWait < 0 {
+		s.maxWait = 0
+	}
+	if cfg.RequestsPerMinute > 0 {
+		s.limiter = newLimiter(cfg.RequestsPerMinute, cfg.Burst)
+	}
+	if cfg.CacheTTL > 0 {
+		s.cache = newCache(cfg.CacheTTL)
+	}
+
+	s.routes = []route{
+		{http.MethodGet, []string{"users", "search"}, OpSearchUsers, true, searchUsers},
+		{http.MethodGet, []string{"tickets", ":id"}, OpGetTicket, true, getTicket},
+		{http.MethodPost, []string{"tickets"}, OpCreateTicket, false, createTicket},
+		{http.MethodPut, []string{"tickets", ":id"}, OpUpdateTicket, false, updateTicket},
+		{http.MethodGet, []string{"tickets", ":id", "comments"}, OpListTicketComments, true, listTicketComments},
+		{http.MethodPost, []string{"tickets", ":id", "comments"}, OpCreateTicketComment, false, createTicketComment},
+		{http.MethodGet, []string{"users", ":id"}, OpGetUser, true, getUser},
+		{http.MethodGet, []string{"organizations", ":id"}, OpGetOrganization, true, getOrganization},
+		{http.MethodGet, []string{"search"}, OpSearch, true, search},
+	}
+
+	return s, nil
+}
+
+// ServeHTTP dispatches the request to the matching operation
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := s.now()
+	entry := AuditEntry{
+		Time:   start,
+		Method: r.Method,
+		Path:   r.URL.Path,
+	}
+	defer func() {
+		entry.Duration = s.now().Sub(start)
+		if s.audit != nil {
+			s.audit.Log(entry)
+		}
+	}()
+
+	rt, id, ok := s.match(r)
+	if !ok {
+		entry.Status = http.StatusNotFound
+		writeError(w, entry.Status, "no such operation")
+		return
+	}
+	entry.Operation = rt.operation
+
+	caller, err := s.auth.Authorize(r, rt.operation)
+	entry.Caller = caller
+	if err != nil {
+		entry.Status = authStatus(err)
+		writeError(w, entry.Status, err.Error())
+		return
+	}
+
+	key := r.URL.Path + "?" + r.URL.RawQuery
+	if rt.cacheable && s.cache != nil {
+		if status, body, hit := s.cache.get(key); hit {
+			entry.Status = status
+			entry.Cached = true
+			writeBody(w, status, body)
+			return
+		}
+	}
+
+	if s.limiter != nil {
+		if err := s.limiter.wait(r.Context(), s.maxWait); err != nil {
+			entry.Status = http.StatusTooManyRequests
+			writeError(w, entry.Status, err.Error())
+			return
+		}
+	}
+
+	status, data, err := rt.handler(s, r, id)
+	if err != nil {
+		entry.Status = s.writeUpstreamError(w, err)
+		return
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		entry.Status = http.StatusInternalServerError
+		writeError(w, entry.Status, err.Error())
+		return
+	}
+
+	if s.cache != nil {
+		if rt.cacheable {
+			s.cache.set(key, status, body)
+		} else {
+			s.cache.invalidate(resourcePrefix(r.URL.Path))
+		}
+	}
+
+	entry.Status = status
+	writeBody(w, status, body)
+}
+
+// match finds the route for the request and extracts its ID parameter
+func (s *Server) match(r *http.Request) (route, int64, bool) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	for _, rt := range s.routes {
+		if rt.method != r.Method || len(rt.path) != len(segments) {
+			continue
+		}
+
+		var id int64
+		matched := true
+		for i, p := range rt.path {
+			if p == ":id" {
+				v, err := strconv.ParseInt(segments[i], 10, 64)
+				if err != nil {
+					matched = false
+					break
+				}
+				id = v
+			} else if p != segments[i] {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			return rt, id, true
+		}
+	}
+
+	return route{}, 0, false
+}
+
+// resourcePrefix returns the path of the resource touched by a write,
+// e.g. /tickets/1 for /tickets/1/comments
+func resourcePrefix(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 2 {
+		segments = segments[:2]
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// badRequestError is an error caused by the caller's input
+type badRequestError struct {
+	msg string
+}
+
+func (e badRequestError) Error() string {
+	return e.msg
+}
+
+func (s *Server) writeUpstreamError(w http.ResponseWriter, err error) int {
+	var badReq badRequestError
+	if errors.As(err, &badReq) {
+		writeError(w, http.StatusBadRequest, badReq.Error())
+		return http.StatusBadRequest
+	}
+
+	var zerr zendesk.Error
+	if errors.As(err, &zerr) {
+		body, _ := io.ReadAll(zerr.Body())
+		if len(body) == 0 || !json.Valid(body) {
+			writeError(w, zerr.Status(), zerr.Error())
+		} else {
+			writeBody(w, zerr.Status(), body)
+		}
+		return zerr.Status()
+	}
+
+	writeError(w, http.StatusBadGateway, err.Error())
+	return http.StatusBadGateway
+}
+
+func writeBody(w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	body, _ := json.Marshal(map[string]string{
+		"error":       http.StatusText(status),
+		"description": msg,
+	})
+	writeBody(w, status, body)
+}
@@ -0,0 +1,272 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// newTestGateway starts a fake Zendesk API and a gateway Server in front of it.
+// It returns the gateway, the number of calls made to Zendesk and the audit entries.
+func newTestGateway(t *testing.T, cfg Config) (*httptest.Server, *int, *[]AuditEntry) {
+	t.Helper()
+
+	calls := 0
+	var mu sync.Mutex
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/tickets/1.json":
+			w.Write([]byte(`{"ticket":{"id":1,"subject":"hello"}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/tickets/1.json":
+			w.Write([]byte(`{"ticket":{"id":1,"subject":"updated"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/tickets/404.json":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"RecordNotFound"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	client, _ := zendesk.NewClient(nil)
+	client.SetEndpointURL(upstream.URL)
+
+	var entries []AuditEntry
+	cfg.AuditLogger = AuditLoggerFunc(func(e AuditEntry) {
+		mu.Lock()
+		defer mu.Unlock()
+		entries = append(entries, e)
+	})
+	if cfg.Authorizer == nil {
+		cfg.Authorizer = NewTokenAuthorizer(map[string]CallerPolicy{
+			"reader": {Name: "reader", Operations: []Operation{OpGetTicket}},
+			"admin":  {Name: "admin", Operations: []Operation{OpAny}},
+		})
+	}
+
+	s, err := NewServer(client, cfg)
+	if err != nil {
+		t.Fatalf("Failed to create gateway: %s", err)
+	}
+
+	gw := httptest.NewServer(s)
+	t.Cleanup(gw.Close)
+	return gw, &calls, &entries
+}
+
+func doRequest(t *testing.T, method, url, token string, body []byte) (*http.Response, []byte) {
+	t.Helper()
+
+	req, _ := http.NewRequest(method, url, bytes.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	buf := new(bytes.Buffer)
+	buf.ReadFrom(resp.Body)
+	return resp, buf.Bytes()
+}
+
+func TestNewServerRequiresAuthorizer(t *testing.T) {
+	client, _ := zendesk.NewClient(nil)
+	if _, err := NewServer(client, Config{}); err == nil {
+		t.Fatal("NewServer should fail without authorizer")
+	}
+}
+
+func TestGetTicket(t *testing.T) {
+	gw, _, entries := newTestGateway(t, Config{})
+
+	resp, body := doRequest(t, http.MethodGet, gw.URL+"/tickets/1", "reader", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Unexpected status %d: %s", resp.StatusCode, body)
+	}
+
+	var data struct {
+		Ticket zendesk.Ticket `json:"ticket"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Ticket.ID != 1 {
+		t.Fatalf("Unexpected ticket id %d", data.Ticket.ID)
+	}
+
+	e := (*entries)[0]
+	if e.Caller != "reader" || e.Operation != OpGetTicket || e.Status != http.StatusOK {
+		t.Fatalf("Unexpected audit entry %+v", e)
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	gw, calls, entries := newTestGateway(t, Config{})
+
+	resp, _ := doRequest(t, http.MethodGet, gw.URL+"/tickets/1", "", nil)
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 but got %d", resp.StatusCode)
+	}
+
+	resp, _ = doRequest(t, http.MethodPut, gw.URL+"/tickets/1", "reader", []byte(`{"ticket":{}}`))
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("Expected 403 but got %d", resp.StatusCode)
+	}
+
+	if *calls != 0 {
+		t.Fatalf("Unauthorized requests should not reach Zendesk, but %d calls were made", *calls)
+	}
+	if (*entries)[1].Caller != "reader" {
+		t.Fatalf("Forbidden request should be audited with its caller: %+v", (*entries)[1])
+	}
+}
+
+func TestUnknownOperation(t *testing.T) {
+	gw, _, _ := newTestGateway(t, Config{})
+
+	resp, _ := doRequest(t, http.MethodDelete, gw.URL+"/tickets/1", "admin", nil)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected 404 but got %d", resp.StatusCode)
+	}
+}
+
+func TestUpstreamError(t *testing.T) {
+	gw, _, _ := newTestGateway(t, Config{})
+
+	resp, body := doRequest(t, http.MethodGet, gw.URL+"/tickets/404", "admin", nil)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected 404 but got %d", resp.StatusCode)
+	}
+	if !strings.Contains(string(body), "RecordNotFound") {
+		t.Fatalf("Zendesk error body should be passed through: %s", body)
+	}
+}
+
+func TestBadRequestBody(t *testing.T) {
+	gw, calls, _ := newTestGateway(t, Config{})
+
+	resp, _ := doRequest(t, http.MethodPut, gw.URL+"/tickets/1", "admin", []byte(`{`))
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected 400 but got %d", resp.StatusCode)
+	}
+	if *calls != 0 {
+		t.Fatalf("Invalid request should not reach Zendesk")
+	}
+}
+
+func TestCache(t *testing.T) {
+	gw, calls, entries := newTestGateway(t, Config{CacheTTL: time.Minute})
+
+	doRequest(t, http.MethodGet, gw.URL+"/tickets/1", "admin", nil)
+	doRequest(t, http.MethodGet, gw.URL+"/tickets/1", "reader", nil)
+	if *calls != 1 {
+		t.Fatalf("Second read should be served from cache, but %d calls were made", *calls)
+	}
+	if !(*entries)[1].Cached {
+		t.Fatal("Cached response should be audited as cached")
+	}
+
+	doRequest(t, http.MethodPut, gw.URL+"/tickets/1", "admin", []byte(`{"ticket":{"subject":"updated"}}`))
+	doRequest(t, http.MethodGet, gw.URL+"/tickets/1", "admin", nil)
+	if *calls != 3 {
+		t.Fatalf("Write should invalidate cached ticket, but %d calls were made", *calls)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	gw, calls, _ := newTestGateway(t, Config{RequestsPerMinute: 1})
+
+	resp, _ := doRequest(t, http.MethodGet, gw.URL+"/tickets/1", "admin", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200 but got %d", resp.StatusCode)
+	}
+
+	resp, _ = doRequest(t, http.MethodGet, gw.URL+"/tickets/1", "admin", nil)
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429 but got %d", resp.StatusCode)
+	}
+	if *calls != 1 {
+		t.Fatalf("Rate limited request should not reach Zendesk")
+	}
+}
+
+func TestRateLimitWaits(t *testing.T) {
+	gw, calls, _ := newTestGateway(t, Config{RequestsPerMinute: 1200})
+
+	for i := 0; i < 2; i++ {
+		resp, _ := doRequest(t, http.MethodGet, gw.URL+"/tickets/1", "admin", nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Request should wait for the budget, but got %d", resp.StatusCode)
+		}
+	}
+	if *calls != 2 {
+		t.Fatalf("Expected 2 calls, but %d calls were made", *calls)
+	}
+}
+
+func TestCacheSweep(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := newCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.set("/tickets/1", http.StatusOK, nil)
+	c.set("/tickets/2", http.StatusOK, nil)
+
+	now = now.Add(2 * time.Minute)
+	c.set("/tickets/3", http.StatusOK, nil)
+	if len(c.entries) != 1 {
+		t.Fatalf("Expired entries should be swept on insert, but %d entries are left", len(c.entries))
+	}
+}
+
+func TestLimiterRefill(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newLimiter(60, 1)
+	l.now = func() time.Time { return now }
+
+	if _, ok := l.reserve(0); !ok {
+		t.Fatal("First token should be available")
+	}
+	if _, ok := l.reserve(0); ok {
+		t.Fatal("Bucket should be empty")
+	}
+
+	wait, ok := l.reserve(2 * time.Second)
+	if !ok || wait != time.Second {
+		t.Fatalf("Expected to wait 1s for the next token, but got %s", wait)
+	}
+
+	now = now.Add(3 * time.Second)
+	if _, ok := l.reserve(0); !ok {
+		t.Fatal("Bucket should be refilled")
+	}
+}
+
+func TestJSONAuditLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := NewJSONAuditLogger(buf)
+	logger.Log(AuditEntry{Caller: "job", Operation: OpSearch, Status: http.StatusOK})
+
+	var e AuditEntry
+	if err := json.Unmarshal(buf.Bytes(), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Caller != "job" || e.Operation != OpSearch {
+		t.Fatalf("Unexpected audit entry %+v", e)
+	}
+}
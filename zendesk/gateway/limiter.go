@@ -0,0 +1,84 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// errRateLimited is returned when the rate limit budget is not available in time
+var errRateLimited = errors.New("rate limit budget exhausted")
+
+// limiter is a token bucket shared by all callers of the gateway
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+	now      func() time.Time
+}
+
+func newLimiter(perMinute, burst int) *limiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &limiter{
+		interval: time.Minute / time.Duration(perMinute),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		now:      time.Now,
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait until
+// the token becomes valid. The token is not taken if the wait exceeds maxWait.
+func (l *limiter) reserve(maxWait time.Duration) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0, true
+	}
+
+	wait := time.Duration((1 - l.tokens) * float64(l.interval))
+	if wait > maxWait {
+		return 0, false
+	}
+
+	l.tokens--
+	return wait, true
+}
+
+// wait blocks until a token is available for the request
+func (l *limiter) wait(ctx context.Context, maxWait time.Duration) error {
+	d, ok := l.reserve(maxWait)
+	if !ok {
+		return errRateLimited
+	}
+	if d == 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,182 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// decodeBody reads the JSON request body into v
+func decodeBody(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return badRequestError{fmt.Sprintf("invalid request body: %s", err)}
+	}
+	return nil
+}
+
+// intQuery returns the integer query parameter of the given name or 0
+func intQuery(r *http.Request, name string) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, badRequestError{fmt.Sprintf("invalid %s: %s", name, v)}
+	}
+	return n, nil
+}
+
+func getTicket(s *Server, r *http.Request, id int64) (int, interface{}, error) {
+	ticket, err := s.api.GetTicket(r.Context(), id)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, map[string]interface{}{"ticket": ticket}, nil
+}
+
+func createTicket(s *Server, r *http.Request, _ int64) (int, interface{}, error) {
+	var data struct {
+		Ticket zendesk.Ticket `json:"ticket"`
+	}
+	if err := decodeBody(r, &data); err != nil {
+		return 0, nil, err
+	}
+
+	ticket, err := s.api.CreateTicket(r.Context(), data.Ticket)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, map[string]interface{}{"ticket": ticket}, nil
+}
+
+func updateTicket(s *Server, r *http.Request, id int64) (int, interface{}, error) {
+	var data struct {
+		Ticket zendesk.Ticket `json:"ticket"`
+	}
+	if err := decodeBody(r, &data); err != nil {
+		return 0, nil, err
+	}
+
+	ticket, err := s.api.UpdateTicket(r.Context(), id, data.Ticket)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, map[string]interface{}{"ticket": ticket}, nil
+}
+
+func listTicketComments(s *Server, r *http.Request, id int64) (int, interface{}, error) {
+	size, err := intQuery(r, "page[size]")
+	if err != nil {
+		return 0, nil, err
+	}
+
+	opts := &zendesk.ListTicketCommentsOptions{
+		CursorPagination: zendesk.CursorPagination{
+			PageSize:  size,
+			PageAfter: r.URL.Query().Get("page[after]"),
+		},
+	}
+
+	result, err := s.api.ListTicketComments(r.Context(), id, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, result, nil
+}
+
+func createTicketComment(s *Server, r *http.Request, id int64) (int, interface{}, error) {
+	var data struct {
+		Comment zendesk.TicketComment `json:"comment"`
+	}
+	if err := decodeBody(r, &data); err != nil {
+		return 0, nil, err
+	}
+
+	comment, err := s.api.CreateTicketComment(r.Context(), id, data.Comment)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, map[string]interface{}{"comment": comment}, nil
+}
+
+func getUser(s *Server, r *http.Request, id int64) (int, interface{}, error) {
+	user, err := s.api.GetUser(r.Context(), id)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, map[string]interface{}{"user": user}, nil
+}
+
+func searchUsers(s *Server, r *http.Request, _ int64) (int, interface{}, error) {
+	page, err := intQuery(r, "page")
+	if err != nil {
+		return 0, nil, err
+	}
+	perPage, err := intQuery(r, "per_page")
+	if err != nil {
+		return 0, nil, err
+	}
+
+	q := r.URL.Query()
+	opts := &zendesk.SearchUsersOptions{
+		PageOptions: zendesk.PageOptions{Page: page, PerPage: perPage},
+		ExternalIDs: q.Get("external_id"),
+		Query:       q.Get("query"),
+	}
+	if opts.Query == "" && opts.ExternalIDs == "" {
+		return 0, nil, badRequestError{"query or external_id is required"}
+	}
+
+	users, p, err := s.api.SearchUsers(r.Context(), opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, struct {
+		Users []zendesk.User `json:"users"`
+		zendesk.Page
+	}{users, p}, nil
+}
+
+func getOrganization(s *Server, r *http.Request, id int64) (int, interface{}, error) {
+	org, err := s.api.GetOrganization(r.Context(), id)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, map[string]interface{}{"organization": org}, nil
+}
+
+func search(s *Server, r *http.Request, _ int64) (int, interface{}, error) {
+	page, err := intQuery(r, "page")
+	if err != nil {
+		return 0, nil, err
+	}
+	perPage, err := intQuery(r, "per_page")
+	if err != nil {
+		return 0, nil, err
+	}
+
+	q := r.URL.Query()
+	opts := &zendesk.SearchOptions{
+		PageOptions: zendesk.PageOptions{Page: page, PerPage: perPage},
+		Query:       q.Get("query"),
+		SortBy:      q.Get("sort_by"),
+		SortOrder:   q.Get("sort_order"),
+	}
+	if opts.Query == "" {
+		return 0, nil, badRequestError{"query is required"}
+	}
+
+	results, p, err := s.api.Search(r.Context(), opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, struct {
+		Results []interface{} `json:"results"`
+		zendesk.Page
+	}{results.List(), p}, nil
+}
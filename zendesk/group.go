@@ -16,6 +16,7 @@ type Group struct {
 	Default     bool      `json:"default,omitempty"`
 	Deleted     bool      `json:"deleted,omitempty"`
 	Description string    `json:"description,omitempty"`
+	IsPublic    *bool     `json:"is_public,omitempty"`
 	CreatedAt   time.Time `json:"created_at,omitempty"`
 	UpdatedAt   time.Time `json:"updated_at,omitempty"`
 }
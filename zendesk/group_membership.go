@@ -3,6 +3,7 @@ package zendesk
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -28,12 +29,32 @@ type (
 		UserID  int64 `json:"user_id,omitempty" url:"user_id,omitempty"`
 	}
 
+	// JoinedGroupMembershipListOptions is a struct for options of the joined group membership list
+	// ref: https://developer.zendesk.com/api-reference/ticketing/groups/group_memberships/#sideloads
+	JoinedGroupMembershipListOptions struct {
+		GroupMembershipListOptions
+
+		// Include is a comma separated list of sideloads, e.g. "users,groups"
+		Include string `json:"include,omitempty" url:"include,omitempty"`
+	}
+
+	// JoinedGroupMembership is a group membership joined with its sideloaded
+	// user and group. User or Group is nil when it was not sideloaded.
+	JoinedGroupMembership struct {
+		GroupMembership
+		User  *User
+		Group *Group
+	}
+
 	// GroupMembershipAPI is an interface containing group membership related methods
 	GroupMembershipAPI interface {
 		GetGroupMemberships(context.Context, *GroupMembershipListOptions) ([]GroupMembership, Page, error)
+		GetJoinedGroupMemberships(ctx context.Context, opts *JoinedGroupMembershipListOptions) ([]JoinedGroupMembership, Page, error)
 		GetGroupMembershipsIterator(ctx context.Context, opts *PaginationOptions) *Iterator[GroupMembership]
 		GetGroupMembershipsOBP(ctx context.Context, opts *OBPOptions) ([]GroupMembership, Page, error)
 		GetGroupMembershipsCBP(ctx context.Context, opts *CBPOptions) ([]GroupMembership, CursorPaginationMeta, error)
+		CreateGroupMembership(ctx context.Context, membership GroupMembership) (GroupMembership, error)
+		DeleteGroupMembership(ctx context.Context, membershipID int64) error
 	}
 )
 
@@ -66,3 +87,82 @@ func (z *Client) GetGroupMemberships(ctx context.Context, opts *GroupMembershipL
 
 	return result.GroupMemberships, result.Page, nil
 }
+
+// GetJoinedGroupMemberships gets group memberships with their users and groups
+// sideloaded. Both are sideloaded unless opts.Include is specified.
+// ref: https://developer.zendesk.com/api-reference/ticketing/groups/group_memberships/#sideloads
+func (z *Client) GetJoinedGroupMemberships(ctx context.Context, opts *JoinedGroupMembershipListOptions) ([]JoinedGroupMembership, Page, error) {
+	var result struct {
+		GroupMemberships []GroupMembership `json:"group_memberships"`
+		sideloads
+		Page
+	}
+
+	tmp := JoinedGroupMembershipListOptions{}
+	if opts != nil {
+		tmp = *opts
+	}
+	if tmp.Include == "" {
+		tmp.Include = SideloadUsers + "," + SideloadGroups
+	}
+
+	u, err := addOptions("/group_memberships.json", tmp)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	body, err := z.get(ctx, u)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, Page{}, err
+	}
+
+	users := result.usersByID()
+	groups := result.groupsByID()
+	joined := make([]JoinedGroupMembership, len(result.GroupMemberships))
+	for i, m := range result.GroupMemberships {
+		joined[i] = JoinedGroupMembership{
+			GroupMembership: m,
+			User:            users[m.UserID],
+			Group:           groups[m.GroupID],
+		}
+	}
+
+	return joined, result.Page, nil
+}
+
+// CreateGroupMembership assigns an agent to a group
+// ref: https://developer.zendesk.com/api-reference/ticketing/groups/group_memberships/#create-membership
+func (z *Client) CreateGroupMembership(ctx context.Context, membership GroupMembership) (GroupMembership, error) {
+	var data struct {
+		GroupMembership struct {
+			UserID  int64 `json:"user_id"`
+			GroupID int64 `json:"group_id"`
+		} `json:"group_membership"`
+	}
+	var result struct {
+		GroupMembership GroupMembership `json:"group_membership"`
+	}
+	data.GroupMembership.UserID = membership.UserID
+	data.GroupMembership.GroupID = membership.GroupID
+
+	body, err := z.post(ctx, "/group_memberships.json", data)
+	if err != nil {
+		return GroupMembership{}, err
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return GroupMembership{}, err
+	}
+
+	return result.GroupMembership, nil
+}
+
+// DeleteGroupMembership removes an agent from a group
+// ref: https://developer.zendesk.com/api-reference/ticketing/groups/group_memberships/#delete-membership
+func (z *Client) DeleteGroupMembership(ctx context.Context, membershipID int64) error {
+	return z.delete(ctx, fmt.Sprintf("/group_memberships/%d.json", membershipID))
+}
@@ -2,6 +2,8 @@ package zendesk
 
 import (
 	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
 )
 
@@ -19,3 +21,71 @@ func TestGetGroupMemberships(t *testing.T) {
 		t.Fatalf("expected length of group memberships is 2, but got %d", len(groupMemberships))
 	}
 }
+
+func TestGetJoinedGroupMemberships(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/group_memberships.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		if include := r.URL.Query().Get("include"); include != "users,groups" {
+			t.Errorf("Expected users and groups to be sideloaded, but got include=%s", include)
+		}
+		w.Write(readFixture(filepath.Join(http.MethodGet, "group_memberships_sideloaded.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	memberships, _, err := client.GetJoinedGroupMemberships(ctx, &JoinedGroupMembershipListOptions{
+		GroupMembershipListOptions: GroupMembershipListOptions{GroupID: 123},
+	})
+	if err != nil {
+		t.Fatalf("Failed to get group memberships: %s", err)
+	}
+
+	if len(memberships) != 2 {
+		t.Fatalf("expected length of group memberships is 2, but got %d", len(memberships))
+	}
+	if memberships[0].User == nil || memberships[0].User.Name != "Johnny Agent" {
+		t.Fatalf("User of the membership is not joined: %v", memberships[0].User)
+	}
+	if memberships[0].Group == nil || memberships[0].Group.Name != "Support" {
+		t.Fatalf("Group of the membership is not joined: %v", memberships[0].Group)
+	}
+	if memberships[1].User == nil || memberships[1].User.ID != memberships[1].UserID {
+		t.Fatalf("User of the membership is not joined: %v", memberships[1].User)
+	}
+	if memberships[1].Group != nil {
+		t.Fatalf("Group which is not sideloaded should be nil, but got %v", memberships[1].Group)
+	}
+}
+
+func TestCreateGroupMembership(t *testing.T) {
+	mockAPI := newMockAPIWithStatus(http.MethodPost, "group_membership.json", http.StatusCreated)
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	membership, err := client.CreateGroupMembership(ctx, GroupMembership{UserID: 29, GroupID: 12})
+	if err != nil {
+		t.Fatalf("Failed to create group membership: %s", err)
+	}
+
+	if membership.ID != 461 {
+		t.Fatalf("Returned group membership does not have the expected ID 461. Group membership id is %d", membership.ID)
+	}
+}
+
+func TestDeleteGroupMembership(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/group_memberships/461.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	err := client.DeleteGroupMembership(ctx, 461)
+	if err != nil {
+		t.Fatalf("Failed to delete group membership: %s", err)
+	}
+}
@@ -0,0 +1,163 @@
+package zendesk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// job status values
+const (
+	JobStatusQueued    = "queued"
+	JobStatusWorking   = "working"
+	JobStatusFailed    = "failed"
+	JobStatusCompleted = "completed"
+	JobStatusKilled    = "killed"
+)
+
+// JobStatus is struct for job status payload of bulk operations
+// https://developer.zendesk.com/api-reference/ticketing/ticket-management/job_statuses/
+type JobStatus struct {
+	ID       string            `json:"id"`
+	URL      string            `json:"url,omitempty"`
+	Total    int               `json:"total"`
+	Progress int               `json:"progress"`
+	Status   string            `json:"status"`
+	Message  string            `json:"message,omitempty"`
+	Results  []JobStatusResult `json:"results,omitempty"`
+}
+
+// JobStatusResult is the result of an item of a bulk operation.
+// Index is the position of the item in the request. It is nil for the
+// results of some operations, such as updates, which only identify the
+// item by its ID, email or external ID.
+type JobStatusResult struct {
+	ID         int64  `json:"id,omitempty"`
+	Index      *int   `json:"index,omitempty"`
+	Action     string `json:"action,omitempty"`
+	Status     string `json:"status,omitempty"`
+	Success    bool   `json:"success,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Details    string `json:"details,omitempty"`
+	ExternalID string `json:"external_id,omitempty"`
+	Email      string `json:"email,omitempty"`
+}
+
+// Done reports whether the job has finished
+func (j JobStatus) Done() bool {
+	return j.Status == JobStatusCompleted || j.Status == JobStatusFailed || j.Status == JobStatusKilled
+}
+
+// JobStatusAPI an interface containing job status related methods
+type JobStatusAPI interface {
+	GetJobStatus(ctx context.Context, id string) (JobStatus, error)
+}
+
+// GetJobStatus gets a specified job status
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/ticket-management/job_statuses/#show-job-status
+func (z *Client) GetJobStatus(ctx context.Context, id string) (JobStatus, error) {
+	var result struct {
+		JobStatus JobStatus `json:"job_status"`
+	}
+
+	body, err := z.get(ctx, fmt.Sprintf("/job_statuses/%s.json", id))
+	if err != nil {
+		return JobStatus{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return JobStatus{}, err
+	}
+	return result.JobStatus, nil
+}
+
+// WaitJobStatus polls the job status every interval until the job finishes
+func WaitJobStatus(ctx context.Context, api API, job JobStatus, interval time.Duration) (JobStatus, error) {
+	for !job.Done() {
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var err error
+		job, err = api.GetJobStatus(ctx, job.ID)
+		if err != nil {
+			return job, err
+		}
+	}
+	return job, nil
+}
+
+// postJob posts data to the bulk operation endpoint and returns its job status
+func (z *Client) postJob(ctx context.Context, path string, data interface{}) (JobStatus, error) {
+	var result struct {
+		JobStatus JobStatus `json:"job_status"`
+	}
+
+	body, err := z.post(ctx, path, data)
+	if err != nil {
+		return JobStatus{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return JobStatus{}, err
+	}
+	return result.JobStatus, nil
+}
+
+// putJob puts data to the bulk operation endpoint and returns its job status
+func (z *Client) putJob(ctx context.Context, path string, data interface{}) (JobStatus, error) {
+	var result struct {
+		JobStatus JobStatus `json:"job_status"`
+	}
+
+	body, err := z.put(ctx, path, data)
+	if err != nil {
+		return JobStatus{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return JobStatus{}, err
+	}
+	return result.JobStatus, nil
+}
+
+// deleteJob deletes the resources of ids with the bulk operation endpoint and returns its job status
+func (z *Client) deleteJob(ctx context.Context, path string, ids []int64) (JobStatus, error) {
+	var result struct {
+		JobStatus JobStatus `json:"job_status"`
+	}
+
+	var opts struct {
+		IDs string `url:"ids"`
+	}
+	idStrs := make([]string, len(ids))
+	for i, id := range ids {
+		idStrs[i] = strconv.FormatInt(id, 10)
+	}
+	opts.IDs = strings.Join(idStrs, ",")
+
+	u, err := addOptions(path, opts)
+	if err != nil {
+		return JobStatus{}, err
+	}
+
+	body, err := z.deleteWithBody(ctx, u)
+	if err != nil {
+		return JobStatus{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return JobStatus{}, err
+	}
+	return result.JobStatus, nil
+}
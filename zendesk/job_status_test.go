@@ -0,0 +1,47 @@
+package zendesk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetJobStatus(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "job_status.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	job, err := client.GetJobStatus(ctx, "82de0b044094f0c67893ac9fe64f1a99")
+	if err != nil {
+		t.Fatalf("Failed to get job status: %s", err)
+	}
+
+	if !job.Done() || len(job.Results) != 2 || job.Results[1].ID != 245 {
+		t.Fatalf("Returned job status is not the expected one: %+v", job)
+	}
+}
+
+func TestWaitJobStatus(t *testing.T) {
+	calls := 0
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 2 {
+			w.Write(readFixture(filepath.Join(http.MethodPost, "job_status.json")))
+			return
+		}
+		w.Write(readFixture(filepath.Join(http.MethodGet, "job_status.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	job, err := WaitJobStatus(ctx, client, JobStatus{ID: "82de0b044094f0c67893ac9fe64f1a99", Status: JobStatusQueued}, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to wait job status: %s", err)
+	}
+
+	if job.Status != JobStatusCompleted || calls != 2 {
+		t.Fatalf("Job should be polled until completed: %+v after %d calls", job, calls)
+	}
+}
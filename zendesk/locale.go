@@ -17,6 +17,21 @@ type Locale struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+type localeContextKey struct{}
+
+// WithLocale returns a copy of ctx which carries a locale such as "ja" or "pt-BR".
+// Requests sent with the returned context ask for the locale with Accept-Language,
+// so that localized properties and dynamic content are rendered in it.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// LocaleFromContext returns the locale carried by ctx, or "" if ctx has no locale.
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeContextKey{}).(string)
+	return locale
+}
+
 // LocaleAPI an interface containing all of the local related zendesk methods
 type LocaleAPI interface {
 	GetLocales(ctx context.Context) ([]Locale, error)
@@ -0,0 +1,315 @@
+package zendesk
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// weights of the scores of MacroRecommendation
+const (
+	macroContentWeight    = 0.6
+	macroUsageWeight      = 0.35
+	macroPopularityWeight = 0.05
+)
+
+var macroPlaceholderPattern = regexp.MustCompile(`\{\{[^}]*\}\}`)
+
+var macroStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "but": true,
+	"by": true, "can": true, "do": true, "for": true, "from": true, "have": true, "hi": true, "hello": true,
+	"how": true, "i": true, "if": true, "in": true, "is": true, "it": true, "me": true, "my": true,
+	"no": true, "not": true, "of": true, "on": true, "or": true, "our": true, "please": true, "so": true,
+	"thank": true, "thanks": true, "that": true, "the": true, "this": true, "to": true, "was": true,
+	"we": true, "what": true, "when": true, "with": true, "you": true, "your": true,
+}
+
+// MacroRecommendation is a macro ranked by MacroRecommender.
+// Score is the weighted sum of ContentScore, UsageScore and the popularity of the macro.
+type MacroRecommendation struct {
+	Macro        Macro   `json:"macro"`
+	Score        float64 `json:"score"`
+	ContentScore float64 `json:"content_score"`
+	UsageScore   float64 `json:"usage_score"`
+}
+
+// MacroRecommender ranks macros for a ticket locally by TF-IDF cosine similarity
+// between the ticket conversation and the text of each macro (title, description,
+// comment and subject actions), and between the conversation and the tickets
+// the macro was applied to in the past.
+// It is safe for concurrent use.
+type MacroRecommender struct {
+	mu sync.Mutex
+
+	macros map[int64]Macro
+	docs   map[int64]map[string]float64
+	usage  map[int64]map[string]float64
+	uses   map[int64]int
+
+	// idf is recomputed when the documents change
+	idf   map[string]float64
+	dirty bool
+}
+
+// NewMacroRecommender returns a MacroRecommender for the active macros
+func NewMacroRecommender(macros []Macro) *MacroRecommender {
+	r := &MacroRecommender{
+		macros: map[int64]Macro{},
+		docs:   map[int64]map[string]float64{},
+		usage:  map[int64]map[string]float64{},
+		uses:   map[int64]int{},
+		dirty:  true,
+	}
+
+	for _, m := range macros {
+		if !m.Active {
+			continue
+		}
+		r.macros[m.ID] = m
+		r.docs[m.ID] = termCounts(macroText(m))
+	}
+	return r
+}
+
+// Learn records that the macros in ticket.MacroIDs were applied to the ticket,
+// so macros are recommended for tickets with similar conversations
+func (r *MacroRecommender) Learn(ticket Ticket, comments []TicketComment) {
+	if len(ticket.MacroIDs) == 0 {
+		return
+	}
+	counts := termCounts(ticketText(ticket, comments))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range ticket.MacroIDs {
+		if _, ok := r.macros[id]; !ok {
+			continue
+		}
+		if r.usage[id] == nil {
+			r.usage[id] = map[string]float64{}
+		}
+		for term, n := range counts {
+			r.usage[id][term] += n
+		}
+		r.uses[id]++
+	}
+	r.dirty = true
+}
+
+// Recommend returns up to limit macros ranked for the ticket, excluding the macros
+// already applied to it. Macros which are not similar to the ticket at all are omitted.
+func (r *MacroRecommender) Recommend(ticket Ticket, comments []TicketComment, limit int) []MacroRecommendation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.dirty {
+		r.computeIDF()
+	}
+
+	query := r.vector(termCounts(ticketText(ticket, comments)))
+	applied := map[int64]bool{}
+	for _, id := range ticket.MacroIDs {
+		applied[id] = true
+	}
+
+	maxUses := 0
+	for _, n := range r.uses {
+		if n > maxUses {
+			maxUses = n
+		}
+	}
+
+	var recs []MacroRecommendation
+	for id, m := range r.macros {
+		if applied[id] {
+			continue
+		}
+
+		rec := MacroRecommendation{
+			Macro:        m,
+			ContentScore: cosine(query, r.vector(r.docs[id])),
+		}
+		if counts := r.usage[id]; counts != nil {
+			rec.UsageScore = cosine(query, r.vector(counts))
+		}
+		if rec.ContentScore == 0 && rec.UsageScore == 0 {
+			continue
+		}
+
+		rec.Score = macroContentWeight*rec.ContentScore + macroUsageWeight*rec.UsageScore
+		if maxUses > 0 {
+			rec.Score += macroPopularityWeight * math.Log1p(float64(r.uses[id])) / math.Log1p(float64(maxUses))
+		}
+		recs = append(recs, rec)
+	}
+
+	sort.Slice(recs, func(i, j int) bool {
+		if recs[i].Score != recs[j].Score {
+			return recs[i].Score > recs[j].Score
+		}
+		return recs[i].Macro.ID < recs[j].Macro.ID
+	})
+	if limit > 0 && len(recs) > limit {
+		recs = recs[:limit]
+	}
+	return recs
+}
+
+// computeIDF computes the smoothed inverse document frequency of the terms
+// over the macro documents and the usage documents
+func (r *MacroRecommender) computeIDF() {
+	df := map[string]int{}
+	n := 0
+	for _, docs := range []map[int64]map[string]float64{r.docs, r.usage} {
+		for _, doc := range docs {
+			n++
+			for term := range doc {
+				df[term]++
+			}
+		}
+	}
+
+	r.idf = make(map[string]float64, len(df))
+	for term, f := range df {
+		r.idf[term] = math.Log(float64(n+1)/float64(f+1)) + 1
+	}
+	r.dirty = false
+}
+
+// vector returns the TF-IDF vector of the term counts. Unknown terms are ignored.
+func (r *MacroRecommender) vector(counts map[string]float64) map[string]float64 {
+	v := make(map[string]float64, len(counts))
+	for term, n := range counts {
+		if idf, ok := r.idf[term]; ok {
+			v[term] = (1 + math.Log(n)) * idf
+		}
+	}
+	return v
+}
+
+func cosine(a, b map[string]float64) float64 {
+	var dot, na, nb float64
+	for term, x := range a {
+		na += x * x
+		if y, ok := b[term]; ok {
+			dot += x * y
+		}
+	}
+	for _, y := range b {
+		nb += y * y
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
+
+// macroText returns the text of the macro used for the recommendation
+func macroText(m Macro) string {
+	parts := []string{strings.ReplaceAll(m.Title, "::", " ")}
+	if d, ok := m.Description.(string); ok {
+		parts = append(parts, d)
+	}
+	for _, a := range m.Actions {
+		switch a.Field {
+		case "comment_value", "comment_value_html", "subject", "set_tags", "current_tags":
+			parts = append(parts, a.Value)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// ticketText returns the conversation of the ticket
+func ticketText(ticket Ticket, comments []TicketComment) string {
+	parts := []string{ticket.Subject}
+	if len(comments) == 0 {
+		parts = append(parts, ticket.Description)
+	}
+	for _, c := range comments {
+		if c.PlainBody != "" {
+			parts = append(parts, c.PlainBody)
+		} else {
+			parts = append(parts, c.Body)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// termCounts tokenizes the text into lower-cased words without placeholders and stop words
+func termCounts(text string) map[string]float64 {
+	counts := map[string]float64{}
+	text = macroPlaceholderPattern.ReplaceAllString(strings.ToLower(text), " ")
+
+	words := strings.FieldsFunc(text, func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_'
+	})
+	for _, w := range words {
+		if len([]rune(w)) < 2 || macroStopWords[w] {
+			continue
+		}
+		// reduce plurals like "refunds" to "refund"
+		if len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+			w = w[:len(w)-1]
+		}
+		counts[w]++
+	}
+	return counts
+}
+
+// BuildMacroRecommender fetches the macros of the account and learns their past usage
+// from the tickets of ticketIDs, such as recently solved tickets
+func BuildMacroRecommender(ctx context.Context, api API, ticketIDs []int64) (*MacroRecommender, error) {
+	var macros []Macro
+	it := api.GetMacrosIterator(ctx, NewPaginationOptions())
+	for it.HasMore() {
+		page, err := it.GetNext()
+		if err != nil {
+			return nil, err
+		}
+		macros = append(macros, page...)
+	}
+
+	r := NewMacroRecommender(macros)
+	for _, id := range ticketIDs {
+		ticket, comments, err := getTicketConversation(ctx, api, id)
+		if err != nil {
+			return nil, err
+		}
+		r.Learn(ticket, comments)
+	}
+	return r, nil
+}
+
+// RecommendMacros fetches the ticket and its comments, and returns up to limit macros ranked for it
+func RecommendMacros(ctx context.Context, api API, r *MacroRecommender, ticketID int64, limit int) ([]MacroRecommendation, error) {
+	ticket, comments, err := getTicketConversation(ctx, api, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	return r.Recommend(ticket, comments, limit), nil
+}
+
+func getTicketConversation(ctx context.Context, api API, ticketID int64) (Ticket, []TicketComment, error) {
+	ticket, err := api.GetTicket(ctx, ticketID)
+	if err != nil {
+		return Ticket{}, nil, err
+	}
+
+	var comments []TicketComment
+	opts := NewPaginationOptions()
+	opts.Id = ticketID
+	it := api.GetTicketCommentsIterator(ctx, opts)
+	for it.HasMore() {
+		page, err := it.GetNext()
+		if err != nil {
+			return Ticket{}, nil, err
+		}
+		comments = append(comments, page...)
+	}
+	return ticket, comments, nil
+}
@@ -0,0 +1,108 @@
+package zendesk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func recommenderTestMacros() []Macro {
+	return []Macro{
+		{
+			ID: 1, Active: true, Title: "Billing::Refund issued",
+			Actions: []MacroAction{{Field: "comment_value", Value: "Hi {{ticket.requester.first_name}}, we issued a refund for the duplicate charge."}},
+		},
+		{
+			ID: 2, Active: true, Title: "Account::Password reset",
+			Actions: []MacroAction{{Field: "comment_value", Value: "Use the reset link to reset your password and log in again."}},
+		},
+		{
+			ID: 3, Active: true, Title: "Shipping::Tracking number",
+			Actions: []MacroAction{{Field: "comment_value", Value: "Your order has shipped. Here is the tracking number."}},
+		},
+		{ID: 4, Active: false, Title: "Refund refund refund"},
+	}
+}
+
+func TestMacroRecommenderContent(t *testing.T) {
+	r := NewMacroRecommender(recommenderTestMacros())
+
+	recs := r.Recommend(Ticket{Subject: "Charged twice"}, []TicketComment{
+		{Body: "I was charged twice this month, can I get a refund?"},
+	}, 2)
+	if len(recs) == 0 || recs[0].Macro.ID != 1 {
+		t.Fatalf("Refund macro should be recommended first: %+v", recs)
+	}
+	for _, rec := range recs {
+		if rec.Macro.ID == 4 {
+			t.Fatal("Inactive macro should not be recommended")
+		}
+	}
+
+	recs = r.Recommend(Ticket{Subject: "Cannot log in", Description: "I forgot my password"}, nil, 0)
+	if len(recs) != 1 || recs[0].Macro.ID != 2 {
+		t.Fatalf("Only password reset macro should be recommended: %+v", recs)
+	}
+}
+
+func TestMacroRecommenderUsage(t *testing.T) {
+	r := NewMacroRecommender(recommenderTestMacros())
+
+	// the tracking macro has been used for tickets about late parcels,
+	// which share no words with the macro itself
+	for i := 0; i < 3; i++ {
+		r.Learn(Ticket{Subject: "Parcel is late", MacroIDs: []int64{3}}, []TicketComment{
+			{Body: "My parcel still hasn't arrived"},
+		})
+	}
+	r.Learn(Ticket{Subject: "Unknown macro", MacroIDs: []int64{99}}, nil)
+
+	recs := r.Recommend(Ticket{Subject: "Where is my parcel?"}, nil, 3)
+	if len(recs) == 0 || recs[0].Macro.ID != 3 || recs[0].UsageScore == 0 || recs[0].ContentScore != 0 {
+		t.Fatalf("Tracking macro should be recommended from its usage: %+v", recs)
+	}
+
+	recs = r.Recommend(Ticket{Subject: "Where is my parcel?", MacroIDs: []int64{3}}, nil, 3)
+	for _, rec := range recs {
+		if rec.Macro.ID == 3 {
+			t.Fatal("Macros applied to the ticket should not be recommended")
+		}
+	}
+}
+
+func TestRecommendMacros(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/macros.json":
+			w.Write([]byte(`{"macros":[
+				{"id":1,"active":true,"title":"Refund issued","actions":[{"field":"comment_value","value":"We issued a refund."}]},
+				{"id":2,"active":true,"title":"Password reset","actions":[]}
+			],"meta":{"has_more":false}}`))
+		case "/tickets/10.json":
+			w.Write([]byte(`{"ticket":{"id":10,"subject":"Money back","macro_ids":[1]}}`))
+		case "/tickets/10/comments.json":
+			w.Write([]byte(`{"comments":[{"id":1,"body":"I want my money back"}],"meta":{"has_more":false}}`))
+		case "/tickets/11.json":
+			w.Write([]byte(`{"ticket":{"id":11,"subject":"Money"}}`))
+		case "/tickets/11/comments.json":
+			w.Write([]byte(`{"comments":[{"id":2,"plain_body":"Can I have my money back?"}],"meta":{"has_more":false}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	r, err := BuildMacroRecommender(ctx, client, []int64{10})
+	if err != nil {
+		t.Fatalf("Failed to build macro recommender: %s", err)
+	}
+
+	recs, err := RecommendMacros(ctx, client, r, 11, 5)
+	if err != nil {
+		t.Fatalf("Failed to recommend macros: %s", err)
+	}
+	if len(recs) != 1 || recs[0].Macro.ID != 1 {
+		t.Fatalf("Refund macro should be recommended: %+v", recs)
+	}
+}
@@ -0,0 +1,691 @@
+// Package migration copies groups, organizations, users and tickets from one
+// Zendesk account to another. Tickets are copied with their full comment history
+// and attachments through the ticket import API. The mapping from source IDs to
+// target IDs is kept in a Store, so an interrupted migration can be resumed.
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// Config is configuration of the Migrator
+type Config struct {
+	// Source is the account to copy from. Required.
+	Source zendesk.API
+
+	// Target is the account to copy to. Required.
+	Target zendesk.API
+
+	// Store keeps the ID mapping. Defaults to a MemoryStore, which cannot resume a migration.
+	Store Store
+
+	// TicketFieldIDs maps the IDs of ticket fields in the source account to the ones in the
+	// target account. Custom fields without a mapping are not copied.
+	TicketFieldIDs map[int64]int64
+
+	// ArchiveImmediately archives closed tickets right after they are imported
+	ArchiveImmediately bool
+
+	// SourceCredential authenticates the downloads of attachments in the source account.
+	// Required unless Download is set, because attachments of private comments are not public.
+	SourceCredential zendesk.Credential
+
+	// Download fetches the content of an attachment in the source account.
+	// Defaults to GET of its content URL authenticated with SourceCredential.
+	Download func(ctx context.Context, attachment zendesk.Attachment) (io.ReadCloser, error)
+}
+
+// Failure is a resource which failed to be migrated
+type Failure struct {
+	Kind     Kind
+	SourceID int64
+	Err      error
+}
+
+func (f Failure) Error() string {
+	return fmt.Sprintf("%s %d: %s", f.Kind, f.SourceID, f.Err)
+}
+
+// Report summarizes a migration
+type Report struct {
+	// Created is the number of resources created in the target account
+	Created map[Kind]int
+
+	// Matched is the number of resources which already existed in the target account
+	Matched map[Kind]int
+
+	// Skipped is the number of resources migrated by an earlier run
+	Skipped map[Kind]int
+
+	// Failures are the resources which failed to be migrated. They are retried by the next run.
+	Failures []Failure
+}
+
+// Migrator copies resources from the source account to the target account
+type Migrator struct {
+	cfg Config
+
+	mu     sync.Mutex
+	report Report
+
+	// name indexes of the target account, loaded on first use
+	targetGroups map[string]int64
+	targetOrgs   map[string]int64
+
+	// imported are the source IDs of the tickets imported by this run. The
+	// export lists a ticket again when it changes during the run, and problem
+	// tickets are imported ahead of their turn with their incidents.
+	imported map[int64]bool
+}
+
+// New returns a Migrator
+func New(cfg Config) (*Migrator, error) {
+	if cfg.Source == nil || cfg.Target == nil {
+		return nil, errors.New("migration: source and target are required")
+	}
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryStore()
+	}
+	if cfg.Download == nil {
+		if cfg.SourceCredential == nil {
+			return nil, errors.New("migration: source credential is required to download attachments")
+		}
+		cfg.Download = attachmentDownloader(cfg.SourceCredential)
+	}
+
+	return &Migrator{
+		cfg: cfg,
+		report: Report{
+			Created: map[Kind]int{},
+			Matched: map[Kind]int{},
+			Skipped: map[Kind]int{},
+		},
+	}, nil
+}
+
+// Run migrates groups, organizations, users and tickets in this order.
+// It returns an error when listing the source account fails. Failures of
+// single resources are recorded in the report and do not stop the migration.
+func (m *Migrator) Run(ctx context.Context) (Report, error) {
+	steps := []func(context.Context) error{
+		m.MigrateGroups,
+		m.MigrateOrganizations,
+		m.MigrateUsers,
+		m.MigrateTickets,
+	}
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			return m.Report(), err
+		}
+	}
+	return m.Report(), nil
+}
+
+// Report returns the summary of the migration so far
+func (m *Migrator) Report() Report {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r := Report{
+		Created:  map[Kind]int{},
+		Matched:  map[Kind]int{},
+		Skipped:  map[Kind]int{},
+		Failures: append([]Failure(nil), m.report.Failures...),
+	}
+	for k, v := range m.report.Created {
+		r.Created[k] = v
+	}
+	for k, v := range m.report.Matched {
+		r.Matched[k] = v
+	}
+	for k, v := range m.report.Skipped {
+		r.Skipped[k] = v
+	}
+	return r
+}
+
+// MigrateGroups copies the groups which do not exist in the target account.
+// Groups are matched by name.
+func (m *Migrator) MigrateGroups(ctx context.Context) error {
+	it := m.cfg.Source.GetGroupsIterator(ctx, zendesk.NewPaginationOptions())
+	for it.HasMore() {
+		groups, err := it.GetNext()
+		if err != nil {
+			return err
+		}
+		for _, group := range groups {
+			if group.Deleted {
+				continue
+			}
+			if m.migrated(KindGroup, group.ID) {
+				continue
+			}
+			if _, err := m.migrateGroup(ctx, group); err != nil {
+				m.fail(KindGroup, group.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// MigrateOrganizations copies the organizations which do not exist in the target account.
+// Organizations are matched by external ID, then by name.
+func (m *Migrator) MigrateOrganizations(ctx context.Context) error {
+	it := m.cfg.Source.GetOrganizationsIterator(ctx, zendesk.NewPaginationOptions())
+	for it.HasMore() {
+		orgs, err := it.GetNext()
+		if err != nil {
+			return err
+		}
+		for _, org := range orgs {
+			if m.migrated(KindOrganization, org.ID) {
+				continue
+			}
+			if _, err := m.migrateOrganization(ctx, org); err != nil {
+				m.fail(KindOrganization, org.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// MigrateUsers copies the users which do not exist in the target account.
+// Users are matched by external ID, then by email.
+func (m *Migrator) MigrateUsers(ctx context.Context) error {
+	it := m.cfg.Source.GetUsersIterator(ctx, zendesk.NewPaginationOptions())
+	for it.HasMore() {
+		users, err := it.GetNext()
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			if m.migrated(KindUser, user.ID) {
+				continue
+			}
+			if _, err := m.migrateUser(ctx, user); err != nil {
+				m.fail(KindUser, user.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// MigrateTickets imports the tickets with their comments and attachments.
+// Tickets are read from the incremental ticket export, as the ticket list
+// leaves out archived tickets. Deleted tickets are not migrated. Users and
+// problem tickets referred by a ticket which have not been migrated yet are
+// migrated first.
+func (m *Migrator) MigrateTickets(ctx context.Context) error {
+	// the export starts at the given time, and 0 is omitted from the query
+	opts := &zendesk.CursorOption{StartTime: 1}
+	for {
+		tickets, meta, err := m.cfg.Source.GetIncrementalTickets(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, ticket := range tickets {
+			if ticket.Status == "deleted" || m.imported[ticket.ID] {
+				continue
+			}
+			if m.migrated(KindTicket, ticket.ID) {
+				continue
+			}
+			if err := m.migrateTicket(ctx, ticket); err != nil {
+				m.fail(KindTicket, ticket.ID, err)
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+		}
+		if meta.EndOfStream || meta.AfterCursor == "" {
+			return nil
+		}
+		opts = &zendesk.CursorOption{Cursor: meta.AfterCursor}
+	}
+}
+
+func (m *Migrator) migrateGroup(ctx context.Context, group zendesk.Group) (int64, error) {
+	if id, ok, err := m.lookup(KindGroup, group.ID); ok || err != nil {
+		return id, err
+	}
+
+	if m.targetGroups == nil {
+		m.targetGroups = map[string]int64{}
+		it := m.cfg.Target.GetGroupsIterator(ctx, zendesk.NewPaginationOptions())
+		for it.HasMore() {
+			groups, err := it.GetNext()
+			if err != nil {
+				m.targetGroups = nil
+				return 0, err
+			}
+			for _, g := range groups {
+				m.targetGroups[strings.ToLower(g.Name)] = g.ID
+			}
+		}
+	}
+	if id, ok := m.targetGroups[strings.ToLower(group.Name)]; ok {
+		return id, m.record(KindGroup, group.ID, id, false)
+	}
+
+	created, err := m.cfg.Target.CreateGroup(ctx, zendesk.Group{
+		Name:        group.Name,
+		Description: group.Description,
+	})
+	if err != nil {
+		return 0, err
+	}
+	m.targetGroups[strings.ToLower(created.Name)] = created.ID
+	return created.ID, m.record(KindGroup, group.ID, created.ID, true)
+}
+
+func (m *Migrator) migrateOrganization(ctx context.Context, org zendesk.Organization) (int64, error) {
+	if id, ok, err := m.lookup(KindOrganization, org.ID); ok || err != nil {
+		return id, err
+	}
+
+	if org.ExternalID != "" {
+		orgs, _, err := m.cfg.Target.GetOrganizationByExternalID(ctx, org.ExternalID)
+		if err != nil {
+			return 0, err
+		}
+		if len(orgs) > 0 {
+			return orgs[0].ID, m.record(KindOrganization, org.ID, orgs[0].ID, false)
+		}
+	}
+
+	if m.targetOrgs == nil {
+		m.targetOrgs = map[string]int64{}
+		it := m.cfg.Target.GetOrganizationsIterator(ctx, zendesk.NewPaginationOptions())
+		for it.HasMore() {
+			orgs, err := it.GetNext()
+			if err != nil {
+				m.targetOrgs = nil
+				return 0, err
+			}
+			for _, o := range orgs {
+				m.targetOrgs[strings.ToLower(o.Name)] = o.ID
+			}
+		}
+	}
+	if id, ok := m.targetOrgs[strings.ToLower(org.Name)]; ok {
+		return id, m.record(KindOrganization, org.ID, id, false)
+	}
+
+	groupID, err := m.mapped(KindGroup, org.GroupID)
+	if err != nil {
+		return 0, err
+	}
+
+	created, err := m.cfg.Target.CreateOrganization(ctx, zendesk.Organization{
+		ExternalID:         org.ExternalID,
+		Name:               org.Name,
+		Details:            org.Details,
+		DomainNames:        org.DomainNames,
+		GroupID:            groupID,
+		SharedTickets:      org.SharedTickets,
+		SharedComments:     org.SharedComments,
+		Tags:               org.Tags,
+		Notes:              org.Notes,
+		OrganizationFields: org.OrganizationFields,
+	})
+	if err != nil {
+		return 0, err
+	}
+	m.targetOrgs[strings.ToLower(created.Name)] = created.ID
+	return created.ID, m.record(KindOrganization, org.ID, created.ID, true)
+}
+
+func (m *Migrator) migrateUser(ctx context.Context, user zendesk.User) (int64, error) {
+	if id, ok, err := m.lookup(KindUser, user.ID); ok || err != nil {
+		return id, err
+	}
+
+	if user.ExternalID != "" {
+		users, _, err := m.cfg.Target.SearchUsers(ctx, &zendesk.SearchUsersOptions{ExternalIDs: user.ExternalID})
+		if err != nil {
+			return 0, err
+		}
+		for _, u := range users {
+			if u.ExternalID == user.ExternalID {
+				return u.ID, m.record(KindUser, user.ID, u.ID, false)
+			}
+		}
+	}
+	if user.Email != "" {
+		users, _, err := m.cfg.Target.SearchUsers(ctx, &zendesk.SearchUsersOptions{Query: user.Email})
+		if err != nil {
+			return 0, err
+		}
+		for _, u := range users {
+			if strings.EqualFold(u.Email, user.Email) {
+				return u.ID, m.record(KindUser, user.ID, u.ID, false)
+			}
+		}
+	}
+
+	orgID, err := m.mapped(KindOrganization, user.OrganizationID)
+	if err != nil {
+		return 0, err
+	}
+	groupID, err := m.mapped(KindGroup, user.DefaultGroupID)
+	if err != nil {
+		return 0, err
+	}
+
+	created, err := m.cfg.Target.CreateUser(ctx, zendesk.User{
+		Email:               user.Email,
+		Name:                user.Name,
+		Alias:               user.Alias,
+		DefaultGroupID:      groupID,
+		Details:             user.Details,
+		ExternalID:          user.ExternalID,
+		IanaTimezone:        user.IanaTimezone,
+		Locale:              user.Locale,
+		Moderator:           user.Moderator,
+		Notes:               user.Notes,
+		OnlyPrivateComments: user.OnlyPrivateComments,
+		OrganizationID:      orgID,
+		Phone:               user.Phone,
+		RemotePhotoURL:      user.Photo.ContentURL,
+		Role:                user.Role,
+		Signature:           user.Signature,
+		Suspended:           user.Suspended,
+		Tags:                user.Tags,
+		TicketRestriction:   user.TicketRestriction,
+		Timezone:            user.Timezone,
+		UserFields:          user.UserFields,
+		Verified:            user.Verified,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return created.ID, m.record(KindUser, user.ID, created.ID, true)
+}
+
+// ensureUser returns the target ID of the source user, migrating the user if needed
+func (m *Migrator) ensureUser(ctx context.Context, sourceID int64) (int64, error) {
+	if sourceID == 0 {
+		return 0, nil
+	}
+	if id, ok, err := m.lookup(KindUser, sourceID); ok || err != nil {
+		return id, err
+	}
+
+	user, err := m.cfg.Source.GetUser(ctx, sourceID)
+	if err != nil {
+		return 0, err
+	}
+	id, err := m.migrateUser(ctx, user)
+	if err != nil {
+		m.fail(KindUser, sourceID, err)
+		return 0, fmt.Errorf("user %d cannot be migrated: %w", sourceID, err)
+	}
+	return id, nil
+}
+
+func (m *Migrator) ensureUsers(ctx context.Context, sourceIDs []int64) ([]int64, error) {
+	if len(sourceIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, 0, len(sourceIDs))
+	for _, sourceID := range sourceIDs {
+		id, err := m.ensureUser(ctx, sourceID)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ensureProblem returns the target ID of the problem ticket of an incident,
+// migrating the problem if needed so that the link is not dropped
+func (m *Migrator) ensureProblem(ctx context.Context, sourceID int64) (int64, error) {
+	if sourceID == 0 {
+		return 0, nil
+	}
+	if id, ok, err := m.lookup(KindTicket, sourceID); ok || err != nil {
+		return id, err
+	}
+
+	problem, err := m.cfg.Source.GetTicket(ctx, sourceID)
+	if err == nil {
+		err = m.migrateTicket(ctx, problem)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("problem ticket %d cannot be migrated: %w", sourceID, err)
+	}
+	id, _, err := m.lookup(KindTicket, sourceID)
+	return id, err
+}
+
+func (m *Migrator) migrateTicket(ctx context.Context, ticket zendesk.Ticket) error {
+	if _, ok, err := m.lookup(KindTicket, ticket.ID); ok || err != nil {
+		return err
+	}
+
+	imported := zendesk.TicketImport{
+		Ticket: zendesk.Ticket{
+			ExternalID: ticket.ExternalID,
+			Type:       ticket.Type,
+			Subject:    ticket.Subject,
+			Priority:   ticket.Priority,
+			Status:     ticket.Status,
+			DueAt:      ticket.DueAt,
+			Tags:       ticket.Tags,
+			CreatedAt:  ticket.CreatedAt,
+			UpdatedAt:  ticket.UpdatedAt,
+		},
+	}
+
+	var err error
+	t := &imported.Ticket
+	if t.RequesterID, err = m.ensureUser(ctx, ticket.RequesterID); err != nil {
+		return err
+	}
+	if t.SubmitterID, err = m.ensureUser(ctx, ticket.SubmitterID); err != nil {
+		return err
+	}
+	if t.AssigneeID, err = m.ensureUser(ctx, ticket.AssigneeID); err != nil {
+		return err
+	}
+	if t.CollaboratorIDs, err = m.ensureUsers(ctx, ticket.CollaboratorIDs); err != nil {
+		return err
+	}
+	if t.FollowerIDs, err = m.ensureUsers(ctx, ticket.FollowerIDs); err != nil {
+		return err
+	}
+	if t.EmailCCIDs, err = m.ensureUsers(ctx, ticket.EmailCCIDs); err != nil {
+		return err
+	}
+	if t.OrganizationID, err = m.mapped(KindOrganization, ticket.OrganizationID); err != nil {
+		return err
+	}
+	if t.ProblemID, err = m.ensureProblem(ctx, ticket.ProblemID); err != nil {
+		return err
+	}
+	if sourceGroupID, _ := ticket.GroupID.Int64(); sourceGroupID != 0 {
+		groupID, err := m.mapped(KindGroup, sourceGroupID)
+		if err != nil {
+			return err
+		}
+		if groupID != 0 {
+			t.GroupID = json.Number(strconv.FormatInt(groupID, 10))
+		}
+	}
+	for _, cf := range ticket.CustomFields {
+		if id, ok := m.cfg.TicketFieldIDs[cf.ID]; ok && cf.Value != nil {
+			t.CustomFields = append(t.CustomFields, zendesk.CustomField{ID: id, Value: cf.Value})
+		}
+	}
+
+	opts := zendesk.NewPaginationOptions()
+	opts.Id = ticket.ID
+	it := m.cfg.Source.GetTicketCommentsIterator(ctx, opts)
+	for it.HasMore() {
+		comments, err := it.GetNext()
+		if err != nil {
+			return err
+		}
+		for _, comment := range comments {
+			c, err := m.importComment(ctx, comment)
+			if err != nil {
+				return err
+			}
+			imported.Comments = append(imported.Comments, c)
+		}
+	}
+
+	created, err := m.cfg.Target.ImportTicket(ctx, imported, &zendesk.TicketImportOptions{
+		ArchiveImmediately: m.cfg.ArchiveImmediately,
+	})
+	if err != nil {
+		return err
+	}
+	if m.imported == nil {
+		m.imported = map[int64]bool{}
+	}
+	m.imported[ticket.ID] = true
+	return m.record(KindTicket, ticket.ID, created.ID, true)
+}
+
+// importComment converts a source comment to a comment of the ticket import,
+// uploading its attachments to the target account
+func (m *Migrator) importComment(ctx context.Context, comment zendesk.TicketComment) (zendesk.TicketComment, error) {
+	authorID, err := m.ensureUser(ctx, comment.AuthorID)
+	if err != nil {
+		return zendesk.TicketComment{}, err
+	}
+
+	c := zendesk.TicketComment{
+		Public:    comment.Public,
+		AuthorID:  authorID,
+		CreatedAt: comment.CreatedAt,
+	}
+	if comment.HTMLBody != "" {
+		c.HTMLBody = comment.HTMLBody
+	} else {
+		c.Body = comment.Body
+	}
+
+	token := ""
+	for _, attachment := range comment.Attachments {
+		token, err = m.copyAttachment(ctx, attachment, token)
+		if err != nil {
+			return zendesk.TicketComment{}, fmt.Errorf("attachment %d cannot be copied: %w", attachment.ID, err)
+		}
+	}
+	if token != "" {
+		c.Uploads = []string{token}
+	}
+	return c, nil
+}
+
+// copyAttachment uploads the attachment to the target account with the upload token.
+// It returns the token of the upload which the attachment was added to.
+func (m *Migrator) copyAttachment(ctx context.Context, attachment zendesk.Attachment, token string) (string, error) {
+	r, err := m.cfg.Download(ctx, attachment)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	w := m.cfg.Target.UploadAttachment(ctx, attachment.FileName, token)
+	if _, err := io.Copy(w, r); err != nil {
+		w.Close()
+		return "", err
+	}
+	upload, err := w.Close()
+	if err != nil {
+		return "", err
+	}
+	return upload.Token, nil
+}
+
+// attachmentDownloader returns a Download which sends GET to the content URL
+// of an attachment with the credential of the source account
+func attachmentDownloader(cred zendesk.Credential) func(context.Context, zendesk.Attachment) (io.ReadCloser, error) {
+	return func(ctx context.Context, attachment zendesk.Attachment) (io.ReadCloser, error) {
+		return downloadAttachment(ctx, cred, attachment)
+	}
+}
+
+func downloadAttachment(ctx context.Context, cred zendesk.Credential, attachment zendesk.Attachment) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, attachment.ContentURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cred.Bearer() {
+		req.Header.Set("Authorization", "Bearer "+cred.Secret())
+	} else {
+		req.SetBasicAuth(cred.Email(), cred.Secret())
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s", attachment.ContentURL, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// lookup returns the target ID of a resource migrated earlier
+func (m *Migrator) lookup(kind Kind, sourceID int64) (int64, bool, error) {
+	return m.cfg.Store.Get(kind, sourceID)
+}
+
+// migrated reports whether the resource was migrated by an earlier run and counts it as skipped
+func (m *Migrator) migrated(kind Kind, sourceID int64) bool {
+	if _, ok, err := m.lookup(kind, sourceID); err != nil || !ok {
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.report.Skipped[kind]++
+	return true
+}
+
+// mapped returns the target ID of a resource, or 0 if it has not been migrated
+func (m *Migrator) mapped(kind Kind, sourceID int64) (int64, error) {
+	if sourceID == 0 {
+		return 0, nil
+	}
+	id, _, err := m.cfg.Store.Get(kind, sourceID)
+	return id, err
+}
+
+func (m *Migrator) record(kind Kind, sourceID, targetID int64, created bool) error {
+	if err := m.cfg.Store.Put(kind, sourceID, targetID); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if created {
+		m.report.Created[kind]++
+	} else {
+		m.report.Matched[kind]++
+	}
+	return nil
+}
+
+func (m *Migrator) fail(kind Kind, sourceID int64, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.report.Failures = append(m.report.Failures, Failure{Kind: kind, SourceID: sourceID, Err: err})
+}
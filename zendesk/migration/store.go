@@ -0,0 +1,134 @@
+package migration
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// Kind is a kind of migrated resource
+type Kind string
+
+const (
+	// KindGroup is a group
+	KindGroup Kind = "group"
+	// KindOrganization is an organization
+	KindOrganization Kind = "organization"
+	// KindUser is a user
+	KindUser Kind = "user"
+	// KindTicket is a ticket
+	KindTicket Kind = "ticket"
+)
+
+// Store keeps the mapping from the IDs in the source account to the IDs in the target account.
+// A migration run with the same store skips the resources migrated by earlier runs.
+type Store interface {
+	// Get returns the target ID of the source resource, and false if it has not been migrated yet
+	Get(kind Kind, sourceID int64) (int64, bool, error)
+
+	// Put records that the source resource was migrated to the target ID
+	Put(kind Kind, sourceID, targetID int64) error
+}
+
+type storeKey struct {
+	kind Kind
+	id   int64
+}
+
+// MemoryStore is a Store which keeps the mapping in memory
+type MemoryStore struct {
+	mu  sync.RWMutex
+	ids map[storeKey]int64
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{ids: map[storeKey]int64{}}
+}
+
+// Get implements Store
+func (s *MemoryStore) Get(kind Kind, sourceID int64) (int64, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.ids[storeKey{kind, sourceID}]
+	return id, ok, nil
+}
+
+// Put implements Store
+func (s *MemoryStore) Put(kind Kind, sourceID, targetID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ids[storeKey{kind, sourceID}] = targetID
+	return nil
+}
+
+// fileStoreEntry is a line of the FileStore file
+type fileStoreEntry struct {
+	Kind     Kind  `json:"kind"`
+	SourceID int64 `json:"source_id"`
+	TargetID int64 `json:"target_id"`
+}
+
+// FileStore is a Store which appends the mapping to a JSON lines file,
+// so an interrupted migration can be resumed by opening the same file.
+type FileStore struct {
+	mem  *MemoryStore
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenFileStore opens the file at path, creating it if needed, and loads the mapping in it
+func OpenFileStore(path string) (*FileStore, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	mem := NewMemoryStore()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e fileStoreEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			f.Close()
+			return nil, err
+		}
+		mem.ids[storeKey{e.Kind, e.SourceID}] = e.TargetID
+	}
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &FileStore{mem: mem, file: f}, nil
+}
+
+// Get implements Store
+func (s *FileStore) Get(kind Kind, sourceID int64) (int64, bool, error) {
+	return s.mem.Get(kind, sourceID)
+}
+
+// Put implements Store
+func (s *FileStore) Put(kind Kind, sourceID, targetID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := json.Marshal(fileStoreEntry{Kind: kind, SourceID: sourceID, TargetID: targetID})
+	if err != nil {
+		return err
+	}
+	if _, err := s.file.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	return s.mem.Put(kind, sourceID, targetID)
+}
+
+// Close closes the file
+func (s *FileStore) Close() error {
+	return s.file.Close()
+}
@@ -131,6 +131,21 @@ func (mr *ClientMockRecorder) CreateBrand(ctx, brand any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBrand", reflect.TypeOf((*Client)(nil).CreateBrand), ctx, brand)
 }
 
+// CreateContentTag mocks base method.
+func (m *Client) CreateContentTag(ctx context.Context, tag zendesk.ContentTag) (zendesk.ContentTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContentTag", ctx, tag)
+	ret0, _ := ret[0].(zendesk.ContentTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateContentTag indicates an expected call of CreateContentTag.
+func (mr *ClientMockRecorder) CreateContentTag(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContentTag", reflect.TypeOf((*Client)(nil).CreateContentTag), ctx, tag)
+}
+
 // CreateCustomObjectRecord mocks base method.
 func (m *Client) CreateCustomObjectRecord(ctx context.Context, record zendesk.CustomObjectRecord, customObjectKey string) (zendesk.CustomObjectRecord, error) {
 	m.ctrl.T.Helper()
@@ -176,6 +191,36 @@ func (mr *ClientMockRecorder) CreateGroup(ctx, group any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*Client)(nil).CreateGroup), ctx, group)
 }
 
+// CreateGroupMembership mocks base method.
+func (m *Client) CreateGroupMembership(ctx context.Context, membership zendesk.GroupMembership) (zendesk.GroupMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroupMembership", ctx, membership)
+	ret0, _ := ret[0].(zendesk.GroupMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroupMembership indicates an expected call of CreateGroupMembership.
+func (mr *ClientMockRecorder) CreateGroupMembership(ctx, membership any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroupMembership", reflect.TypeOf((*Client)(nil).CreateGroupMembership), ctx, membership)
+}
+
+// CreateHoliday mocks base method.
+func (m *Client) CreateHoliday(ctx context.Context, scheduleID int64, holiday zendesk.Holiday) (zendesk.Holiday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHoliday", ctx, scheduleID, holiday)
+	ret0, _ := ret[0].(zendesk.Holiday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHoliday indicates an expected call of CreateHoliday.
+func (mr *ClientMockRecorder) CreateHoliday(ctx, scheduleID, holiday any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHoliday", reflect.TypeOf((*Client)(nil).CreateHoliday), ctx, scheduleID, holiday)
+}
+
 // CreateMacro mocks base method.
 func (m *Client) CreateMacro(ctx context.Context, macro zendesk.Macro) (zendesk.Macro, error) {
 	m.ctrl.T.Helper()
@@ -191,6 +236,81 @@ func (mr *ClientMockRecorder) CreateMacro(ctx, macro any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMacro", reflect.TypeOf((*Client)(nil).CreateMacro), ctx, macro)
 }
 
+// CreateManyOrganizations mocks base method.
+func (m *Client) CreateManyOrganizations(ctx context.Context, orgs []zendesk.Organization) (zendesk.JobStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateManyOrganizations", ctx, orgs)
+	ret0, _ := ret[0].(zendesk.JobStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateManyOrganizations indicates an expected call of CreateManyOrganizations.
+func (mr *ClientMockRecorder) CreateManyOrganizations(ctx, orgs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateManyOrganizations", reflect.TypeOf((*Client)(nil).CreateManyOrganizations), ctx, orgs)
+}
+
+// CreateManyTickets mocks base method.
+func (m *Client) CreateManyTickets(ctx context.Context, tickets []zendesk.Ticket) (zendesk.JobStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateManyTickets", ctx, tickets)
+	ret0, _ := ret[0].(zendesk.JobStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateManyTickets indicates an expected call of CreateManyTickets.
+func (mr *ClientMockRecorder) CreateManyTickets(ctx, tickets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateManyTickets", reflect.TypeOf((*Client)(nil).CreateManyTickets), ctx, tickets)
+}
+
+// CreateManyUsers mocks base method.
+func (m *Client) CreateManyUsers(ctx context.Context, users []zendesk.User) (zendesk.JobStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateManyUsers", ctx, users)
+	ret0, _ := ret[0].(zendesk.JobStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateManyUsers indicates an expected call of CreateManyUsers.
+func (mr *ClientMockRecorder) CreateManyUsers(ctx, users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateManyUsers", reflect.TypeOf((*Client)(nil).CreateManyUsers), ctx, users)
+}
+
+// CreateObjectTrigger mocks base method.
+func (m *Client) CreateObjectTrigger(ctx context.Context, customObjectKey string, trigger zendesk.ObjectTrigger) (zendesk.ObjectTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateObjectTrigger", ctx, customObjectKey, trigger)
+	ret0, _ := ret[0].(zendesk.ObjectTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateObjectTrigger indicates an expected call of CreateObjectTrigger.
+func (mr *ClientMockRecorder) CreateObjectTrigger(ctx, customObjectKey, trigger any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateObjectTrigger", reflect.TypeOf((*Client)(nil).CreateObjectTrigger), ctx, customObjectKey, trigger)
+}
+
+// CreateOrUpdateManyUsers mocks base method.
+func (m *Client) CreateOrUpdateManyUsers(ctx context.Context, users []zendesk.User) (zendesk.JobStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdateManyUsers", ctx, users)
+	ret0, _ := ret[0].(zendesk.JobStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdateManyUsers indicates an expected call of CreateOrUpdateManyUsers.
+func (mr *ClientMockRecorder) CreateOrUpdateManyUsers(ctx, users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateManyUsers", reflect.TypeOf((*Client)(nil).CreateOrUpdateManyUsers), ctx, users)
+}
+
 // CreateOrUpdateUser mocks base method.
 func (m *Client) CreateOrUpdateUser(ctx context.Context, user zendesk.User) (zendesk.User, error) {
 	m.ctrl.T.Helper()
@@ -251,6 +371,21 @@ func (mr *ClientMockRecorder) CreateOrganizationMembership(arg0, arg1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganizationMembership", reflect.TypeOf((*Client)(nil).CreateOrganizationMembership), arg0, arg1)
 }
 
+// CreatePermissionGroup mocks base method.
+func (m *Client) CreatePermissionGroup(ctx context.Context, group zendesk.PermissionGroup) (zendesk.PermissionGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePermissionGroup", ctx, group)
+	ret0, _ := ret[0].(zendesk.PermissionGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePermissionGroup indicates an expected call of CreatePermissionGroup.
+func (mr *ClientMockRecorder) CreatePermissionGroup(ctx, group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePermissionGroup", reflect.TypeOf((*Client)(nil).CreatePermissionGroup), ctx, group)
+}
+
 // CreateSLAPolicy mocks base method.
 func (m *Client) CreateSLAPolicy(ctx context.Context, slaPolicy zendesk.SLAPolicy) (zendesk.SLAPolicy, error) {
 	m.ctrl.T.Helper()
@@ -266,6 +401,21 @@ func (mr *ClientMockRecorder) CreateSLAPolicy(ctx, slaPolicy any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSLAPolicy", reflect.TypeOf((*Client)(nil).CreateSLAPolicy), ctx, slaPolicy)
 }
 
+// CreateSchedule mocks base method.
+func (m *Client) CreateSchedule(ctx context.Context, schedule zendesk.Schedule) (zendesk.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedule", ctx, schedule)
+	ret0, _ := ret[0].(zendesk.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *ClientMockRecorder) CreateSchedule(ctx, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*Client)(nil).CreateSchedule), ctx, schedule)
+}
+
 // CreateTarget mocks base method.
 func (m *Client) CreateTarget(ctx context.Context, ticketField zendesk.Target) (zendesk.Target, error) {
 	m.ctrl.T.Helper()
@@ -341,6 +491,21 @@ func (mr *ClientMockRecorder) CreateTicketForm(ctx, ticketForm any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTicketForm", reflect.TypeOf((*Client)(nil).CreateTicketForm), ctx, ticketForm)
 }
 
+// CreateTicketFromTweet mocks base method.
+func (m *Client) CreateTicketFromTweet(ctx context.Context, tweetID, monitoredTwitterHandleID int64) (zendesk.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTicketFromTweet", ctx, tweetID, monitoredTwitterHandleID)
+	ret0, _ := ret[0].(zendesk.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTicketFromTweet indicates an expected call of CreateTicketFromTweet.
+func (mr *ClientMockRecorder) CreateTicketFromTweet(ctx, tweetID, monitoredTwitterHandleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTicketFromTweet", reflect.TypeOf((*Client)(nil).CreateTicketFromTweet), ctx, tweetID, monitoredTwitterHandleID)
+}
+
 // CreateTrigger mocks base method.
 func (m *Client) CreateTrigger(ctx context.Context, trigger zendesk.Trigger) (zendesk.Trigger, error) {
 	m.ctrl.T.Helper()
@@ -386,6 +551,21 @@ func (mr *ClientMockRecorder) CreateUserField(ctx, userField any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserField", reflect.TypeOf((*Client)(nil).CreateUserField), ctx, userField)
 }
 
+// CreateUserSegment mocks base method.
+func (m *Client) CreateUserSegment(ctx context.Context, segment zendesk.UserSegment) (zendesk.UserSegment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserSegment", ctx, segment)
+	ret0, _ := ret[0].(zendesk.UserSegment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserSegment indicates an expected call of CreateUserSegment.
+func (mr *ClientMockRecorder) CreateUserSegment(ctx, segment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserSegment", reflect.TypeOf((*Client)(nil).CreateUserSegment), ctx, segment)
+}
+
 // CreateWebhook mocks base method.
 func (m *Client) CreateWebhook(ctx context.Context, hook *zendesk.Webhook) (*zendesk.Webhook, error) {
 	m.ctrl.T.Helper()
@@ -443,6 +623,20 @@ func (mr *ClientMockRecorder) DeleteBrand(ctx, brandID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBrand", reflect.TypeOf((*Client)(nil).DeleteBrand), ctx, brandID)
 }
 
+// DeleteContentTag mocks base method.
+func (m *Client) DeleteContentTag(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteContentTag", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteContentTag indicates an expected call of DeleteContentTag.
+func (mr *ClientMockRecorder) DeleteContentTag(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContentTag", reflect.TypeOf((*Client)(nil).DeleteContentTag), ctx, id)
+}
+
 // DeleteDynamicContentItem mocks base method.
 func (m *Client) DeleteDynamicContentItem(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -471,6 +665,34 @@ func (mr *ClientMockRecorder) DeleteGroup(ctx, groupID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*Client)(nil).DeleteGroup), ctx, groupID)
 }
 
+// DeleteGroupMembership mocks base method.
+func (m *Client) DeleteGroupMembership(ctx context.Context, membershipID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroupMembership", ctx, membershipID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroupMembership indicates an expected call of DeleteGroupMembership.
+func (mr *ClientMockRecorder) DeleteGroupMembership(ctx, membershipID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroupMembership", reflect.TypeOf((*Client)(nil).DeleteGroupMembership), ctx, membershipID)
+}
+
+// DeleteHoliday mocks base method.
+func (m *Client) DeleteHoliday(ctx context.Context, scheduleID, holidayID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHoliday", ctx, scheduleID, holidayID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHoliday indicates an expected call of DeleteHoliday.
+func (mr *ClientMockRecorder) DeleteHoliday(ctx, scheduleID, holidayID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHoliday", reflect.TypeOf((*Client)(nil).DeleteHoliday), ctx, scheduleID, holidayID)
+}
+
 // DeleteMacro mocks base method.
 func (m *Client) DeleteMacro(ctx context.Context, macroID int64) error {
 	m.ctrl.T.Helper()
@@ -485,6 +707,65 @@ func (mr *ClientMockRecorder) DeleteMacro(ctx, macroID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMacro", reflect.TypeOf((*Client)(nil).DeleteMacro), ctx, macroID)
 }
 
+// DeleteManyOrganizations mocks base method.
+func (m *Client) DeleteManyOrganizations(ctx context.Context, orgIDs []int64) (zendesk.JobStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteManyOrganizations", ctx, orgIDs)
+	ret0, _ := ret[0].(zendesk.JobStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteManyOrganizations indicates an expected call of DeleteManyOrganizations.
+func (mr *ClientMockRecorder) DeleteManyOrganizations(ctx, orgIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteManyOrganizations", reflect.TypeOf((*Client)(nil).DeleteManyOrganizations), ctx, orgIDs)
+}
+
+// DeleteManyTickets mocks base method.
+func (m *Client) DeleteManyTickets(ctx context.Context, ticketIDs []int64) (zendesk.JobStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteManyTickets", ctx, ticketIDs)
+	ret0, _ := ret[0].(zendesk.JobStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteManyTickets indicates an expected call of DeleteManyTickets.
+func (mr *ClientMockRecorder) DeleteManyTickets(ctx, ticketIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteManyTickets", reflect.TypeOf((*Client)(nil).DeleteManyTickets), ctx, ticketIDs)
+}
+
+// DeleteManyUsers mocks base method.
+func (m *Client) DeleteManyUsers(ctx context.Context, userIDs []int64) (zendesk.JobStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteManyUsers", ctx, userIDs)
+	ret0, _ := ret[0].(zendesk.JobStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteManyUsers indicates an expected call of DeleteManyUsers.
+func (mr *ClientMockRecorder) DeleteManyUsers(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteManyUsers", reflect.TypeOf((*Client)(nil).DeleteManyUsers), ctx, userIDs)
+}
+
+// DeleteObjectTrigger mocks base method.
+func (m *Client) DeleteObjectTrigger(ctx context.Context, customObjectKey string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteObjectTrigger", ctx, customObjectKey, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteObjectTrigger indicates an expected call of DeleteObjectTrigger.
+func (mr *ClientMockRecorder) DeleteObjectTrigger(ctx, customObjectKey, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteObjectTrigger", reflect.TypeOf((*Client)(nil).DeleteObjectTrigger), ctx, customObjectKey, id)
+}
+
 // DeleteOrganization mocks base method.
 func (m *Client) DeleteOrganization(ctx context.Context, orgID int64) error {
 	m.ctrl.T.Helper()
@@ -499,6 +780,34 @@ func (mr *ClientMockRecorder) DeleteOrganization(ctx, orgID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*Client)(nil).DeleteOrganization), ctx, orgID)
 }
 
+// DeleteOrganizationMembership mocks base method.
+func (m *Client) DeleteOrganizationMembership(ctx context.Context, membershipID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganizationMembership", ctx, membershipID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganizationMembership indicates an expected call of DeleteOrganizationMembership.
+func (mr *ClientMockRecorder) DeleteOrganizationMembership(ctx, membershipID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganizationMembership", reflect.TypeOf((*Client)(nil).DeleteOrganizationMembership), ctx, membershipID)
+}
+
+// DeletePermissionGroup mocks base method.
+func (m *Client) DeletePermissionGroup(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePermissionGroup", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePermissionGroup indicates an expected call of DeletePermissionGroup.
+func (mr *ClientMockRecorder) DeletePermissionGroup(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePermissionGroup", reflect.TypeOf((*Client)(nil).DeletePermissionGroup), ctx, id)
+}
+
 // DeleteSLAPolicy mocks base method.
 func (m *Client) DeleteSLAPolicy(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -513,6 +822,20 @@ func (mr *ClientMockRecorder) DeleteSLAPolicy(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSLAPolicy", reflect.TypeOf((*Client)(nil).DeleteSLAPolicy), ctx, id)
 }
 
+// DeleteSchedule mocks base method.
+func (m *Client) DeleteSchedule(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *ClientMockRecorder) DeleteSchedule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*Client)(nil).DeleteSchedule), ctx, id)
+}
+
 // DeleteTarget mocks base method.
 func (m *Client) DeleteTarget(ctx context.Context, ticketID int64) error {
 	m.ctrl.T.Helper()
@@ -597,6 +920,35 @@ func (mr *ClientMockRecorder) DeleteUpload(ctx, token any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUpload", reflect.TypeOf((*Client)(nil).DeleteUpload), ctx, token)
 }
 
+// DeleteUser mocks base method.
+func (m *Client) DeleteUser(ctx context.Context, userID int64) (zendesk.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, userID)
+	ret0, _ := ret[0].(zendesk.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *ClientMockRecorder) DeleteUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*Client)(nil).DeleteUser), ctx, userID)
+}
+
+// DeleteUserSegment mocks base method.
+func (m *Client) DeleteUserSegment(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSegment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSegment indicates an expected call of DeleteUserSegment.
+func (mr *ClientMockRecorder) DeleteUserSegment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSegment", reflect.TypeOf((*Client)(nil).DeleteUserSegment), ctx, id)
+}
+
 // DeleteWebhook mocks base method.
 func (m *Client) DeleteWebhook(ctx context.Context, webhookID string) error {
 	m.ctrl.T.Helper()
@@ -642,6 +994,22 @@ func (mr *ClientMockRecorder) GetAllTicketAudits(ctx, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTicketAudits", reflect.TypeOf((*Client)(nil).GetAllTicketAudits), ctx, opts)
 }
 
+// GetApplicableUserSegments mocks base method.
+func (m *Client) GetApplicableUserSegments(ctx context.Context, opts *zendesk.PageOptions) ([]zendesk.UserSegment, zendesk.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicableUserSegments", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.UserSegment)
+	ret1, _ := ret[1].(zendesk.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetApplicableUserSegments indicates an expected call of GetApplicableUserSegments.
+func (mr *ClientMockRecorder) GetApplicableUserSegments(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicableUserSegments", reflect.TypeOf((*Client)(nil).GetApplicableUserSegments), ctx, opts)
+}
+
 // GetAttachment mocks base method.
 func (m *Client) GetAttachment(ctx context.Context, id int64) (zendesk.Attachment, error) {
 	m.ctrl.T.Helper()
@@ -749,6 +1117,37 @@ func (mr *ClientMockRecorder) GetBrand(ctx, brandID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrand", reflect.TypeOf((*Client)(nil).GetBrand), ctx, brandID)
 }
 
+// GetContentTag mocks base method.
+func (m *Client) GetContentTag(ctx context.Context, id string) (zendesk.ContentTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContentTag", ctx, id)
+	ret0, _ := ret[0].(zendesk.ContentTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContentTag indicates an expected call of GetContentTag.
+func (mr *ClientMockRecorder) GetContentTag(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContentTag", reflect.TypeOf((*Client)(nil).GetContentTag), ctx, id)
+}
+
+// GetContentTags mocks base method.
+func (m *Client) GetContentTags(ctx context.Context, opts *zendesk.ContentTagListOptions) ([]zendesk.ContentTag, zendesk.CursorPaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContentTags", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.ContentTag)
+	ret1, _ := ret[1].(zendesk.CursorPaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetContentTags indicates an expected call of GetContentTags.
+func (mr *ClientMockRecorder) GetContentTags(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContentTags", reflect.TypeOf((*Client)(nil).GetContentTags), ctx, opts)
+}
+
 // GetCountTicketsInViews mocks base method.
 func (m *Client) GetCountTicketsInViews(ctx context.Context, ids []string) ([]zendesk.ViewCount, error) {
 	m.ctrl.T.Helper()
@@ -779,6 +1178,21 @@ func (mr *ClientMockRecorder) GetCustomRoles(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomRoles", reflect.TypeOf((*Client)(nil).GetCustomRoles), ctx)
 }
 
+// GetCustomStatuses mocks base method.
+func (m *Client) GetCustomStatuses(ctx context.Context, opts *zendesk.CustomStatusListOptions) ([]zendesk.CustomStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomStatuses", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.CustomStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomStatuses indicates an expected call of GetCustomStatuses.
+func (mr *ClientMockRecorder) GetCustomStatuses(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomStatuses", reflect.TypeOf((*Client)(nil).GetCustomStatuses), ctx, opts)
+}
+
 // GetDynamicContentItem mocks base method.
 func (m *Client) GetDynamicContentItem(ctx context.Context, id int64) (zendesk.DynamicContentItem, error) {
 	m.ctrl.T.Helper()
@@ -856,6 +1270,36 @@ func (mr *ClientMockRecorder) GetDynamicContentItemsOBP(ctx, opts any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDynamicContentItemsOBP", reflect.TypeOf((*Client)(nil).GetDynamicContentItemsOBP), ctx, opts)
 }
 
+// GetFacebookPage mocks base method.
+func (m *Client) GetFacebookPage(ctx context.Context, id int64) (zendesk.FacebookPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFacebookPage", ctx, id)
+	ret0, _ := ret[0].(zendesk.FacebookPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFacebookPage indicates an expected call of GetFacebookPage.
+func (mr *ClientMockRecorder) GetFacebookPage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFacebookPage", reflect.TypeOf((*Client)(nil).GetFacebookPage), ctx, id)
+}
+
+// GetFacebookPages mocks base method.
+func (m *Client) GetFacebookPages(ctx context.Context) ([]zendesk.FacebookPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFacebookPages", ctx)
+	ret0, _ := ret[0].([]zendesk.FacebookPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFacebookPages indicates an expected call of GetFacebookPages.
+func (mr *ClientMockRecorder) GetFacebookPages(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFacebookPages", reflect.TypeOf((*Client)(nil).GetFacebookPages), ctx)
+}
+
 // GetGroup mocks base method.
 func (m *Client) GetGroup(ctx context.Context, groupID int64) (zendesk.Group, error) {
 	m.ctrl.T.Helper()
@@ -995,31 +1439,140 @@ func (mr *ClientMockRecorder) GetGroupsOBP(ctx, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupsOBP", reflect.TypeOf((*Client)(nil).GetGroupsOBP), ctx, opts)
 }
 
-// GetLocales mocks base method.
-func (m *Client) GetLocales(ctx context.Context) ([]zendesk.Locale, error) {
+// GetHoliday mocks base method.
+func (m *Client) GetHoliday(ctx context.Context, scheduleID, holidayID int64) (zendesk.Holiday, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLocales", ctx)
-	ret0, _ := ret[0].([]zendesk.Locale)
+	ret := m.ctrl.Call(m, "GetHoliday", ctx, scheduleID, holidayID)
+	ret0, _ := ret[0].(zendesk.Holiday)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLocales indicates an expected call of GetLocales.
-func (mr *ClientMockRecorder) GetLocales(ctx any) *gomock.Call {
+// GetHoliday indicates an expected call of GetHoliday.
+func (mr *ClientMockRecorder) GetHoliday(ctx, scheduleID, holidayID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocales", reflect.TypeOf((*Client)(nil).GetLocales), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoliday", reflect.TypeOf((*Client)(nil).GetHoliday), ctx, scheduleID, holidayID)
 }
 
-// GetMacro mocks base method.
-func (m *Client) GetMacro(ctx context.Context, macroID int64) (zendesk.Macro, error) {
+// GetHolidays mocks base method.
+func (m *Client) GetHolidays(ctx context.Context, scheduleID int64) ([]zendesk.Holiday, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMacro", ctx, macroID)
-	ret0, _ := ret[0].(zendesk.Macro)
+	ret := m.ctrl.Call(m, "GetHolidays", ctx, scheduleID)
+	ret0, _ := ret[0].([]zendesk.Holiday)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMacro indicates an expected call of GetMacro.
+// GetHolidays indicates an expected call of GetHolidays.
+func (mr *ClientMockRecorder) GetHolidays(ctx, scheduleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHolidays", reflect.TypeOf((*Client)(nil).GetHolidays), ctx, scheduleID)
+}
+
+// GetIncrementalTickets mocks base method.
+func (m *Client) GetIncrementalTickets(ctx context.Context, opts *zendesk.CursorOption) ([]zendesk.Ticket, zendesk.IncrementalExportMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncrementalTickets", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.Ticket)
+	ret1, _ := ret[1].(zendesk.IncrementalExportMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetIncrementalTickets indicates an expected call of GetIncrementalTickets.
+func (mr *ClientMockRecorder) GetIncrementalTickets(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncrementalTickets", reflect.TypeOf((*Client)(nil).GetIncrementalTickets), ctx, opts)
+}
+
+// GetJobStatus mocks base method.
+func (m *Client) GetJobStatus(ctx context.Context, id string) (zendesk.JobStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobStatus", ctx, id)
+	ret0, _ := ret[0].(zendesk.JobStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobStatus indicates an expected call of GetJobStatus.
+func (mr *ClientMockRecorder) GetJobStatus(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobStatus", reflect.TypeOf((*Client)(nil).GetJobStatus), ctx, id)
+}
+
+// GetJoinedGroupMemberships mocks base method.
+func (m *Client) GetJoinedGroupMemberships(ctx context.Context, opts *zendesk.JoinedGroupMembershipListOptions) ([]zendesk.JoinedGroupMembership, zendesk.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJoinedGroupMemberships", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.JoinedGroupMembership)
+	ret1, _ := ret[1].(zendesk.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetJoinedGroupMemberships indicates an expected call of GetJoinedGroupMemberships.
+func (mr *ClientMockRecorder) GetJoinedGroupMemberships(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJoinedGroupMemberships", reflect.TypeOf((*Client)(nil).GetJoinedGroupMemberships), ctx, opts)
+}
+
+// GetJoinedOrganizationMemberships mocks base method.
+func (m *Client) GetJoinedOrganizationMemberships(ctx context.Context, opts *zendesk.JoinedOrganizationMembershipListOptions) ([]zendesk.JoinedOrganizationMembership, zendesk.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJoinedOrganizationMemberships", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.JoinedOrganizationMembership)
+	ret1, _ := ret[1].(zendesk.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetJoinedOrganizationMemberships indicates an expected call of GetJoinedOrganizationMemberships.
+func (mr *ClientMockRecorder) GetJoinedOrganizationMemberships(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJoinedOrganizationMemberships", reflect.TypeOf((*Client)(nil).GetJoinedOrganizationMemberships), ctx, opts)
+}
+
+// GetLocales mocks base method.
+func (m *Client) GetLocales(ctx context.Context) ([]zendesk.Locale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocales", ctx)
+	ret0, _ := ret[0].([]zendesk.Locale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocales indicates an expected call of GetLocales.
+func (mr *ClientMockRecorder) GetLocales(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocales", reflect.TypeOf((*Client)(nil).GetLocales), ctx)
+}
+
+// GetLocalizedTicketFields mocks base method.
+func (m *Client) GetLocalizedTicketFields(ctx context.Context, locale string) ([]zendesk.TicketField, zendesk.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocalizedTicketFields", ctx, locale)
+	ret0, _ := ret[0].([]zendesk.TicketField)
+	ret1, _ := ret[1].(zendesk.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLocalizedTicketFields indicates an expected call of GetLocalizedTicketFields.
+func (mr *ClientMockRecorder) GetLocalizedTicketFields(ctx, locale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocalizedTicketFields", reflect.TypeOf((*Client)(nil).GetLocalizedTicketFields), ctx, locale)
+}
+
+// GetMacro mocks base method.
+func (m *Client) GetMacro(ctx context.Context, macroID int64) (zendesk.Macro, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMacro", ctx, macroID)
+	ret0, _ := ret[0].(zendesk.Macro)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMacro indicates an expected call of GetMacro.
 func (mr *ClientMockRecorder) GetMacro(ctx, macroID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMacro", reflect.TypeOf((*Client)(nil).GetMacro), ctx, macroID)
@@ -1103,6 +1656,36 @@ func (mr *ClientMockRecorder) GetManyUsers(ctx, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyUsers", reflect.TypeOf((*Client)(nil).GetManyUsers), ctx, opts)
 }
 
+// GetMonitoredTwitterHandle mocks base method.
+func (m *Client) GetMonitoredTwitterHandle(ctx context.Context, id int64) (zendesk.MonitoredTwitterHandle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonitoredTwitterHandle", ctx, id)
+	ret0, _ := ret[0].(zendesk.MonitoredTwitterHandle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonitoredTwitterHandle indicates an expected call of GetMonitoredTwitterHandle.
+func (mr *ClientMockRecorder) GetMonitoredTwitterHandle(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonitoredTwitterHandle", reflect.TypeOf((*Client)(nil).GetMonitoredTwitterHandle), ctx, id)
+}
+
+// GetMonitoredTwitterHandles mocks base method.
+func (m *Client) GetMonitoredTwitterHandles(ctx context.Context) ([]zendesk.MonitoredTwitterHandle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonitoredTwitterHandles", ctx)
+	ret0, _ := ret[0].([]zendesk.MonitoredTwitterHandle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonitoredTwitterHandles indicates an expected call of GetMonitoredTwitterHandles.
+func (mr *ClientMockRecorder) GetMonitoredTwitterHandles(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonitoredTwitterHandles", reflect.TypeOf((*Client)(nil).GetMonitoredTwitterHandles), ctx)
+}
+
 // GetMultipleTickets mocks base method.
 func (m *Client) GetMultipleTickets(ctx context.Context, ticketIDs []int64) ([]zendesk.Ticket, error) {
 	m.ctrl.T.Helper()
@@ -1118,6 +1701,52 @@ func (mr *ClientMockRecorder) GetMultipleTickets(ctx, ticketIDs any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultipleTickets", reflect.TypeOf((*Client)(nil).GetMultipleTickets), ctx, ticketIDs)
 }
 
+// GetObjectTrigger mocks base method.
+func (m *Client) GetObjectTrigger(ctx context.Context, customObjectKey string, id int64) (zendesk.ObjectTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectTrigger", ctx, customObjectKey, id)
+	ret0, _ := ret[0].(zendesk.ObjectTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectTrigger indicates an expected call of GetObjectTrigger.
+func (mr *ClientMockRecorder) GetObjectTrigger(ctx, customObjectKey, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectTrigger", reflect.TypeOf((*Client)(nil).GetObjectTrigger), ctx, customObjectKey, id)
+}
+
+// GetObjectTriggerDefinitions mocks base method.
+func (m *Client) GetObjectTriggerDefinitions(ctx context.Context, customObjectKey string) (zendesk.TriggerDefinitions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectTriggerDefinitions", ctx, customObjectKey)
+	ret0, _ := ret[0].(zendesk.TriggerDefinitions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectTriggerDefinitions indicates an expected call of GetObjectTriggerDefinitions.
+func (mr *ClientMockRecorder) GetObjectTriggerDefinitions(ctx, customObjectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectTriggerDefinitions", reflect.TypeOf((*Client)(nil).GetObjectTriggerDefinitions), ctx, customObjectKey)
+}
+
+// GetObjectTriggers mocks base method.
+func (m *Client) GetObjectTriggers(ctx context.Context, customObjectKey string, opts *zendesk.ObjectTriggerListOptions) ([]zendesk.ObjectTrigger, zendesk.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectTriggers", ctx, customObjectKey, opts)
+	ret0, _ := ret[0].([]zendesk.ObjectTrigger)
+	ret1, _ := ret[1].(zendesk.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetObjectTriggers indicates an expected call of GetObjectTriggers.
+func (mr *ClientMockRecorder) GetObjectTriggers(ctx, customObjectKey, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectTriggers", reflect.TypeOf((*Client)(nil).GetObjectTriggers), ctx, customObjectKey, opts)
+}
+
 // GetOrganization mocks base method.
 func (m *Client) GetOrganization(ctx context.Context, orgID int64) (zendesk.Organization, error) {
 	m.ctrl.T.Helper()
@@ -1474,6 +2103,83 @@ func (mr *ClientMockRecorder) GetOrganizationsOBP(ctx, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationsOBP", reflect.TypeOf((*Client)(nil).GetOrganizationsOBP), ctx, opts)
 }
 
+// GetPermissionGroup mocks base method.
+func (m *Client) GetPermissionGroup(ctx context.Context, id int64) (zendesk.PermissionGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissionGroup", ctx, id)
+	ret0, _ := ret[0].(zendesk.PermissionGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermissionGroup indicates an expected call of GetPermissionGroup.
+func (mr *ClientMockRecorder) GetPermissionGroup(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissionGroup", reflect.TypeOf((*Client)(nil).GetPermissionGroup), ctx, id)
+}
+
+// GetPermissionGroups mocks base method.
+func (m *Client) GetPermissionGroups(ctx context.Context, opts *zendesk.PageOptions) ([]zendesk.PermissionGroup, zendesk.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissionGroups", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.PermissionGroup)
+	ret1, _ := ret[1].(zendesk.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPermissionGroups indicates an expected call of GetPermissionGroups.
+func (mr *ClientMockRecorder) GetPermissionGroups(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissionGroups", reflect.TypeOf((*Client)(nil).GetPermissionGroups), ctx, opts)
+}
+
+// GetRecentTicketsCBP mocks base method.
+func (m *Client) GetRecentTicketsCBP(ctx context.Context, opts *zendesk.CBPOptions) ([]zendesk.Ticket, zendesk.CursorPaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentTicketsCBP", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.Ticket)
+	ret1, _ := ret[1].(zendesk.CursorPaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRecentTicketsCBP indicates an expected call of GetRecentTicketsCBP.
+func (mr *ClientMockRecorder) GetRecentTicketsCBP(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentTicketsCBP", reflect.TypeOf((*Client)(nil).GetRecentTicketsCBP), ctx, opts)
+}
+
+// GetRecentTicketsIterator mocks base method.
+func (m *Client) GetRecentTicketsIterator(ctx context.Context, opts *zendesk.PaginationOptions) *zendesk.Iterator[zendesk.Ticket] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentTicketsIterator", ctx, opts)
+	ret0, _ := ret[0].(*zendesk.Iterator[zendesk.Ticket])
+	return ret0
+}
+
+// GetRecentTicketsIterator indicates an expected call of GetRecentTicketsIterator.
+func (mr *ClientMockRecorder) GetRecentTicketsIterator(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentTicketsIterator", reflect.TypeOf((*Client)(nil).GetRecentTicketsIterator), ctx, opts)
+}
+
+// GetRecentTicketsOBP mocks base method.
+func (m *Client) GetRecentTicketsOBP(ctx context.Context, opts *zendesk.OBPOptions) ([]zendesk.Ticket, zendesk.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentTicketsOBP", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.Ticket)
+	ret1, _ := ret[1].(zendesk.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRecentTicketsOBP indicates an expected call of GetRecentTicketsOBP.
+func (mr *ClientMockRecorder) GetRecentTicketsOBP(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentTicketsOBP", reflect.TypeOf((*Client)(nil).GetRecentTicketsOBP), ctx, opts)
+}
+
 // GetSLAPolicies mocks base method.
 func (m *Client) GetSLAPolicies(ctx context.Context, opts *zendesk.SLAPolicyListOptions) ([]zendesk.SLAPolicy, zendesk.Page, error) {
 	m.ctrl.T.Helper()
@@ -1551,6 +2257,36 @@ func (mr *ClientMockRecorder) GetSLAPolicy(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSLAPolicy", reflect.TypeOf((*Client)(nil).GetSLAPolicy), ctx, id)
 }
 
+// GetSchedule mocks base method.
+func (m *Client) GetSchedule(ctx context.Context, id int64) (zendesk.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", ctx, id)
+	ret0, _ := ret[0].(zendesk.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *ClientMockRecorder) GetSchedule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*Client)(nil).GetSchedule), ctx, id)
+}
+
+// GetSchedules mocks base method.
+func (m *Client) GetSchedules(ctx context.Context) ([]zendesk.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedules", ctx)
+	ret0, _ := ret[0].([]zendesk.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedules indicates an expected call of GetSchedules.
+func (mr *ClientMockRecorder) GetSchedules(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedules", reflect.TypeOf((*Client)(nil).GetSchedules), ctx)
+}
+
 // GetSearchCBP mocks base method.
 func (m *Client) GetSearchCBP(ctx context.Context, opts *zendesk.CBPOptions) ([]zendesk.SearchResults, zendesk.CursorPaginationMeta, error) {
 	m.ctrl.T.Helper()
@@ -2136,6 +2872,21 @@ func (mr *ClientMockRecorder) GetTriggersOBP(ctx, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTriggersOBP", reflect.TypeOf((*Client)(nil).GetTriggersOBP), ctx, opts)
 }
 
+// GetTwitterStatuses mocks base method.
+func (m *Client) GetTwitterStatuses(ctx context.Context, ticketID int64, commentIDs []int64) ([]zendesk.TwitterStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTwitterStatuses", ctx, ticketID, commentIDs)
+	ret0, _ := ret[0].([]zendesk.TwitterStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTwitterStatuses indicates an expected call of GetTwitterStatuses.
+func (mr *ClientMockRecorder) GetTwitterStatuses(ctx, ticketID, commentIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwitterStatuses", reflect.TypeOf((*Client)(nil).GetTwitterStatuses), ctx, ticketID, commentIDs)
+}
+
 // GetUser mocks base method.
 func (m *Client) GetUser(ctx context.Context, userID int64) (zendesk.User, error) {
 	m.ctrl.T.Helper()
@@ -2151,6 +2902,98 @@ func (mr *ClientMockRecorder) GetUser(ctx, userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*Client)(nil).GetUser), ctx, userID)
 }
 
+// GetUserAssignedTicketsCBP mocks base method.
+func (m *Client) GetUserAssignedTicketsCBP(ctx context.Context, opts *zendesk.CBPOptions) ([]zendesk.Ticket, zendesk.CursorPaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAssignedTicketsCBP", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.Ticket)
+	ret1, _ := ret[1].(zendesk.CursorPaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserAssignedTicketsCBP indicates an expected call of GetUserAssignedTicketsCBP.
+func (mr *ClientMockRecorder) GetUserAssignedTicketsCBP(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAssignedTicketsCBP", reflect.TypeOf((*Client)(nil).GetUserAssignedTicketsCBP), ctx, opts)
+}
+
+// GetUserAssignedTicketsIterator mocks base method.
+func (m *Client) GetUserAssignedTicketsIterator(ctx context.Context, opts *zendesk.PaginationOptions) *zendesk.Iterator[zendesk.Ticket] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAssignedTicketsIterator", ctx, opts)
+	ret0, _ := ret[0].(*zendesk.Iterator[zendesk.Ticket])
+	return ret0
+}
+
+// GetUserAssignedTicketsIterator indicates an expected call of GetUserAssignedTicketsIterator.
+func (mr *ClientMockRecorder) GetUserAssignedTicketsIterator(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAssignedTicketsIterator", reflect.TypeOf((*Client)(nil).GetUserAssignedTicketsIterator), ctx, opts)
+}
+
+// GetUserAssignedTicketsOBP mocks base method.
+func (m *Client) GetUserAssignedTicketsOBP(ctx context.Context, opts *zendesk.OBPOptions) ([]zendesk.Ticket, zendesk.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAssignedTicketsOBP", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.Ticket)
+	ret1, _ := ret[1].(zendesk.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserAssignedTicketsOBP indicates an expected call of GetUserAssignedTicketsOBP.
+func (mr *ClientMockRecorder) GetUserAssignedTicketsOBP(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAssignedTicketsOBP", reflect.TypeOf((*Client)(nil).GetUserAssignedTicketsOBP), ctx, opts)
+}
+
+// GetUserCCDTicketsCBP mocks base method.
+func (m *Client) GetUserCCDTicketsCBP(ctx context.Context, opts *zendesk.CBPOptions) ([]zendesk.Ticket, zendesk.CursorPaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCCDTicketsCBP", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.Ticket)
+	ret1, _ := ret[1].(zendesk.CursorPaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserCCDTicketsCBP indicates an expected call of GetUserCCDTicketsCBP.
+func (mr *ClientMockRecorder) GetUserCCDTicketsCBP(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCCDTicketsCBP", reflect.TypeOf((*Client)(nil).GetUserCCDTicketsCBP), ctx, opts)
+}
+
+// GetUserCCDTicketsIterator mocks base method.
+func (m *Client) GetUserCCDTicketsIterator(ctx context.Context, opts *zendesk.PaginationOptions) *zendesk.Iterator[zendesk.Ticket] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCCDTicketsIterator", ctx, opts)
+	ret0, _ := ret[0].(*zendesk.Iterator[zendesk.Ticket])
+	return ret0
+}
+
+// GetUserCCDTicketsIterator indicates an expected call of GetUserCCDTicketsIterator.
+func (mr *ClientMockRecorder) GetUserCCDTicketsIterator(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCCDTicketsIterator", reflect.TypeOf((*Client)(nil).GetUserCCDTicketsIterator), ctx, opts)
+}
+
+// GetUserCCDTicketsOBP mocks base method.
+func (m *Client) GetUserCCDTicketsOBP(ctx context.Context, opts *zendesk.OBPOptions) ([]zendesk.Ticket, zendesk.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCCDTicketsOBP", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.Ticket)
+	ret1, _ := ret[1].(zendesk.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserCCDTicketsOBP indicates an expected call of GetUserCCDTicketsOBP.
+func (mr *ClientMockRecorder) GetUserCCDTicketsOBP(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCCDTicketsOBP", reflect.TypeOf((*Client)(nil).GetUserCCDTicketsOBP), ctx, opts)
+}
+
 // GetUserFields mocks base method.
 func (m *Client) GetUserFields(ctx context.Context, opts *zendesk.UserFieldListOptions) ([]zendesk.UserField, zendesk.Page, error) {
 	m.ctrl.T.Helper()
@@ -2213,6 +3056,52 @@ func (mr *ClientMockRecorder) GetUserFieldsOBP(ctx, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFieldsOBP", reflect.TypeOf((*Client)(nil).GetUserFieldsOBP), ctx, opts)
 }
 
+// GetUserFollowedTicketsCBP mocks base method.
+func (m *Client) GetUserFollowedTicketsCBP(ctx context.Context, opts *zendesk.CBPOptions) ([]zendesk.Ticket, zendesk.CursorPaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserFollowedTicketsCBP", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.Ticket)
+	ret1, _ := ret[1].(zendesk.CursorPaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserFollowedTicketsCBP indicates an expected call of GetUserFollowedTicketsCBP.
+func (mr *ClientMockRecorder) GetUserFollowedTicketsCBP(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFollowedTicketsCBP", reflect.TypeOf((*Client)(nil).GetUserFollowedTicketsCBP), ctx, opts)
+}
+
+// GetUserFollowedTicketsIterator mocks base method.
+func (m *Client) GetUserFollowedTicketsIterator(ctx context.Context, opts *zendesk.PaginationOptions) *zendesk.Iterator[zendesk.Ticket] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserFollowedTicketsIterator", ctx, opts)
+	ret0, _ := ret[0].(*zendesk.Iterator[zendesk.Ticket])
+	return ret0
+}
+
+// GetUserFollowedTicketsIterator indicates an expected call of GetUserFollowedTicketsIterator.
+func (mr *ClientMockRecorder) GetUserFollowedTicketsIterator(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFollowedTicketsIterator", reflect.TypeOf((*Client)(nil).GetUserFollowedTicketsIterator), ctx, opts)
+}
+
+// GetUserFollowedTicketsOBP mocks base method.
+func (m *Client) GetUserFollowedTicketsOBP(ctx context.Context, opts *zendesk.OBPOptions) ([]zendesk.Ticket, zendesk.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserFollowedTicketsOBP", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.Ticket)
+	ret1, _ := ret[1].(zendesk.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserFollowedTicketsOBP indicates an expected call of GetUserFollowedTicketsOBP.
+func (mr *ClientMockRecorder) GetUserFollowedTicketsOBP(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFollowedTicketsOBP", reflect.TypeOf((*Client)(nil).GetUserFollowedTicketsOBP), ctx, opts)
+}
+
 // GetUserRelated mocks base method.
 func (m *Client) GetUserRelated(ctx context.Context, userID int64) (zendesk.UserRelated, error) {
 	m.ctrl.T.Helper()
@@ -2228,6 +3117,130 @@ func (mr *ClientMockRecorder) GetUserRelated(ctx, userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRelated", reflect.TypeOf((*Client)(nil).GetUserRelated), ctx, userID)
 }
 
+// GetUserRequestedTicketsCBP mocks base method.
+func (m *Client) GetUserRequestedTicketsCBP(ctx context.Context, opts *zendesk.CBPOptions) ([]zendesk.Ticket, zendesk.CursorPaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRequestedTicketsCBP", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.Ticket)
+	ret1, _ := ret[1].(zendesk.CursorPaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserRequestedTicketsCBP indicates an expected call of GetUserRequestedTicketsCBP.
+func (mr *ClientMockRecorder) GetUserRequestedTicketsCBP(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRequestedTicketsCBP", reflect.TypeOf((*Client)(nil).GetUserRequestedTicketsCBP), ctx, opts)
+}
+
+// GetUserRequestedTicketsIterator mocks base method.
+func (m *Client) GetUserRequestedTicketsIterator(ctx context.Context, opts *zendesk.PaginationOptions) *zendesk.Iterator[zendesk.Ticket] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRequestedTicketsIterator", ctx, opts)
+	ret0, _ := ret[0].(*zendesk.Iterator[zendesk.Ticket])
+	return ret0
+}
+
+// GetUserRequestedTicketsIterator indicates an expected call of GetUserRequestedTicketsIterator.
+func (mr *ClientMockRecorder) GetUserRequestedTicketsIterator(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRequestedTicketsIterator", reflect.TypeOf((*Client)(nil).GetUserRequestedTicketsIterator), ctx, opts)
+}
+
+// GetUserRequestedTicketsOBP mocks base method.
+func (m *Client) GetUserRequestedTicketsOBP(ctx context.Context, opts *zendesk.OBPOptions) ([]zendesk.Ticket, zendesk.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRequestedTicketsOBP", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.Ticket)
+	ret1, _ := ret[1].(zendesk.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserRequestedTicketsOBP indicates an expected call of GetUserRequestedTicketsOBP.
+func (mr *ClientMockRecorder) GetUserRequestedTicketsOBP(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRequestedTicketsOBP", reflect.TypeOf((*Client)(nil).GetUserRequestedTicketsOBP), ctx, opts)
+}
+
+// GetUserSegment mocks base method.
+func (m *Client) GetUserSegment(ctx context.Context, id int64) (zendesk.UserSegment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSegment", ctx, id)
+	ret0, _ := ret[0].(zendesk.UserSegment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSegment indicates an expected call of GetUserSegment.
+func (mr *ClientMockRecorder) GetUserSegment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSegment", reflect.TypeOf((*Client)(nil).GetUserSegment), ctx, id)
+}
+
+// GetUserSegmentSections mocks base method.
+func (m *Client) GetUserSegmentSections(ctx context.Context, id int64, opts *zendesk.PageOptions) ([]zendesk.Section, zendesk.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSegmentSections", ctx, id, opts)
+	ret0, _ := ret[0].([]zendesk.Section)
+	ret1, _ := ret[1].(zendesk.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserSegmentSections indicates an expected call of GetUserSegmentSections.
+func (mr *ClientMockRecorder) GetUserSegmentSections(ctx, id, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSegmentSections", reflect.TypeOf((*Client)(nil).GetUserSegmentSections), ctx, id, opts)
+}
+
+// GetUserSegmentTopics mocks base method.
+func (m *Client) GetUserSegmentTopics(ctx context.Context, id int64, opts *zendesk.PageOptions) ([]zendesk.Topic, zendesk.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSegmentTopics", ctx, id, opts)
+	ret0, _ := ret[0].([]zendesk.Topic)
+	ret1, _ := ret[1].(zendesk.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserSegmentTopics indicates an expected call of GetUserSegmentTopics.
+func (mr *ClientMockRecorder) GetUserSegmentTopics(ctx, id, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSegmentTopics", reflect.TypeOf((*Client)(nil).GetUserSegmentTopics), ctx, id, opts)
+}
+
+// GetUserSegments mocks base method.
+func (m *Client) GetUserSegments(ctx context.Context, opts *zendesk.UserSegmentListOptions) ([]zendesk.UserSegment, zendesk.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSegments", ctx, opts)
+	ret0, _ := ret[0].([]zendesk.UserSegment)
+	ret1, _ := ret[1].(zendesk.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserSegments indicates an expected call of GetUserSegments.
+func (mr *ClientMockRecorder) GetUserSegments(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSegments", reflect.TypeOf((*Client)(nil).GetUserSegments), ctx, opts)
+}
+
+// GetUserSegmentsForUser mocks base method.
+func (m *Client) GetUserSegmentsForUser(ctx context.Context, userID int64) ([]zendesk.UserSegment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSegmentsForUser", ctx, userID)
+	ret0, _ := ret[0].([]zendesk.UserSegment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSegmentsForUser indicates an expected call of GetUserSegmentsForUser.
+func (mr *ClientMockRecorder) GetUserSegmentsForUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSegmentsForUser", reflect.TypeOf((*Client)(nil).GetUserSegmentsForUser), ctx, userID)
+}
+
 // GetUserTags mocks base method.
 func (m *Client) GetUserTags(ctx context.Context, userID int64) ([]zendesk.Tag, error) {
 	m.ctrl.T.Helper()
@@ -2412,6 +3425,51 @@ func (mr *ClientMockRecorder) GetWebhookSigningSecret(ctx, webhookID any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookSigningSecret", reflect.TypeOf((*Client)(nil).GetWebhookSigningSecret), ctx, webhookID)
 }
 
+// ImportManyTickets mocks base method.
+func (m *Client) ImportManyTickets(ctx context.Context, tickets []zendesk.TicketImport, opts *zendesk.TicketImportOptions) (zendesk.JobStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportManyTickets", ctx, tickets, opts)
+	ret0, _ := ret[0].(zendesk.JobStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportManyTickets indicates an expected call of ImportManyTickets.
+func (mr *ClientMockRecorder) ImportManyTickets(ctx, tickets, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportManyTickets", reflect.TypeOf((*Client)(nil).ImportManyTickets), ctx, tickets, opts)
+}
+
+// ImportTicket mocks base method.
+func (m *Client) ImportTicket(ctx context.Context, ticket zendesk.TicketImport, opts *zendesk.TicketImportOptions) (zendesk.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTicket", ctx, ticket, opts)
+	ret0, _ := ret[0].(zendesk.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTicket indicates an expected call of ImportTicket.
+func (mr *ClientMockRecorder) ImportTicket(ctx, ticket, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTicket", reflect.TypeOf((*Client)(nil).ImportTicket), ctx, ticket, opts)
+}
+
+// ListCustomObjectFields mocks base method.
+func (m *Client) ListCustomObjectFields(ctx context.Context, customObjectKey string) ([]zendesk.CustomObjectField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCustomObjectFields", ctx, customObjectKey)
+	ret0, _ := ret[0].([]zendesk.CustomObjectField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCustomObjectFields indicates an expected call of ListCustomObjectFields.
+func (mr *ClientMockRecorder) ListCustomObjectFields(ctx, customObjectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomObjectFields", reflect.TypeOf((*Client)(nil).ListCustomObjectFields), ctx, customObjectKey)
+}
+
 // ListCustomObjectRecords mocks base method.
 func (m *Client) ListCustomObjectRecords(ctx context.Context, customObjectKey string, opts *zendesk.CustomObjectListOptions) ([]zendesk.CustomObjectRecord, zendesk.Page, error) {
 	m.ctrl.T.Helper()
@@ -2428,6 +3486,21 @@ func (mr *ClientMockRecorder) ListCustomObjectRecords(ctx, customObjectKey, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomObjectRecords", reflect.TypeOf((*Client)(nil).ListCustomObjectRecords), ctx, customObjectKey, opts)
 }
 
+// ListCustomObjects mocks base method.
+func (m *Client) ListCustomObjects(ctx context.Context) ([]zendesk.CustomObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCustomObjects", ctx)
+	ret0, _ := ret[0].([]zendesk.CustomObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCustomObjects indicates an expected call of ListCustomObjects.
+func (mr *ClientMockRecorder) ListCustomObjects(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomObjects", reflect.TypeOf((*Client)(nil).ListCustomObjects), ctx)
+}
+
 // ListInstallations mocks base method.
 func (m *Client) ListInstallations(ctx context.Context) ([]zendesk.AppInstallation, error) {
 	m.ctrl.T.Helper()
@@ -2488,6 +3561,21 @@ func (mr *ClientMockRecorder) MakeCommentPrivate(ctx, ticketID, ticketCommentID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeCommentPrivate", reflect.TypeOf((*Client)(nil).MakeCommentPrivate), ctx, ticketID, ticketCommentID)
 }
 
+// MergeTickets mocks base method.
+func (m *Client) MergeTickets(ctx context.Context, targetID int64, opts zendesk.TicketMergeOptions) (zendesk.JobStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTickets", ctx, targetID, opts)
+	ret0, _ := ret[0].(zendesk.JobStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeTickets indicates an expected call of MergeTickets.
+func (mr *ClientMockRecorder) MergeTickets(ctx, targetID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTickets", reflect.TypeOf((*Client)(nil).MergeTickets), ctx, targetID, opts)
+}
+
 // Post mocks base method.
 func (m *Client) Post(ctx context.Context, path string, data any) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -2518,6 +3606,21 @@ func (mr *ClientMockRecorder) Put(ctx, path, data any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*Client)(nil).Put), ctx, path, data)
 }
 
+// ReorderObjectTriggers mocks base method.
+func (m *Client) ReorderObjectTriggers(ctx context.Context, customObjectKey string, ids []int64) ([]zendesk.ObjectTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderObjectTriggers", ctx, customObjectKey, ids)
+	ret0, _ := ret[0].([]zendesk.ObjectTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderObjectTriggers indicates an expected call of ReorderObjectTriggers.
+func (mr *ClientMockRecorder) ReorderObjectTriggers(ctx, customObjectKey, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderObjectTriggers", reflect.TypeOf((*Client)(nil).ReorderObjectTriggers), ctx, customObjectKey, ids)
+}
+
 // Search mocks base method.
 func (m *Client) Search(ctx context.Context, opts *zendesk.SearchOptions) (zendesk.SearchResults, zendesk.Page, error) {
 	m.ctrl.T.Helper()
@@ -2565,6 +3668,22 @@ func (mr *ClientMockRecorder) SearchCustomObjectRecords(ctx, customObjectKey, op
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCustomObjectRecords", reflect.TypeOf((*Client)(nil).SearchCustomObjectRecords), ctx, customObjectKey, opts)
 }
 
+// SearchExport mocks base method.
+func (m *Client) SearchExport(ctx context.Context, opts *zendesk.SearchExportOptions) (zendesk.SearchResults, zendesk.CursorPaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchExport", ctx, opts)
+	ret0, _ := ret[0].(zendesk.SearchResults)
+	ret1, _ := ret[1].(zendesk.CursorPaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchExport indicates an expected call of SearchExport.
+func (mr *ClientMockRecorder) SearchExport(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchExport", reflect.TypeOf((*Client)(nil).SearchExport), ctx, opts)
+}
+
 // SearchUsers mocks base method.
 func (m *Client) SearchUsers(ctx context.Context, opts *zendesk.SearchUsersOptions) ([]zendesk.User, zendesk.Page, error) {
 	m.ctrl.T.Helper()
@@ -2596,6 +3715,21 @@ func (mr *ClientMockRecorder) SetDefaultOrganization(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefaultOrganization", reflect.TypeOf((*Client)(nil).SetDefaultOrganization), arg0, arg1)
 }
 
+// SetUserSuspended mocks base method.
+func (m *Client) SetUserSuspended(ctx context.Context, userID int64, suspended bool) (zendesk.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserSuspended", ctx, userID, suspended)
+	ret0, _ := ret[0].(zendesk.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserSuspended indicates an expected call of SetUserSuspended.
+func (mr *ClientMockRecorder) SetUserSuspended(ctx, userID, suspended any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserSuspended", reflect.TypeOf((*Client)(nil).SetUserSuspended), ctx, userID, suspended)
+}
+
 // ShowCustomObjectRecord mocks base method.
 func (m *Client) ShowCustomObjectRecord(ctx context.Context, customObjectKey, customObjectRecordID string) (*zendesk.CustomObjectRecord, error) {
 	m.ctrl.T.Helper()
@@ -2641,6 +3775,21 @@ func (mr *ClientMockRecorder) UpdateBrand(ctx, brandID, brand any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBrand", reflect.TypeOf((*Client)(nil).UpdateBrand), ctx, brandID, brand)
 }
 
+// UpdateContentTag mocks base method.
+func (m *Client) UpdateContentTag(ctx context.Context, id string, tag zendesk.ContentTag) (zendesk.ContentTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContentTag", ctx, id, tag)
+	ret0, _ := ret[0].(zendesk.ContentTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateContentTag indicates an expected call of UpdateContentTag.
+func (mr *ClientMockRecorder) UpdateContentTag(ctx, id, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContentTag", reflect.TypeOf((*Client)(nil).UpdateContentTag), ctx, id, tag)
+}
+
 // UpdateCustomObjectRecord mocks base method.
 func (m *Client) UpdateCustomObjectRecord(ctx context.Context, customObjectKey, customObjectRecordID string, record zendesk.CustomObjectRecord) (*zendesk.CustomObjectRecord, error) {
 	m.ctrl.T.Helper()
@@ -2686,6 +3835,21 @@ func (mr *ClientMockRecorder) UpdateGroup(ctx, groupID, group any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*Client)(nil).UpdateGroup), ctx, groupID, group)
 }
 
+// UpdateHoliday mocks base method.
+func (m *Client) UpdateHoliday(ctx context.Context, scheduleID, holidayID int64, holiday zendesk.Holiday) (zendesk.Holiday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHoliday", ctx, scheduleID, holidayID, holiday)
+	ret0, _ := ret[0].(zendesk.Holiday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHoliday indicates an expected call of UpdateHoliday.
+func (mr *ClientMockRecorder) UpdateHoliday(ctx, scheduleID, holidayID, holiday any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHoliday", reflect.TypeOf((*Client)(nil).UpdateHoliday), ctx, scheduleID, holidayID, holiday)
+}
+
 // UpdateMacro mocks base method.
 func (m *Client) UpdateMacro(ctx context.Context, macroID int64, macro zendesk.Macro) (zendesk.Macro, error) {
 	m.ctrl.T.Helper()
@@ -2701,6 +3865,36 @@ func (mr *ClientMockRecorder) UpdateMacro(ctx, macroID, macro any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMacro", reflect.TypeOf((*Client)(nil).UpdateMacro), ctx, macroID, macro)
 }
 
+// UpdateManyOrganizations mocks base method.
+func (m *Client) UpdateManyOrganizations(ctx context.Context, orgs []zendesk.Organization) (zendesk.JobStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateManyOrganizations", ctx, orgs)
+	ret0, _ := ret[0].(zendesk.JobStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateManyOrganizations indicates an expected call of UpdateManyOrganizations.
+func (mr *ClientMockRecorder) UpdateManyOrganizations(ctx, orgs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateManyOrganizations", reflect.TypeOf((*Client)(nil).UpdateManyOrganizations), ctx, orgs)
+}
+
+// UpdateObjectTrigger mocks base method.
+func (m *Client) UpdateObjectTrigger(ctx context.Context, customObjectKey string, id int64, trigger zendesk.ObjectTrigger) (zendesk.ObjectTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateObjectTrigger", ctx, customObjectKey, id, trigger)
+	ret0, _ := ret[0].(zendesk.ObjectTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateObjectTrigger indicates an expected call of UpdateObjectTrigger.
+func (mr *ClientMockRecorder) UpdateObjectTrigger(ctx, customObjectKey, id, trigger any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateObjectTrigger", reflect.TypeOf((*Client)(nil).UpdateObjectTrigger), ctx, customObjectKey, id, trigger)
+}
+
 // UpdateOrganization mocks base method.
 func (m *Client) UpdateOrganization(ctx context.Context, orgID int64, org zendesk.Organization) (zendesk.Organization, error) {
 	m.ctrl.T.Helper()
@@ -2716,6 +3910,21 @@ func (mr *ClientMockRecorder) UpdateOrganization(ctx, orgID, org any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrganization", reflect.TypeOf((*Client)(nil).UpdateOrganization), ctx, orgID, org)
 }
 
+// UpdatePermissionGroup mocks base method.
+func (m *Client) UpdatePermissionGroup(ctx context.Context, id int64, group zendesk.PermissionGroup) (zendesk.PermissionGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePermissionGroup", ctx, id, group)
+	ret0, _ := ret[0].(zendesk.PermissionGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePermissionGroup indicates an expected call of UpdatePermissionGroup.
+func (mr *ClientMockRecorder) UpdatePermissionGroup(ctx, id, group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePermissionGroup", reflect.TypeOf((*Client)(nil).UpdatePermissionGroup), ctx, id, group)
+}
+
 // UpdateSLAPolicy mocks base method.
 func (m *Client) UpdateSLAPolicy(ctx context.Context, id int64, slaPolicy zendesk.SLAPolicy) (zendesk.SLAPolicy, error) {
 	m.ctrl.T.Helper()
@@ -2731,6 +3940,36 @@ func (mr *ClientMockRecorder) UpdateSLAPolicy(ctx, id, slaPolicy any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSLAPolicy", reflect.TypeOf((*Client)(nil).UpdateSLAPolicy), ctx, id, slaPolicy)
 }
 
+// UpdateSchedule mocks base method.
+func (m *Client) UpdateSchedule(ctx context.Context, id int64, schedule zendesk.Schedule) (zendesk.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSchedule", ctx, id, schedule)
+	ret0, _ := ret[0].(zendesk.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSchedule indicates an expected call of UpdateSchedule.
+func (mr *ClientMockRecorder) UpdateSchedule(ctx, id, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchedule", reflect.TypeOf((*Client)(nil).UpdateSchedule), ctx, id, schedule)
+}
+
+// UpdateScheduleWorkweek mocks base method.
+func (m *Client) UpdateScheduleWorkweek(ctx context.Context, id int64, intervals []zendesk.ScheduleInterval) ([]zendesk.ScheduleInterval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduleWorkweek", ctx, id, intervals)
+	ret0, _ := ret[0].([]zendesk.ScheduleInterval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScheduleWorkweek indicates an expected call of UpdateScheduleWorkweek.
+func (mr *ClientMockRecorder) UpdateScheduleWorkweek(ctx, id, intervals any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduleWorkweek", reflect.TypeOf((*Client)(nil).UpdateScheduleWorkweek), ctx, id, intervals)
+}
+
 // UpdateTarget mocks base method.
 func (m *Client) UpdateTarget(ctx context.Context, ticketID int64, field zendesk.Target) (zendesk.Target, error) {
 	m.ctrl.T.Helper()
@@ -2821,6 +4060,21 @@ func (mr *ClientMockRecorder) UpdateUser(ctx, userID, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*Client)(nil).UpdateUser), ctx, userID, user)
 }
 
+// UpdateUserSegment mocks base method.
+func (m *Client) UpdateUserSegment(ctx context.Context, id int64, segment zendesk.UserSegment) (zendesk.UserSegment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserSegment", ctx, id, segment)
+	ret0, _ := ret[0].(zendesk.UserSegment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserSegment indicates an expected call of UpdateUserSegment.
+func (mr *ClientMockRecorder) UpdateUserSegment(ctx, id, segment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserSegment", reflect.TypeOf((*Client)(nil).UpdateUserSegment), ctx, id, segment)
+}
+
 // UpdateWebhook mocks base method.
 func (m *Client) UpdateWebhook(ctx context.Context, webhookID string, hook *zendesk.Webhook) error {
 	m.ctrl.T.Helper()
@@ -41,6 +41,9 @@ type OrganizationAPI interface {
 	GetOrganizationByExternalID(ctx context.Context, externalID string) ([]Organization, Page, error)
 	UpdateOrganization(ctx context.Context, orgID int64, org Organization) (Organization, error)
 	DeleteOrganization(ctx context.Context, orgID int64) error
+	CreateManyOrganizations(ctx context.Context, orgs []Organization) (JobStatus, error)
+	UpdateManyOrganizations(ctx context.Context, orgs []Organization) (JobStatus, error)
+	DeleteManyOrganizations(ctx context.Context, orgIDs []int64) (JobStatus, error)
 	GetOrganizationsIterator(ctx context.Context, opts *PaginationOptions) *Iterator[Organization]
 	GetOrganizationsOBP(ctx context.Context, opts *OBPOptions) ([]Organization, Page, error)
 	GetOrganizationsCBP(ctx context.Context, opts *CBPOptions) ([]Organization, CursorPaginationMeta, error)
@@ -172,3 +175,31 @@ func (z *Client) DeleteOrganization(ctx context.Context, orgID int64) error {
 
 	return nil
 }
+
+// CreateManyOrganizations creates up to 100 organizations in a background job
+// ref: https://developer.zendesk.com/api-reference/ticketing/organizations/organizations/#create-many-organizations
+func (z *Client) CreateManyOrganizations(ctx context.Context, orgs []Organization) (JobStatus, error) {
+	var data struct {
+		Organizations []Organization `json:"organizations"`
+	}
+	data.Organizations = orgs
+
+	return z.postJob(ctx, "/organizations/create_many.json", data)
+}
+
+// UpdateManyOrganizations updates up to 100 organizations identified by their IDs in a background job
+// ref: https://developer.zendesk.com/api-reference/ticketing/organizations/organizations/#update-many-organizations
+func (z *Client) UpdateManyOrganizations(ctx context.Context, orgs []Organization) (JobStatus, error) {
+	var data struct {
+		Organizations []Organization `json:"organizations"`
+	}
+	data.Organizations = orgs
+
+	return z.putJob(ctx, "/organizations/update_many.json", data)
+}
+
+// DeleteManyOrganizations deletes up to 100 organizations in a background job
+// ref: https://developer.zendesk.com/api-reference/ticketing/organizations/organizations/#bulk-delete-organizations
+func (z *Client) DeleteManyOrganizations(ctx context.Context, orgIDs []int64) (JobStatus, error) {
+	return z.deleteJob(ctx, "/organizations/destroy_many.json", orgIDs)
+}
@@ -29,6 +29,24 @@ type (
 		UserID         int64 `json:"user_id,omitempty" url:"user_id,omitempty"`
 	}
 
+	// JoinedOrganizationMembershipListOptions is a struct for options of the joined organization membership list
+	// ref: https://developer.zendesk.com/api-reference/ticketing/organizations/organization_memberships/#sideloads
+	JoinedOrganizationMembershipListOptions struct {
+		OrganizationMembershipListOptions
+
+		// Include is a comma separated list of sideloads, e.g. "users,organizations"
+		Include string `json:"include,omitempty" url:"include,omitempty"`
+	}
+
+	// JoinedOrganizationMembership is an organization membership joined with its
+	// sideloaded user and organization. User or Organization is nil when it was
+	// not sideloaded.
+	JoinedOrganizationMembership struct {
+		OrganizationMembership
+		User         *User
+		Organization *Organization
+	}
+
 	// OrganizationMembershipOptions is a struct for options for organization membership
 	// https://developer.zendesk.com/api-reference/ticketing/organizations/organization_memberships/
 	OrganizationMembershipOptions struct {
@@ -39,8 +57,10 @@ type (
 	// OrganizationMembershipAPI is an interface containing organization membership related methods
 	OrganizationMembershipAPI interface {
 		GetOrganizationMemberships(context.Context, *OrganizationMembershipListOptions) ([]OrganizationMembership, Page, error)
+		GetJoinedOrganizationMemberships(ctx context.Context, opts *JoinedOrganizationMembershipListOptions) ([]JoinedOrganizationMembership, Page, error)
 		CreateOrganizationMembership(context.Context, OrganizationMembershipOptions) (OrganizationMembership, error)
 		SetDefaultOrganization(context.Context, OrganizationMembershipOptions) (OrganizationMembership, error)
+		DeleteOrganizationMembership(ctx context.Context, membershipID int64) error
 		GetOrganizationMembershipsIterator(ctx context.Context, opts *PaginationOptions) *Iterator[OrganizationMembership]
 		GetOrganizationMembershipsOBP(ctx context.Context, opts *OBPOptions) ([]OrganizationMembership, Page, error)
 		GetOrganizationMembershipsCBP(ctx context.Context, opts *CBPOptions) ([]OrganizationMembership, CursorPaginationMeta, error)
@@ -77,6 +97,52 @@ func (z *Client) GetOrganizationMemberships(ctx context.Context, opts *Organizat
 	return result.OrganizationMemberships, result.Page, nil
 }
 
+// GetJoinedOrganizationMemberships gets organization memberships with their users
+// and organizations sideloaded. Both are sideloaded unless opts.Include is specified.
+// ref: https://developer.zendesk.com/api-reference/ticketing/organizations/organization_memberships/#sideloads
+func (z *Client) GetJoinedOrganizationMemberships(ctx context.Context, opts *JoinedOrganizationMembershipListOptions) ([]JoinedOrganizationMembership, Page, error) {
+	var result struct {
+		OrganizationMemberships []OrganizationMembership `json:"organization_memberships"`
+		sideloads
+		Page
+	}
+
+	tmp := JoinedOrganizationMembershipListOptions{}
+	if opts != nil {
+		tmp = *opts
+	}
+	if tmp.Include == "" {
+		tmp.Include = SideloadUsers + "," + SideloadOrganizations
+	}
+
+	u, err := addOptions("/organization_memberships.json", tmp)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	body, err := z.get(ctx, u)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, Page{}, err
+	}
+
+	users := result.usersByID()
+	organizations := result.organizationsByID()
+	joined := make([]JoinedOrganizationMembership, len(result.OrganizationMemberships))
+	for i, m := range result.OrganizationMemberships {
+		joined[i] = JoinedOrganizationMembership{
+			OrganizationMembership: m,
+			User:                   users[m.UserID],
+			Organization:           organizations[m.OrganizationID],
+		}
+	}
+
+	return joined, result.Page, nil
+}
+
 // CreateOrganizationMembership creates an organization membership for an existing user and org
 // https://developer.zendesk.com/api-reference/ticketing/organizations/organization_memberships/#create-membership
 func (z *Client) CreateOrganizationMembership(ctx context.Context, opts OrganizationMembershipOptions) (OrganizationMembership, error) {
@@ -121,3 +187,9 @@ func (z *Client) SetDefaultOrganization(ctx context.Context, opts OrganizationMe
 
 	return result.OrganizationMembership, nil
 }
+
+// DeleteOrganizationMembership removes a user from an organization
+// https://developer.zendesk.com/api-reference/ticketing/organizations/organization_memberships/#delete-membership
+func (z *Client) DeleteOrganizationMembership(ctx context.Context, membershipID int64) error {
+	return z.delete(ctx, fmt.Sprintf("/organization_memberships/%d.json", membershipID))
+}
@@ -2,6 +2,8 @@ package zendesk
 
 import (
 	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
 )
 
@@ -22,6 +24,42 @@ func TestGetOrganizationMemberships(t *testing.T) {
 	}
 }
 
+func TestGetJoinedOrganizationMemberships(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/organization_memberships.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		if include := r.URL.Query().Get("include"); include != "users" {
+			t.Errorf("Expected include option to be passed through, but got include=%s", include)
+		}
+		w.Write(readFixture(filepath.Join(http.MethodGet, "organization_memberships_sideloaded.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	memberships, _, err := client.GetJoinedOrganizationMemberships(ctx, &JoinedOrganizationMembershipListOptions{
+		Include: SideloadUsers,
+	})
+	if err != nil {
+		t.Fatalf("Failed to get organization memberships: %s", err)
+	}
+
+	if len(memberships) != 2 {
+		t.Fatalf("expected length of organization memberships is 2, but got %d", len(memberships))
+	}
+	for _, m := range memberships {
+		if m.User == nil || m.User.ID != m.UserID {
+			t.Fatalf("User of the membership %d is not joined: %v", m.ID, m.User)
+		}
+		if m.Organization == nil || m.Organization.Name != "Rebel Alliance" {
+			t.Fatalf("Organization of the membership %d is not joined: %v", m.ID, m.Organization)
+		}
+	}
+	if memberships[1].User.Name != "Leia Organa" {
+		t.Fatalf("expected user Leia Organa, but got %s", memberships[1].User.Name)
+	}
+}
+
 func TestCreateOrganizationMembership(t *testing.T) {
 	mockAPI := newMockAPIWithStatus(http.MethodPost, "organization_membership.json", http.StatusCreated)
 	client := newTestClient(mockAPI)
@@ -48,3 +86,19 @@ func TestSetDefaultOrganization(t *testing.T) {
 		t.Fatalf("Returned org membership does not have the expected default status %v. It is %v", expectedDefault, orgMembership.Default)
 	}
 }
+
+func TestDeleteOrganizationMembership(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/organization_memberships/4.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	err := client.DeleteOrganizationMembership(ctx, 4)
+	if err != nil {
+		t.Fatalf("Failed to delete organization membership: %s", err)
+	}
+}
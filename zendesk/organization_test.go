@@ -3,6 +3,7 @@ package zendesk
 import (
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"testing"
 )
 
@@ -76,3 +77,48 @@ func TestDeleteOrganization(t *testing.T) {
 		t.Fatalf("Failed to delete organization: %s", err)
 	}
 }
+
+func TestCreateManyOrganizations(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodPost, "job_status.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	job, err := client.CreateManyOrganizations(ctx, []Organization{{Name: "a"}, {Name: "b"}})
+	if err != nil {
+		t.Fatalf("Failed to create many organizations: %s", err)
+	}
+
+	if job.Status != JobStatusQueued {
+		t.Fatalf("Returned job status is not the expected one: %+v", job)
+	}
+}
+
+func TestUpdateManyOrganizations(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodPut, "job_status.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	job, err := client.UpdateManyOrganizations(ctx, []Organization{{ID: 1, Name: "a"}})
+	if err != nil {
+		t.Fatalf("Failed to update many organizations: %s", err)
+	}
+
+	if job.Status != JobStatusQueued {
+		t.Fatalf("Returned job status is not the expected one: %+v", job)
+	}
+}
+
+func TestDeleteManyOrganizations(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/organizations/destroy_many.json" || r.URL.Query().Get("ids") != "1,2" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write(readFixture(filepath.Join(http.MethodPost, "job_status.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	if _, err := client.DeleteManyOrganizations(ctx, []int64{1, 2}); err != nil {
+		t.Fatalf("Failed to delete many organizations: %s", err)
+	}
+}
@@ -0,0 +1,130 @@
+package zendesk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PermissionGroup defines which user segments can edit and publish Help Center articles
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/permission_groups/
+type PermissionGroup struct {
+	ID        int64     `json:"id,omitempty"`
+	Name      string    `json:"name"`
+	Edit      []int64   `json:"edit"`
+	Publish   []int64   `json:"publish"`
+	BuiltIn   bool      `json:"built_in,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// PermissionGroupAPI an interface containing all permission group related methods
+type PermissionGroupAPI interface {
+	GetPermissionGroups(ctx context.Context, opts *PageOptions) ([]PermissionGroup, Page, error)
+	GetPermissionGroup(ctx context.Context, id int64) (PermissionGroup, error)
+	CreatePermissionGroup(ctx context.Context, group PermissionGroup) (PermissionGroup, error)
+	UpdatePermissionGroup(ctx context.Context, id int64, group PermissionGroup) (PermissionGroup, error)
+	DeletePermissionGroup(ctx context.Context, id int64) error
+}
+
+// GetPermissionGroups fetch permission group list
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/permission_groups/#list-permission-groups
+func (z *Client) GetPermissionGroups(ctx context.Context, opts *PageOptions) ([]PermissionGroup, Page, error) {
+	var data struct {
+		PermissionGroups []PermissionGroup `json:"permission_groups"`
+		Page
+	}
+
+	tmp := opts
+	if tmp == nil {
+		tmp = &PageOptions{}
+	}
+
+	u, err := addOptions("/guide/permission_groups.json", tmp)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	body, err := z.get(ctx, u)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, Page{}, err
+	}
+	return data.PermissionGroups, data.Page, nil
+}
+
+// GetPermissionGroup gets a specified permission group
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/permission_groups/#show-permission-group
+func (z *Client) GetPermissionGroup(ctx context.Context, id int64) (PermissionGroup, error) {
+	var result struct {
+		PermissionGroup PermissionGroup `json:"permission_group"`
+	}
+
+	body, err := z.get(ctx, fmt.Sprintf("/guide/permission_groups/%d.json", id))
+	if err != nil {
+		return PermissionGroup{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return PermissionGroup{}, err
+	}
+	return result.PermissionGroup, nil
+}
+
+// CreatePermissionGroup creates new permission group
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/permission_groups/#create-permission-group
+func (z *Client) CreatePermissionGroup(ctx context.Context, group PermissionGroup) (PermissionGroup, error) {
+	var data, result struct {
+		PermissionGroup PermissionGroup `json:"permission_group"`
+	}
+	data.PermissionGroup = group
+
+	body, err := z.post(ctx, "/guide/permission_groups.json", data)
+	if err != nil {
+		return PermissionGroup{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return PermissionGroup{}, err
+	}
+	return result.PermissionGroup, nil
+}
+
+// UpdatePermissionGroup updates a permission group with the specified permission group
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/permission_groups/#update-permission-group
+func (z *Client) UpdatePermissionGroup(ctx context.Context, id int64, group PermissionGroup) (PermissionGroup, error) {
+	var data, result struct {
+		PermissionGroup PermissionGroup `json:"permission_group"`
+	}
+	data.PermissionGroup = group
+
+	body, err := z.put(ctx, fmt.Sprintf("/guide/permission_groups/%d.json", id), data)
+	if err != nil {
+		return PermissionGroup{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return PermissionGroup{}, err
+	}
+	return result.PermissionGroup, nil
+}
+
+// DeletePermissionGroup deletes the specified permission group
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/permission_groups/#delete-permission-group
+func (z *Client) DeletePermissionGroup(ctx context.Context, id int64) error {
+	return z.delete(ctx, fmt.Sprintf("/guide/permission_groups/%d.json", id))
+}
@@ -0,0 +1,91 @@
+package zendesk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetPermissionGroups(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "permission_groups.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	groups, _, err := client.GetPermissionGroups(ctx, nil)
+	if err != nil {
+		t.Fatalf("Failed to get permission groups: %s", err)
+	}
+
+	if len(groups) != 2 {
+		t.Fatalf("expected length of permission groups is 2, but got %d", len(groups))
+	}
+	if len(groups[1].Edit) != 2 || groups[1].Publish[0] != 7284 {
+		t.Fatalf("Returned permission group does not have the expected segments %+v", groups[1])
+	}
+}
+
+func TestGetPermissionGroup(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "permission_group.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	group, err := client.GetPermissionGroup(ctx, 1234)
+	if err != nil {
+		t.Fatalf("Failed to get permission group: %s", err)
+	}
+
+	expectedID := int64(1234)
+	if group.ID != expectedID {
+		t.Fatalf("Returned permission group does not have the expected ID %d. Permission group id is %d", expectedID, group.ID)
+	}
+}
+
+func TestCreatePermissionGroup(t *testing.T) {
+	mockAPI := newMockAPIWithStatus(http.MethodPost, "permission_group.json", http.StatusCreated)
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	group, err := client.CreatePermissionGroup(ctx, PermissionGroup{
+		Name:    "Editors",
+		Edit:    []int64{7283, 7284},
+		Publish: []int64{7284},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create permission group: %s", err)
+	}
+
+	if group.ID == 0 {
+		t.Fatal("Created permission group should have an ID")
+	}
+}
+
+func TestUpdatePermissionGroup(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodPut, "permission_group.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	group, err := client.UpdatePermissionGroup(ctx, 1234, PermissionGroup{Name: "Editors"})
+	if err != nil {
+		t.Fatalf("Failed to update permission group: %s", err)
+	}
+
+	if group.Name != "Editors" {
+		t.Fatalf("Updated permission group does not have the expected name %s", group.Name)
+	}
+}
+
+func TestDeletePermissionGroup(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/guide/permission_groups/1234.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	err := client.DeletePermissionGroup(ctx, 1234)
+	if err != nil {
+		t.Fatalf("Failed to delete permission group: %s", err)
+	}
+}
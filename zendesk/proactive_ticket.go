@@ -0,0 +1,305 @@
+package zendesk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// maxRateLimitRetries is the number of times a ticket is retried after 429 Too Many Requests
+const maxRateLimitRetries = 3
+
+// maxShowManyUsers is the number of users GetManyUsers takes at once
+const maxShowManyUsers = 100
+
+var campaignTagRegexp = regexp.MustCompile(`[^a-z0-9_/-]+`)
+
+// ProactiveTicketOptions is options of CreateProactiveTickets
+type ProactiveTicketOptions struct {
+	// Users are the recipients of the message. They are fetched again when
+	// OptOutField is set, so only their IDs are required.
+	Users []User
+
+	// Query is a user search query whose results are also recipients, e.g.
+	// "organization:acme". It is exported, so it is not limited to 1000 results.
+	Query string
+
+	// Subject and Message are text/template templates rendered for each recipient.
+	// The data is a ProactiveTicketData, e.g. "Hello {{ .User.Name }}".
+	Subject string
+	Message string
+
+	// CampaignID identifies the outreach. Every ticket is tagged with CampaignTag(CampaignID).
+	CampaignID string
+
+	// OptOutField is the key of a checkbox user field. Users whose field is checked are skipped.
+	OptOutField string
+
+	// AuthorID is the agent who sends the message. Defaults to the authenticated user.
+	AuthorID int64
+
+	GroupID  int64
+	Tags     []string
+	Type     string
+	Priority string
+
+	// Status of the tickets. Defaults to "pending", as they wait for the customers.
+	Status string
+
+	// RequestsPerMinute spaces the ticket creations to stay under the rate limit.
+	// Tickets are created as fast as the client allows when it is 0.
+	RequestsPerMinute int
+}
+
+// ProactiveTicketData is the data of the templates of ProactiveTicketOptions
+type ProactiveTicketData struct {
+	User       User
+	CampaignID string
+}
+
+// ProactiveTicketFailure is a recipient whose ticket failed to be created
+type ProactiveTicketFailure struct {
+	UserID int64
+	Err    error
+}
+
+func (f ProactiveTicketFailure) Error() string {
+	return fmt.Sprintf("user %d: %s", f.UserID, f.Err)
+}
+
+// ProactiveTicketReport is the result of CreateProactiveTickets
+type ProactiveTicketReport struct {
+	// TicketIDs maps the recipients to their created tickets
+	TicketIDs map[int64]int64
+
+	// OptedOut are the recipients skipped by their opt-out field
+	OptedOut []int64
+
+	Failures []ProactiveTicketFailure
+}
+
+// CampaignTag returns the tag of the tickets of a proactive campaign
+func CampaignTag(campaignID string) string {
+	return "campaign_" + strings.Trim(campaignTagRegexp.ReplaceAllString(strings.ToLower(campaignID), "_"), "_")
+}
+
+// CreateProactiveTickets creates a ticket requested by each recipient with a
+// personalized message, e.g. to notify the customers affected by an incident.
+// It returns an error when the templates are invalid or the user search fails.
+// Failures of single recipients are recorded in the report. Requests rejected
+// with 429 Too Many Requests are retried after the time given by Retry-After.
+//
+// ref: https://developer.zendesk.com/documentation/ticketing/managing-tickets/creating-and-managing-requests/
+func CreateProactiveTickets(ctx context.Context, api API, opts ProactiveTicketOptions) (ProactiveTicketReport, error) {
+	report := ProactiveTicketReport{TicketIDs: map[int64]int64{}}
+
+	if opts.CampaignID == "" {
+		return report, errors.New("campaign ID is required")
+	}
+	subject, err := template.New("subject").Option("missingkey=error").Parse(opts.Subject)
+	if err != nil {
+		return report, err
+	}
+	message, err := template.New("message").Option("missingkey=error").Parse(opts.Message)
+	if err != nil {
+		return report, err
+	}
+
+	users, missing, err := proactiveRecipients(ctx, api, opts)
+	if err != nil {
+		return report, err
+	}
+	report.Failures = append(report.Failures, missing...)
+
+	var tick <-chan time.Time
+	if opts.RequestsPerMinute > 0 {
+		ticker := time.NewTicker(time.Minute / time.Duration(opts.RequestsPerMinute))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	first := true
+	for _, user := range users {
+		if opts.OptOutField != "" && isChecked(user.UserFields[opts.OptOutField]) {
+			report.OptedOut = append(report.OptedOut, user.ID)
+			continue
+		}
+
+		if tick != nil && !first {
+			select {
+			case <-ctx.Done():
+				return report, ctx.Err()
+			case <-tick:
+			}
+		}
+		first = false
+
+		ticket, err := proactiveTicket(user, opts, subject, message)
+		if err == nil {
+			ticket, err = createTicketWithRetry(ctx, api, ticket)
+		}
+		if err != nil {
+			report.Failures = append(report.Failures, ProactiveTicketFailure{UserID: user.ID, Err: err})
+			if ctx.Err() != nil {
+				return report, ctx.Err()
+			}
+			continue
+		}
+		report.TicketIDs[user.ID] = ticket.ID
+	}
+	return report, nil
+}
+
+// proactiveRecipients returns opts.Users followed by the results of opts.Query,
+// without duplicates. opts.Users are fetched again when an opt-out field is set,
+// as the given users may lack their user fields, and the users which are not
+// found are returned as failures.
+func proactiveRecipients(ctx context.Context, api API, opts ProactiveTicketOptions) ([]User, []ProactiveTicketFailure, error) {
+	seen := map[int64]bool{}
+	var users []User
+	add := func(list []User) {
+		for _, u := range list {
+			if !seen[u.ID] {
+				seen[u.ID] = true
+				users = append(users, u)
+			}
+		}
+	}
+
+	var missing []ProactiveTicketFailure
+	if opts.OptOutField == "" {
+		add(opts.Users)
+	} else {
+		for from := 0; from < len(opts.Users); from += maxShowManyUsers {
+			to := from + maxShowManyUsers
+			if to > len(opts.Users) {
+				to = len(opts.Users)
+			}
+			ids := make([]string, 0, to-from)
+			for _, u := range opts.Users[from:to] {
+				ids = append(ids, strconv.FormatInt(u.ID, 10))
+			}
+
+			fetched, _, err := api.GetManyUsers(ctx, &GetManyUsersOptions{IDs: strings.Join(ids, ",")})
+			if err != nil {
+				return nil, nil, err
+			}
+			byID := make(map[int64]User, len(fetched))
+			for _, u := range fetched {
+				byID[u.ID] = u
+			}
+			for _, u := range opts.Users[from:to] {
+				if f, ok := byID[u.ID]; ok {
+					add([]User{f})
+				} else if !seen[u.ID] {
+					seen[u.ID] = true
+					missing = append(missing, ProactiveTicketFailure{UserID: u.ID, Err: errors.New("user not found")})
+				}
+			}
+		}
+	}
+
+	if opts.Query == "" {
+		return users, missing, nil
+	}
+
+	search := &SearchExportOptions{Query: opts.Query, FilterType: "user", CursorPagination: CursorPagination{PageSize: 100}}
+	for {
+		results, meta, err := api.SearchExport(ctx, search)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, r := range results.List() {
+			if u, ok := r.(User); ok {
+				add([]User{u})
+			}
+		}
+		if !meta.HasMore || meta.AfterCursor == "" {
+			return users, missing, nil
+		}
+		search.PageAfter = meta.AfterCursor
+	}
+}
+
+// proactiveTicket builds the ticket of a recipient
+func proactiveTicket(user User, opts ProactiveTicketOptions, subject, message *template.Template) (Ticket, error) {
+	data := ProactiveTicketData{User: user, CampaignID: opts.CampaignID}
+
+	var s, m bytes.Buffer
+	if err := subject.Execute(&s, data); err != nil {
+		return Ticket{}, err
+	}
+	if err := message.Execute(&m, data); err != nil {
+		return Ticket{}, err
+	}
+
+	status := opts.Status
+	if status == "" {
+		status = "pending"
+	}
+
+	public := true
+	ticket := Ticket{
+		Subject:     s.String(),
+		Type:        opts.Type,
+		Priority:    opts.Priority,
+		Status:      status,
+		RequesterID: user.ID,
+		SubmitterID: opts.AuthorID,
+		Tags:        append(append([]string{}, opts.Tags...), CampaignTag(opts.CampaignID)),
+		Comment: &TicketComment{
+			Body:     m.String(),
+			Public:   &public,
+			AuthorID: opts.AuthorID,
+		},
+	}
+	if opts.GroupID != 0 {
+		ticket.GroupID = json.Number(strconv.FormatInt(opts.GroupID, 10))
+	}
+	return ticket, nil
+}
+
+// createTicketWithRetry creates the ticket, waiting for Retry-After when the
+// rate limit is exceeded
+func createTicketWithRetry(ctx context.Context, api API, ticket Ticket) (Ticket, error) {
+	for retry := 0; ; retry++ {
+		created, err := api.CreateTicket(ctx, ticket)
+
+		var zerr Error
+		if err == nil || retry == maxRateLimitRetries || !errors.As(err, &zerr) || zerr.Status() != http.StatusTooManyRequests {
+			return created, err
+		}
+
+		wait := time.Minute
+		if secs, perr := strconv.Atoi(zerr.Headers().Get("Retry-After")); perr == nil {
+			wait = time.Duration(secs) * time.Second
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return Ticket{}, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// isChecked reports whether a checkbox user field is checked
+func isChecked(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return false
+}
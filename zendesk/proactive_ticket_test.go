@@ -0,0 +1,113 @@
+package zendesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateProactiveTickets(t *testing.T) {
+	var created []map[string]interface{}
+	limited := false
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/users/show_many.json":
+			// the given users are fetched again for their user fields. user 5 was deleted
+			if ids := r.URL.Query().Get("ids"); ids != "1,4,5" {
+				t.Errorf("Unexpected IDs %s", ids)
+			}
+			w.Write([]byte(`{"users": [
+				{"id": 1, "name": "Alice"},
+				{"id": 4, "name": "Dave", "user_fields": {"outage_opt_out": true}}
+			]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/search/export.json":
+			if q := r.URL.Query(); q.Get("query") != "organization:acme" || q.Get("filter[type]") != "user" {
+				t.Errorf("Unexpected query %s", r.URL.RawQuery)
+			}
+			switch r.URL.Query().Get("page[after]") {
+			case "":
+				w.Write([]byte(`{"results": [
+					{"result_type": "user", "id": 2, "name": "Bob", "user_fields": {"outage_opt_out": true}},
+					{"result_type": "user", "id": 1, "name": "Alice"}
+				], "meta": {"has_more": true, "after_cursor": "next"}}`))
+			case "next":
+				w.Write([]byte(`{"results": [{"result_type": "user", "id": 3, "name": "Carol", "user_fields": {"outage_opt_out": false, "plan": "enterprise"}}],
+					"meta": {"has_more": false}}`))
+			default:
+				t.Errorf("Unexpected cursor %s", r.URL.Query().Get("page[after]"))
+			}
+		case r.Method == http.MethodPost && r.URL.Path == "/tickets.json":
+			if !limited {
+				limited = true
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			var data struct {
+				Ticket map[string]interface{} `json:"ticket"`
+			}
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &data); err != nil {
+				t.Errorf("Failed to decode ticket: %s", err)
+			}
+			created = append(created, data.Ticket)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"ticket": {"id": %d}}`, 100+len(created))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	report, err := CreateProactiveTickets(ctx, client, ProactiveTicketOptions{
+		Users:             []User{{ID: 1}, {ID: 4}, {ID: 5}},
+		Query:             "organization:acme",
+		Subject:           "Service disruption for {{ .User.Name }}",
+		Message:           "Hi {{ .User.Name }}, we are investigating an outage ({{ .CampaignID }}).",
+		CampaignID:        "INC-2024/05 Outage",
+		OptOutField:       "outage_opt_out",
+		AuthorID:          9,
+		GroupID:           5,
+		Tags:              []string{"incident"},
+		RequestsPerMinute: 6000,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create proactive tickets: %s", err)
+	}
+
+	if len(report.TicketIDs) != 2 || report.TicketIDs[1] != 101 || report.TicketIDs[3] != 102 {
+		t.Fatalf("Unexpected created tickets %v", report.TicketIDs)
+	}
+	if len(report.OptedOut) != 2 || report.OptedOut[0] != 4 || report.OptedOut[1] != 2 {
+		t.Fatalf("Unexpected opted out users %v", report.OptedOut)
+	}
+	if len(report.Failures) != 1 || report.Failures[0].UserID != 5 {
+		t.Fatalf("Missing user should be a failure %+v", report.Failures)
+	}
+
+	alice := created[0]
+	if alice["requester_id"] != float64(1) || alice["submitter_id"] != float64(9) || alice["group_id"] != float64(5) || alice["status"] != "pending" {
+		t.Fatalf("Unexpected ticket %v", alice)
+	}
+	if alice["subject"] != "Service disruption for Alice" {
+		t.Fatalf("Unexpected subject %v", alice["subject"])
+	}
+	comment := alice["comment"].(map[string]interface{})
+	if comment["body"] != "Hi Alice, we are investigating an outage (INC-2024/05 Outage)." || comment["public"] != true {
+		t.Fatalf("Unexpected comment %v", comment)
+	}
+	if tags, _ := json.Marshal(alice["tags"]); string(tags) != `["incident","campaign_inc-2024/05_outage"]` {
+		t.Fatalf("Unexpected tags %s", tags)
+	}
+}
+
+func TestCreateProactiveTicketsInvalidTemplate(t *testing.T) {
+	_, err := CreateProactiveTickets(ctx, nil, ProactiveTicketOptions{CampaignID: "x", Message: "{{ .User.Name"})
+	if err == nil {
+		t.Fatal("Invalid message template should be an error")
+	}
+}
@@ -0,0 +1,85 @@
+package recurring
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("Failed to load location: %s", err)
+	}
+
+	// 2024-05-01 is a Wednesday
+	from := time.Date(2024, 5, 1, 10, 30, 15, 0, time.UTC)
+	cases := []struct {
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"* * * * *", from, time.Date(2024, 5, 1, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", from, time.Date(2024, 5, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 9 * * mon", from, time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", from, time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)},
+		{"30 10 1 * *", from, time.Date(2024, 6, 1, 10, 30, 0, 0, time.UTC)},
+		{"0 0 31 * *", from, time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 feb *", from, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 8 1,15 * 7", from, time.Date(2024, 5, 5, 8, 0, 0, 0, time.UTC)},
+		{"5/20 12-14 * * *", from, time.Date(2024, 5, 1, 12, 5, 0, 0, time.UTC)},
+		{"@monthly", from, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", from, time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * *", from.In(tokyo), time.Date(2024, 5, 2, 9, 0, 0, 0, tokyo)},
+		{"0 0 1 1 *", time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		s, err := ParseSchedule(c.spec)
+		if err != nil {
+			t.Fatalf("Failed to parse schedule %s: %s", c.spec, err)
+		}
+		if got := s.Next(c.from); !got.Equal(c.want) {
+			t.Fatalf("%s: expected %s, but got %s", c.spec, c.want, got)
+		}
+	}
+
+	s, _ := ParseSchedule("0 0 30 feb *")
+	if got := s.Next(from); !got.IsZero() {
+		t.Fatalf("Impossible schedule should have no next time, but got %s", got)
+	}
+
+	for _, invalid := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "* * * foo *", "5-1 * * * *", "*/0 * * * *"} {
+		if _, err := ParseSchedule(invalid); err == nil {
+			t.Fatalf("Invalid schedule %q should not be parsed", invalid)
+		}
+	}
+}
+
+func TestScheduleNextDSTFallBack(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Failed to load location: %s", err)
+	}
+
+	// clocks go back from 02:00 EDT to 01:00 EST on 2024-11-03
+	daily, _ := ParseSchedule("0 1 * * *")
+	var got []time.Time
+	for next := time.Date(2024, 11, 2, 12, 0, 0, 0, newYork); len(got) < 3; {
+		next = daily.Next(next)
+		got = append(got, next)
+	}
+	for i, want := range []time.Time{
+		time.Date(2024, 11, 3, 1, 0, 0, 0, newYork),
+		time.Date(2024, 11, 4, 1, 0, 0, 0, newYork),
+		time.Date(2024, 11, 5, 1, 0, 0, 0, newYork),
+	} {
+		if !got[i].Equal(want) {
+			t.Fatalf("Daily schedule should occur once a day, but got %v", got)
+		}
+	}
+
+	halfHourly, _ := ParseSchedule("*/30 * * * *")
+	from := time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC).In(newYork) // 01:30 EDT
+	if next := halfHourly.Next(from); !next.Equal(time.Date(2024, 11, 3, 7, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Repeated hour should be skipped, but got %s", next)
+	}
+}
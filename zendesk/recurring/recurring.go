@@ -0,0 +1,234 @@
+// Package recurring is a scheduler which creates tickets from templates on
+// cron-like schedules, such as weekly maintenance and compliance tickets.
+//
+// The scheduler saves which occurrences it handled in a Store, and tags each
+// ticket with an external ID derived from its template and occurrence. A
+// restarted scheduler continues from the saved state, and an occurrence whose
+// creation was interrupted is looked up by its external ID before it is
+// created again, so no occurrence creates more than one ticket.
+package recurring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// Config is configuration of the Scheduler
+type Config struct {
+	// API creates the tickets. Required.
+	API zendesk.API
+
+	// Templates are the recurring tickets
+	Templates []Template
+
+	// Store keeps the run state. Defaults to a MemoryStore, which does not
+	// survive a restart.
+	Store Store
+
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Created is a ticket created for an occurrence
+type Created struct {
+	Occurrence
+	TicketID int64
+
+	// Recovered reports that the ticket was created by an interrupted run and found by its external ID
+	Recovered bool
+}
+
+// Failure is an occurrence which failed to be created. It is retried by the next run.
+type Failure struct {
+	Occurrence
+	Err error
+}
+
+func (f Failure) Error() string {
+	return fmt.Sprintf("%s at %s: %s", f.Template, f.Time.Format(time.RFC3339), f.Err)
+}
+
+// Report summarizes a run
+type Report struct {
+	Created  []Created
+	Failures []Failure
+}
+
+// Scheduler creates the tickets of the templates when they are due
+type Scheduler struct {
+	cfg Config
+	mu  sync.Mutex
+}
+
+// New returns a Scheduler
+func New(cfg Config) (*Scheduler, error) {
+	if cfg.API == nil {
+		return nil, errors.New("recurring: api is required")
+	}
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryStore()
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	names := map[string]bool{}
+	templates := make([]Template, len(cfg.Templates))
+	for i, t := range cfg.Templates {
+		if err := t.compile(); err != nil {
+			return nil, err
+		}
+		if names[t.Name] {
+			return nil, fmt.Errorf("recurring: template %s is duplicated", t.Name)
+		}
+		names[t.Name] = true
+		templates[i] = t
+	}
+	cfg.Templates = templates
+
+	return &Scheduler{cfg: cfg}, nil
+}
+
+// ExternalID returns the external ID of the ticket of an occurrence
+func ExternalID(template string, t time.Time) string {
+	return fmt.Sprintf("recurring:%s:%s", template, t.UTC().Format(time.RFC3339))
+}
+
+// RunDue creates the tickets of all occurrences which became due since the
+// last run. It returns an error only when the state cannot be loaded or saved.
+// Failures of single occurrences are recorded in the report, and the later
+// occurrences of the same template wait until it succeeds.
+func (s *Scheduler) RunDue(ctx context.Context) (Report, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var report Report
+	state, err := s.cfg.Store.Load()
+	if err != nil {
+		return report, err
+	}
+
+	now := s.cfg.Now()
+	for i := range s.cfg.Templates {
+		t := &s.cfg.Templates[i]
+		if err := s.runTemplate(ctx, t, state, now, &report); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+func (s *Scheduler) runTemplate(ctx context.Context, t *Template, state State, now time.Time, report *Report) error {
+	st, ok := state[t.Name]
+	if !ok {
+		st.LastRun = now
+		if !t.Start.IsZero() {
+			// the occurrence at Start itself is due
+			st.LastRun = t.Start.Add(-time.Minute)
+		}
+		state[t.Name] = st
+		if err := s.cfg.Store.Save(state); err != nil {
+			return err
+		}
+	}
+
+	var due []time.Time
+	if st.Pending != nil {
+		due = append(due, *st.Pending)
+	}
+	for next := t.schedule.Next(st.LastRun.In(t.location)); !next.IsZero() && !next.After(now); next = t.schedule.Next(next) {
+		if st.Pending == nil || next.After(*st.Pending) {
+			due = append(due, next)
+		}
+	}
+	if t.SkipMissed && st.Pending == nil && len(due) > 1 {
+		due = due[len(due)-1:]
+	}
+
+	for _, at := range due {
+		o := Occurrence{Template: t.Name, Time: at.In(t.location), Counter: st.Counter + 1}
+		if st.Counter == 0 && t.CounterStart != 0 {
+			o.Counter = t.CounterStart
+		}
+
+		recovering := st.Pending != nil
+		pending := at
+		st.Pending = &pending
+		state[t.Name] = st
+		if err := s.cfg.Store.Save(state); err != nil {
+			return err
+		}
+
+		created, err := s.create(ctx, t, o, recovering)
+		if err != nil {
+			report.Failures = append(report.Failures, Failure{Occurrence: o, Err: err})
+			return nil
+		}
+		report.Created = append(report.Created, created)
+
+		st = TemplateState{LastRun: at, Counter: o.Counter, LastTicketID: created.TicketID}
+		state[t.Name] = st
+		if err := s.cfg.Store.Save(state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// create creates the ticket of an occurrence. When recovering from an
+// interrupted run, a ticket which already has the external ID is used instead.
+func (s *Scheduler) create(ctx context.Context, t *Template, o Occurrence, recovering bool) (Created, error) {
+	externalID := ExternalID(t.Name, o.Time)
+	if recovering {
+		tickets, _, err := s.cfg.API.GetTickets(ctx, &zendesk.TicketListOptions{ExternalID: externalID})
+		if err != nil {
+			return Created{}, err
+		}
+		for _, ticket := range tickets {
+			if ticket.ExternalID == externalID {
+				return Created{Occurrence: o, TicketID: ticket.ID, Recovered: true}, nil
+			}
+		}
+	}
+
+	ticket, err := t.BuildTicket(o)
+	if err != nil {
+		return Created{}, err
+	}
+	ticket.ExternalID = externalID
+
+	created, err := s.cfg.API.CreateTicket(ctx, ticket)
+	if err != nil {
+		return Created{}, err
+	}
+	return Created{Occurrence: o, TicketID: created.ID}, nil
+}
+
+// Run calls RunDue every interval until ctx is done. onReport is called with
+// the report of every run which created or failed to create tickets, and may
+// be nil. It returns the error of RunDue, or the error of ctx.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration, onReport func(Report)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := s.RunDue(ctx)
+		if err != nil {
+			return err
+		}
+		if onReport != nil && (len(report.Created) > 0 || len(report.Failures) > 0) {
+			onReport(report)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
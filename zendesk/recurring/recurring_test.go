@@ -0,0 +1,250 @@
+package recurring
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+const testTemplates = `
+templates:
+  - name: backup-check
+    schedule: "0 9 * * mon"
+    timezone: Asia/Tokyo
+    counter_start: 10
+    ticket:
+      subject: 'Backup check {{ .Time | date "2006-01-02" }} (#{{ .Counter }})'
+      comment: Check the backups of week {{ .Time | week }}.
+      public: false
+      type: task
+      group_id: 5
+      tags: [maintenance, 'week-{{ .Time | week }}']
+      custom_fields:
+        100: '{{ .Time | addDays 6 | date "2006-01-02" }}'
+        101: 3
+      due_in: 72h
+`
+
+// fakeTickets is a fake ticket API which records created tickets
+type fakeTickets struct {
+	mu      sync.Mutex
+	tickets []map[string]interface{}
+	fail    bool
+}
+
+func (f *fakeTickets) server(t *testing.T) *zendesk.Client {
+	t.Helper()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/tickets.json":
+			if f.fail {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			var data struct {
+				Ticket map[string]interface{} `json:"ticket"`
+			}
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &data); err != nil {
+				t.Errorf("Failed to decode ticket: %s", err)
+			}
+			data.Ticket["id"] = len(f.tickets) + 1
+			f.tickets = append(f.tickets, data.Ticket)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(data)
+		case r.Method == http.MethodGet && r.URL.Path == "/tickets.json":
+			var found []map[string]interface{}
+			for _, ticket := range f.tickets {
+				if ticket["external_id"] == r.URL.Query().Get("external_id") {
+					found = append(found, ticket)
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"tickets": found})
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	client, _ := zendesk.NewClient(nil)
+	client.SetEndpointURL(s.URL)
+	return client
+}
+
+func loadTestTemplates(t *testing.T) []Template {
+	t.Helper()
+
+	templates, err := LoadTemplates(strings.NewReader(testTemplates))
+	if err != nil {
+		t.Fatalf("Failed to load templates: %s", err)
+	}
+	return templates
+}
+
+func TestBuildTicket(t *testing.T) {
+	templates := loadTestTemplates(t)
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	ticket, err := templates[0].BuildTicket(Occurrence{
+		Template: "backup-check",
+		Time:     time.Date(2024, 5, 6, 9, 0, 0, 0, tokyo),
+		Counter:  12,
+	})
+	if err != nil {
+		t.Fatalf("Failed to build ticket: %s", err)
+	}
+
+	if ticket.Subject != "Backup check 2024-05-06 (#12)" || ticket.Comment.Body != "Check the backups of week 19." {
+		t.Fatalf("Unexpected text of ticket %s: %s", ticket.Subject, ticket.Comment.Body)
+	}
+	if *ticket.Comment.Public || ticket.Type != "task" || ticket.GroupID != "5" {
+		t.Fatalf("Unexpected ticket %v", ticket)
+	}
+	if len(ticket.Tags) != 2 || ticket.Tags[1] != "week-19" {
+		t.Fatalf("Unexpected tags %v", ticket.Tags)
+	}
+	if len(ticket.CustomFields) != 2 || ticket.CustomFields[0].Value != "2024-05-12" || ticket.CustomFields[1].Value != "3" {
+		t.Fatalf("Unexpected custom fields %v", ticket.CustomFields)
+	}
+	if !ticket.DueAt.Equal(time.Date(2024, 5, 9, 9, 0, 0, 0, tokyo)) {
+		t.Fatalf("Unexpected due date %s", ticket.DueAt)
+	}
+
+	for _, invalid := range []string{
+		"templates:\n  - name: x\n    schedule: '* *'\n",
+		"templates:\n  - schedule: '@daily'\n",
+		"templates:\n  - name: x\n    schedule: '@daily'\n    ticket:\n      subject: '{{ .Nope'\n",
+		"templates:\n  - name: x\n    schedule: '@daily'\n    unknown: 1\n",
+	} {
+		if _, err := LoadTemplates(strings.NewReader(invalid)); err == nil {
+			t.Fatalf("Invalid templates should not be loaded: %s", invalid)
+		}
+	}
+}
+
+func TestRunDue(t *testing.T) {
+	fake := &fakeTickets{}
+	client := fake.server(t)
+	store := NewFileStore(filepath.Join(t.TempDir(), "state.json"))
+
+	// Monday 2024-05-06 08:00 in Tokyo
+	now := time.Date(2024, 5, 5, 23, 0, 0, 0, time.UTC)
+	newScheduler := func() *Scheduler {
+		s, err := New(Config{
+			API:       client,
+			Templates: loadTestTemplates(t),
+			Store:     store,
+			Now:       func() time.Time { return now },
+		})
+		if err != nil {
+			t.Fatalf("Failed to create scheduler: %s", err)
+		}
+		return s
+	}
+
+	s := newScheduler()
+	report, err := s.RunDue(ctx())
+	if err != nil || len(report.Created) != 0 {
+		t.Fatalf("First run should only record the start: %v %v", report, err)
+	}
+
+	// 09:00 in Tokyo
+	now = now.Add(time.Hour)
+	report, err = s.RunDue(ctx())
+	if err != nil {
+		t.Fatalf("Failed to run: %s", err)
+	}
+	if len(report.Created) != 1 || report.Created[0].Counter != 10 || report.Created[0].TicketID != 1 {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+	if fake.tickets[0]["external_id"] != "recurring:backup-check:2024-05-06T00:00:00Z" {
+		t.Fatalf("Unexpected external ID %v", fake.tickets[0]["external_id"])
+	}
+
+	// a restarted scheduler does not create the occurrence again
+	s = newScheduler()
+	if report, _ := s.RunDue(ctx()); len(report.Created) != 0 || len(fake.tickets) != 1 {
+		t.Fatalf("Occurrence should not be created twice: %+v", report)
+	}
+
+	// missed occurrences are created in order after a downtime
+	now = now.Add(14 * 24 * time.Hour)
+	report, err = newScheduler().RunDue(ctx())
+	if err != nil {
+		t.Fatalf("Failed to run: %s", err)
+	}
+	if len(report.Created) != 2 || report.Created[0].Counter != 11 || report.Created[1].Counter != 12 {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+	if fake.tickets[2]["subject"] != "Backup check 2024-05-20 (#12)" {
+		t.Fatalf("Unexpected subject %v", fake.tickets[2]["subject"])
+	}
+
+	state, _ := store.Load()
+	if st := state["backup-check"]; st.Counter != 12 || st.LastTicketID != 3 || st.Pending != nil {
+		t.Fatalf("Unexpected state %+v", st)
+	}
+}
+
+func TestRunDueRecoversPending(t *testing.T) {
+	fake := &fakeTickets{}
+	client := fake.server(t)
+	store := NewMemoryStore()
+
+	templates := loadTestTemplates(t)
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	occurrence := time.Date(2024, 5, 6, 9, 0, 0, 0, tokyo)
+
+	// the ticket was created, but the run was interrupted before the state was saved
+	ticket, _ := templates[0].BuildTicket(Occurrence{Template: "backup-check", Time: occurrence, Counter: 10})
+	ticket.ExternalID = ExternalID("backup-check", occurrence)
+	if _, err := client.CreateTicket(ctx(), ticket); err != nil {
+		t.Fatalf("Failed to create ticket: %s", err)
+	}
+	store.Save(State{"backup-check": {LastRun: occurrence.Add(-7 * 24 * time.Hour), Pending: &occurrence}})
+
+	s, _ := New(Config{
+		API:       client,
+		Templates: templates,
+		Store:     store,
+		Now:       func() time.Time { return occurrence.Add(time.Hour) },
+	})
+	report, err := s.RunDue(ctx())
+	if err != nil {
+		t.Fatalf("Failed to run: %s", err)
+	}
+	if len(report.Created) != 1 || !report.Created[0].Recovered || len(fake.tickets) != 1 {
+		t.Fatalf("Pending occurrence should be recovered without creating a ticket: %+v", report)
+	}
+
+	// a failed creation stays pending and is retried
+	fake.fail = true
+	next := occurrence.Add(7 * 24 * time.Hour)
+	s.cfg.Now = func() time.Time { return next }
+	if report, _ := s.RunDue(ctx()); len(report.Failures) != 1 {
+		t.Fatalf("Expected a failure, but got %+v", report)
+	}
+	fake.fail = false
+	report, _ = s.RunDue(ctx())
+	if len(report.Created) != 1 || report.Created[0].Recovered || report.Created[0].Counter != 11 || len(fake.tickets) != 2 {
+		t.Fatalf("Failed occurrence should be retried: %+v", report)
+	}
+}
+
+func ctx() context.Context {
+	return context.Background()
+}
@@ -0,0 +1,129 @@
+package recurring
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// TemplateState is the run state of a template
+type TemplateState struct {
+	// LastRun is the time of the last occurrence which was handled
+	LastRun time.Time `json:"last_run"`
+
+	// Counter is the counter of the last occurrence which was handled
+	Counter int64 `json:"counter"`
+
+	// LastTicketID is the ticket created for the last occurrence
+	LastTicketID int64 `json:"last_ticket_id,omitempty"`
+
+	// Pending is the occurrence whose ticket is being created. It is set before
+	// the ticket is created, so a run interrupted in between looks the ticket up
+	// instead of creating it again.
+	Pending *time.Time `json:"pending,omitempty"`
+}
+
+// State is the run state of the templates keyed by template name
+type State map[string]TemplateState
+
+// Store persists the State between runs of the scheduler
+type Store interface {
+	// Load returns the saved state, or an empty state if nothing was saved yet
+	Load() (State, error)
+
+	// Save replaces the saved state
+	Save(State) error
+}
+
+// MemoryStore is a Store which keeps the state in memory
+type MemoryStore struct {
+	mu    sync.Mutex
+	state State
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{state: State{}}
+}
+
+// Load implements Store
+func (s *MemoryStore) Load() (State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return copyState(s.state), nil
+}
+
+// Save implements Store
+func (s *MemoryStore) Save(state State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state = copyState(state)
+	return nil
+}
+
+func copyState(state State) State {
+	out := make(State, len(state))
+	for name, st := range state {
+		out[name] = st
+	}
+	return out
+}
+
+// FileStore is a Store which keeps the state in a JSON file. The file is
+// replaced atomically, so it is never left half written.
+type FileStore struct {
+	path string
+}
+
+// NewFileStore returns a FileStore of the file at path
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load implements Store
+func (s *FileStore) Load() (State, error) {
+	b, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return State{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := State{}
+	if err := json.Unmarshal(b, &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Save implements Store
+func (s *FileStore) Save(state State) error {
+	b, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,268 @@
+package recurring
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+	"gopkg.in/yaml.v3"
+)
+
+// Template is a recurring ticket. The text of the ticket is rendered with
+// text/template for each occurrence, so it can contain placeholders such as
+//
+//	Backup check for {{ .Time | date "2006-01-02" }} (#{{ .Counter }})
+//
+// The data of the placeholders is an Occurrence. The functions date, addDays
+// and week are available to format the time of the occurrence.
+type Template struct {
+	// Name identifies the template in the state. Required and unique.
+	Name string `yaml:"name"`
+
+	// Schedule is a cron schedule such as "0 9 * * mon". Required.
+	Schedule string `yaml:"schedule"`
+
+	// Timezone is the IANA time zone of the schedule. Defaults to UTC.
+	Timezone string `yaml:"timezone"`
+
+	// Start is the time the schedule starts from on the first run. Defaults to
+	// the first run itself, so occurrences before it are not created.
+	Start time.Time `yaml:"start"`
+
+	// SkipMissed creates only the latest occurrence when several were missed
+	// while the scheduler was not running
+	SkipMissed bool `yaml:"skip_missed"`
+
+	// CounterStart is the counter of the first occurrence. Defaults to 1.
+	CounterStart int64 `yaml:"counter_start"`
+
+	Ticket TicketTemplate `yaml:"ticket"`
+
+	schedule *Schedule
+	location *time.Location
+	texts    map[string]*template.Template
+}
+
+// TicketTemplate is the ticket created by a Template. Subject, Comment, Tags
+// and string values of CustomFields are rendered for each occurrence. Other
+// values of CustomFields are sent as strings, and lists as lists of strings.
+type TicketTemplate struct {
+	Subject        string                `yaml:"subject"`
+	Comment        string                `yaml:"comment"`
+	Public         *bool                 `yaml:"public"`
+	Type           string                `yaml:"type"`
+	Priority       string                `yaml:"priority"`
+	Status         string                `yaml:"status"`
+	RequesterID    int64                 `yaml:"requester_id"`
+	SubmitterID    int64                 `yaml:"submitter_id"`
+	AssigneeID     int64                 `yaml:"assignee_id"`
+	GroupID        int64                 `yaml:"group_id"`
+	OrganizationID int64                 `yaml:"organization_id"`
+	BrandID        int64                 `yaml:"brand_id"`
+	TicketFormID   int64                 `yaml:"ticket_form_id"`
+	Tags           []string              `yaml:"tags"`
+	CustomFields   map[int64]interface{} `yaml:"custom_fields"`
+
+	// DueIn sets the due date of task tickets relative to the occurrence, e.g. "72h"
+	DueIn time.Duration `yaml:"due_in"`
+}
+
+// Occurrence is an occurrence of a Template
+type Occurrence struct {
+	// Template is the name of the template
+	Template string
+
+	// Time is the scheduled time in the time zone of the template
+	Time time.Time
+
+	// Counter is the number of the occurrence, counted from CounterStart
+	Counter int64
+}
+
+var templateFuncs = template.FuncMap{
+	"date": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+	"addDays": func(days int, t time.Time) time.Time {
+		return t.AddDate(0, 0, days)
+	},
+	"week": func(t time.Time) int {
+		_, week := t.ISOWeek()
+		return week
+	},
+}
+
+// LoadTemplates reads templates from a YAML document of the form
+//
+//	templates:
+//	  - name: weekly-backup-check
+//	    schedule: "0 9 * * mon"
+//	    ticket:
+//	      subject: Backup check for week {{ .Time | week }}
+func LoadTemplates(r io.Reader) ([]Template, error) {
+	var doc struct {
+		Templates []Template `yaml:"templates"`
+	}
+
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	for i := range doc.Templates {
+		if err := doc.Templates[i].compile(); err != nil {
+			return nil, err
+		}
+	}
+	return doc.Templates, nil
+}
+
+// LoadTemplateFile reads templates from a YAML file
+func LoadTemplateFile(path string) ([]Template, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadTemplates(f)
+}
+
+// compile parses the schedule, time zone and texts of the template
+func (t *Template) compile() error {
+	if t.Name == "" {
+		return fmt.Errorf("recurring: template name is required")
+	}
+
+	schedule, err := ParseSchedule(t.Schedule)
+	if err != nil {
+		return fmt.Errorf("recurring: template %s: %s", t.Name, err)
+	}
+	t.schedule = schedule
+
+	t.location = time.UTC
+	if t.Timezone != "" {
+		if t.location, err = time.LoadLocation(t.Timezone); err != nil {
+			return fmt.Errorf("recurring: template %s: %s", t.Name, err)
+		}
+	}
+
+	t.texts = map[string]*template.Template{}
+	texts := map[string]string{
+		"subject": t.Ticket.Subject,
+		"comment": t.Ticket.Comment,
+	}
+	for i, tag := range t.Ticket.Tags {
+		texts[fmt.Sprintf("tags.%d", i)] = tag
+	}
+	for id, v := range t.Ticket.CustomFields {
+		if s, ok := v.(string); ok {
+			texts[fmt.Sprintf("custom_fields.%d", id)] = s
+		}
+	}
+	for key, text := range texts {
+		tmpl, err := template.New(key).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return fmt.Errorf("recurring: template %s: %s", t.Name, err)
+		}
+		t.texts[key] = tmpl
+	}
+	return nil
+}
+
+func (t *Template) render(key string, o Occurrence) (string, error) {
+	var buf bytes.Buffer
+	if err := t.texts[key].Execute(&buf, o); err != nil {
+		return "", fmt.Errorf("recurring: template %s: %s", t.Name, err)
+	}
+	return buf.String(), nil
+}
+
+// BuildTicket renders the ticket of an occurrence
+func (t *Template) BuildTicket(o Occurrence) (zendesk.Ticket, error) {
+	if t.texts == nil {
+		if err := t.compile(); err != nil {
+			return zendesk.Ticket{}, err
+		}
+	}
+
+	spec := t.Ticket
+	ticket := zendesk.Ticket{
+		Type:           spec.Type,
+		Priority:       spec.Priority,
+		Status:         spec.Status,
+		RequesterID:    spec.RequesterID,
+		SubmitterID:    spec.SubmitterID,
+		AssigneeID:     spec.AssigneeID,
+		OrganizationID: spec.OrganizationID,
+		BrandID:        spec.BrandID,
+		TicketFormID:   spec.TicketFormID,
+	}
+	if spec.GroupID != 0 {
+		ticket.GroupID = json.Number(strconv.FormatInt(spec.GroupID, 10))
+	}
+	if spec.DueIn > 0 {
+		due := o.Time.Add(spec.DueIn)
+		ticket.DueAt = &due
+	}
+
+	var err error
+	if ticket.Subject, err = t.render("subject", o); err != nil {
+		return zendesk.Ticket{}, err
+	}
+
+	body, err := t.render("comment", o)
+	if err != nil {
+		return zendesk.Ticket{}, err
+	}
+	if body == "" {
+		body = ticket.Subject
+	}
+	ticket.Comment = &zendesk.TicketComment{Body: body, Public: spec.Public}
+
+	for i := range spec.Tags {
+		tag, err := t.render(fmt.Sprintf("tags.%d", i), o)
+		if err != nil {
+			return zendesk.Ticket{}, err
+		}
+		ticket.Tags = append(ticket.Tags, tag)
+	}
+
+	ids := make([]int64, 0, len(spec.CustomFields))
+	for id := range spec.CustomFields {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		var value interface{}
+		switch v := spec.CustomFields[id].(type) {
+		case string:
+			if value, err = t.render(fmt.Sprintf("custom_fields.%d", id), o); err != nil {
+				return zendesk.Ticket{}, err
+			}
+		case bool, nil:
+			value = v
+		case []interface{}:
+			// multi-select fields take a list of option values
+			values := make([]string, len(v))
+			for i, e := range v {
+				values[i] = fmt.Sprint(e)
+			}
+			value = values
+		default:
+			// the API takes numeric and date fields as strings
+			value = fmt.Sprint(v)
+		}
+		ticket.CustomFields = append(ticket.CustomFields, zendesk.CustomField{ID: id, Value: value})
+	}
+
+	return ticket, nil
+}
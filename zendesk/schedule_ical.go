@@ -0,0 +1,745 @@
+package zendesk
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	icalDateLayout     = "20060102"
+	icalDateTimeLayout = "20060102T150405"
+	holidayDateLayout  = "2006-01-02"
+)
+
+// icalWeekAnchor is the Sunday the exported business hours start from, since
+// schedule intervals are counted in minutes from Sunday 00:00
+var icalWeekAnchor = time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// ICalEvent is a VEVENT of an iCalendar feed
+type ICalEvent struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+
+	// AllDay is true when the event has dates without times. End is exclusive.
+	AllDay bool
+
+	// Floating is true when the times of the event have no time zone. They
+	// are parsed in UTC and mean the same wall clock time in every zone.
+	Floating bool
+
+	Status string
+
+	// Recurring is true when the event has an RRULE or RDATE. The occurrences
+	// of yearly rules are listed by ExpandICalEvent.
+	Recurring bool
+
+	// RRule is the value of the RRULE, such as "FREQ=YEARLY;COUNT=5"
+	RRule string
+
+	// ExDates are the excluded occurrences of a recurring event
+	ExDates []time.Time
+}
+
+// ParseICalEvents parses the VEVENTs of an iCalendar feed (RFC 5545). Times with
+// a TZID are parsed in the IANA time zone of the TZID.
+func ParseICalEvents(r io.Reader) ([]ICalEvent, error) {
+	lines, err := unfoldICal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []ICalEvent
+	var event *ICalEvent
+	var hasEnd bool
+	var duration string
+	for n, line := range lines {
+		name, params, value, ok := parseICalLine(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event, hasEnd, duration = &ICalEvent{}, false, ""
+		case event == nil:
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if event.Start.IsZero() {
+				return nil, fmt.Errorf("ical: event %q has no DTSTART", event.Summary)
+			}
+			if !hasEnd {
+				event.End = event.Start
+				if event.AllDay {
+					event.End = event.Start.AddDate(0, 0, 1)
+				}
+				if duration != "" {
+					d, err := parseICalDuration(duration)
+					if err != nil {
+						return nil, fmt.Errorf("ical: line %d: %s", n+1, err)
+					}
+					event.End = event.Start.Add(d)
+				}
+			}
+			events = append(events, *event)
+			event = nil
+		case name == "UID":
+			event.UID = value
+		case name == "SUMMARY":
+			event.Summary = unescapeICalText(value)
+		case name == "STATUS":
+			event.Status = strings.ToUpper(value)
+		case name == "RRULE":
+			event.Recurring, event.RRule = true, value
+		case name == "RDATE":
+			event.Recurring = true
+		case name == "EXDATE":
+			for _, v := range strings.Split(value, ",") {
+				t, _, _, err := parseICalTime(params, v)
+				if err != nil {
+					return nil, fmt.Errorf("ical: line %d: %s", n+1, err)
+				}
+				event.ExDates = append(event.ExDates, t)
+			}
+		case name == "DURATION":
+			duration = value
+		case name == "DTSTART" || name == "DTEND":
+			t, allDay, floating, err := parseICalTime(params, value)
+			if err != nil {
+				return nil, fmt.Errorf("ical: line %d: %s", n+1, err)
+			}
+			if name == "DTSTART" {
+				event.Start, event.AllDay, event.Floating = t, allDay, floating
+			} else {
+				event.End, hasEnd = t, true
+			}
+		}
+	}
+	return events, nil
+}
+
+// unfoldICal reads the content lines of a feed, joining folded lines
+func unfoldICal(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseICalLine splits a content line such as "DTSTART;TZID=Europe/Berlin:20240101T090000"
+func parseICalLine(line string) (string, map[string]string, string, bool) {
+	// the value starts at the first colon which is not in a quoted parameter
+	quoted := false
+	colon := -1
+	for i, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params := map[string]string{}
+	for _, p := range parts[1:] {
+		if kv := strings.SplitN(p, "=", 2); len(kv) == 2 {
+			params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:], true
+}
+
+func parseICalTime(params map[string]string, value string) (t time.Time, allDay, floating bool, err error) {
+	if params["VALUE"] == "DATE" || len(value) == len(icalDateLayout) {
+		t, err = time.Parse(icalDateLayout, value)
+		return t, true, false, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse(icalDateTimeLayout, strings.TrimSuffix(value, "Z"))
+		return t, false, false, err
+	}
+
+	if tzid := params["TZID"]; tzid != "" {
+		loc, err := time.LoadLocation(tzid)
+		if err != nil {
+			return t, false, false, fmt.Errorf("unknown TZID %q", tzid)
+		}
+		t, err = time.ParseInLocation(icalDateTimeLayout, value, loc)
+		return t, false, false, err
+	}
+
+	t, err = time.Parse(icalDateTimeLayout, value)
+	return t, false, true, err
+}
+
+// parseICalDuration parses durations such as "P1D", "PT1H30M" and "P1W"
+func parseICalDuration(s string) (time.Duration, error) {
+	v := strings.TrimPrefix(strings.TrimPrefix(s, "+"), "P")
+	if v == s || v == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	var d time.Duration
+	inTime := false
+	num := 0
+	for _, c := range v {
+		switch {
+		case c >= '0' && c <= '9':
+			num = num*10 + int(c-'0')
+			continue
+		case c == 'T':
+			inTime = true
+			continue
+		case c == 'W' && !inTime:
+			d += time.Duration(num) * 7 * 24 * time.Hour
+		case c == 'D' && !inTime:
+			d += time.Duration(num) * 24 * time.Hour
+		case c == 'H' && inTime:
+			d += time.Duration(num) * time.Hour
+		case c == 'M' && inTime:
+			d += time.Duration(num) * time.Minute
+		case c == 'S' && inTime:
+			d += time.Duration(num) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		num = 0
+	}
+	return d, nil
+}
+
+var icalTextUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, `;`, `\,`, `,`, `\n`, "\n", `\N`, "\n")
+
+var icalTextEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\n", `\n`)
+
+func unescapeICalText(s string) string {
+	return icalTextUnescaper.Replace(s)
+}
+
+// ExpandICalEvent lists the occurrences of a recurring event which overlap
+// [from, until). Only RRULEs with FREQ=YEARLY and optionally INTERVAL, COUNT,
+// UNTIL and the BYMONTH and BYMONTHDAY of DTSTART are supported, and other
+// rules and RDATEs return an error. Events which are not recurring are
+// returned as is when they overlap the window.
+func ExpandICalEvent(e ICalEvent, from, until time.Time) ([]ICalEvent, error) {
+	overlaps := func(o ICalEvent) bool {
+		return o.Start.Before(until) && (o.End.After(from) || o.End.Equal(o.Start) && !o.Start.Before(from))
+	}
+	if !e.Recurring {
+		if overlaps(e) {
+			return []ICalEvent{e}, nil
+		}
+		return nil, nil
+	}
+	if e.RRule == "" {
+		return nil, fmt.Errorf("ical: event %q has an RDATE, which is not supported", e.Summary)
+	}
+
+	interval, count := 1, 0
+	var last time.Time
+	for _, part := range strings.Split(e.RRule, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("ical: invalid RRULE %q", e.RRule)
+		}
+		var err error
+		value := kv[1]
+		switch strings.ToUpper(kv[0]) {
+		case "FREQ":
+			if !strings.EqualFold(value, "YEARLY") {
+				err = fmt.Errorf("FREQ=%s", value)
+			}
+		case "INTERVAL":
+			_, err = fmt.Sscanf(value, "%d", &interval)
+			if err == nil && interval < 1 {
+				err = fmt.Errorf("INTERVAL=%s", value)
+			}
+		case "COUNT":
+			_, err = fmt.Sscanf(value, "%d", &count)
+		case "UNTIL":
+			last, _, _, err = parseICalTime(nil, value)
+		case "BYMONTH":
+			if value != fmt.Sprint(int(e.Start.Month())) {
+				err = fmt.Errorf("BYMONTH=%s", value)
+			}
+		case "BYMONTHDAY":
+			if value != fmt.Sprint(e.Start.Day()) {
+				err = fmt.Errorf("BYMONTHDAY=%s", value)
+			}
+		case "WKST":
+		default:
+			err = fmt.Errorf("%s", part)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ical: RRULE of event %q is not supported: %s", e.Summary, err)
+		}
+	}
+
+	excluded := map[string]bool{}
+	for _, t := range e.ExDates {
+		excluded[t.In(e.Start.Location()).Format(icalDateLayout)] = true
+	}
+
+	var events []ICalEvent
+	for i, n := 0, 0; count == 0 || n < count; i += interval {
+		o := e
+		o.Start, o.End = e.Start.AddDate(i, 0, 0), e.End.AddDate(i, 0, 0)
+		if !o.Start.Before(until) || !last.IsZero() && o.Start.After(last) {
+			break
+		}
+		// February 29 only occurs in leap years
+		if o.Start.Day() != e.Start.Day() {
+			continue
+		}
+		n++
+		if excluded[o.Start.Format(icalDateLayout)] || !overlaps(o) {
+			continue
+		}
+		o.Recurring, o.RRule, o.ExDates = false, "", nil
+		events = append(events, o)
+	}
+	return events, nil
+}
+
+// HolidayFromICalEvent converts an event to a holiday covering the days of the
+// event in loc. Timed events cover every day they overlap in loc, while all-day
+// and floating events keep their dates. loc is required for timed events which
+// are not floating.
+func HolidayFromICalEvent(e ICalEvent, loc *time.Location) (Holiday, error) {
+	start, end := e.Start, e.End
+	switch {
+	case e.AllDay:
+		// DTEND of all-day events is exclusive
+		end = end.AddDate(0, 0, -1)
+	case e.Floating:
+		if end.After(start) {
+			end = end.Add(-time.Nanosecond)
+		}
+	default:
+		if loc == nil {
+			return Holiday{}, fmt.Errorf("time zone is required to convert event %q", e.Summary)
+		}
+		start, end = start.In(loc), end.In(loc)
+		if end.After(start) {
+			end = end.Add(-time.Nanosecond)
+		}
+	}
+	if end.Before(start) {
+		end = start
+	}
+
+	return Holiday{
+		Name:      e.Summary,
+		StartDate: start.Format(holidayDateLayout),
+		EndDate:   end.Format(holidayDateLayout),
+	}, nil
+}
+
+// HolidayChanges are the changes which make the holidays of a schedule match a feed
+type HolidayChanges struct {
+	Create    []Holiday
+	Update    []Holiday
+	Delete    []Holiday
+	Unchanged []Holiday
+
+	// Skipped are the recurring events which could not be expanded. The
+	// holidays named like them are kept.
+	Skipped []ICalEvent
+}
+
+// ReconcileHolidays computes the changes from existing holidays to wanted ones.
+// Holidays with the same name and dates are unchanged. The remaining holidays
+// of the same name are paired in order of their dates and updated, and the
+// rest are created or deleted. Changed holidays are listed in order of their dates.
+func ReconcileHolidays(existing, wanted []Holiday) HolidayChanges {
+	var changes HolidayChanges
+
+	used := make([]bool, len(existing))
+	var rest []Holiday
+	for _, w := range wanted {
+		matched := false
+		for i, h := range existing {
+			if !used[i] && h.Name == w.Name && h.StartDate == w.StartDate && h.EndDate == w.EndDate {
+				used[i], matched = true, true
+				changes.Unchanged = append(changes.Unchanged, h)
+				break
+			}
+		}
+		if !matched {
+			rest = append(rest, w)
+		}
+	}
+
+	byName := map[string][]Holiday{}
+	var left []Holiday
+	for i, h := range existing {
+		if !used[i] {
+			left = append(left, h)
+		}
+	}
+	sortHolidays(left)
+	for _, h := range left {
+		byName[h.Name] = append(byName[h.Name], h)
+	}
+
+	sortHolidays(rest)
+	for _, w := range rest {
+		if hs := byName[w.Name]; len(hs) > 0 {
+			w.ID = hs[0].ID
+			changes.Update = append(changes.Update, w)
+			byName[w.Name] = hs[1:]
+			continue
+		}
+		changes.Create = append(changes.Create, w)
+	}
+
+	for _, h := range left {
+		if hs := byName[h.Name]; len(hs) > 0 && hs[0].ID == h.ID {
+			changes.Delete = append(changes.Delete, h)
+			byName[h.Name] = hs[1:]
+		}
+	}
+	return changes
+}
+
+func sortHolidays(hs []Holiday) {
+	sort.SliceStable(hs, func(i, j int) bool {
+		if hs[i].StartDate != hs[j].StartDate {
+			return hs[i].StartDate < hs[j].StartDate
+		}
+		return hs[i].EndDate < hs[j].EndDate
+	})
+}
+
+// HolidayImportOptions is options of ImportScheduleHolidays
+type HolidayImportOptions struct {
+	// Location converts timed events to dates. Defaults to the time zone of the
+	// schedule, which must then be an IANA time zone such as "Europe/Berlin".
+	Location *time.Location
+
+	// KeepMissing keeps the holidays which are not in the feed instead of deleting them
+	KeepMissing bool
+
+	// RecurFrom and RecurUntil are the window in which the occurrences of
+	// yearly events are imported. They default to the year from now.
+	RecurFrom  time.Time
+	RecurUntil time.Time
+
+	// DryRun only computes the changes without applying them
+	DryRun bool
+}
+
+// ImportScheduleHolidays reconciles the holidays of a schedule with the events
+// of an iCalendar feed. Cancelled events are skipped, and yearly events are
+// expanded over the recurrence window. Other recurring events are reported in
+// Skipped, and the holidays of their names are not deleted. It returns the
+// applied changes, where created holidays have their new IDs.
+func ImportScheduleHolidays(ctx context.Context, api API, scheduleID int64, feed io.Reader, opts HolidayImportOptions) (HolidayChanges, error) {
+	events, err := ParseICalEvents(feed)
+	if err != nil {
+		return HolidayChanges{}, err
+	}
+
+	schedule, err := api.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		return HolidayChanges{}, err
+	}
+	loc := opts.Location
+	if loc == nil {
+		loc, _ = time.LoadLocation(schedule.TimeZone)
+	}
+
+	from, until := opts.RecurFrom, opts.RecurUntil
+	if from.IsZero() {
+		from = time.Now()
+	}
+	if until.IsZero() {
+		until = from.AddDate(1, 0, 0)
+	}
+
+	var wanted []Holiday
+	var skipped []ICalEvent
+	for _, e := range events {
+		if e.Status == "CANCELLED" {
+			continue
+		}
+		occurrences := []ICalEvent{e}
+		if e.Recurring {
+			if occurrences, err = ExpandICalEvent(e, from, until); err != nil {
+				skipped = append(skipped, e)
+				continue
+			}
+		}
+		for _, o := range occurrences {
+			h, err := HolidayFromICalEvent(o, loc)
+			if err != nil {
+				return HolidayChanges{}, fmt.Errorf("%s: schedule time zone %q is not an IANA time zone, set Location", err, schedule.TimeZone)
+			}
+			wanted = append(wanted, h)
+		}
+	}
+
+	existing, err := api.GetHolidays(ctx, scheduleID)
+	if err != nil {
+		return HolidayChanges{}, err
+	}
+
+	changes := ReconcileHolidays(existing, wanted)
+	changes.Skipped = skipped
+	if opts.KeepMissing {
+		changes.Unchanged = append(changes.Unchanged, changes.Delete...)
+		changes.Delete = nil
+	} else if len(skipped) > 0 {
+		// the holidays of skipped events are not known to be missing
+		names := map[string]bool{}
+		for _, e := range skipped {
+			names[e.Summary] = true
+		}
+		var deleted []Holiday
+		for _, h := range changes.Delete {
+			if names[h.Name] {
+				changes.Unchanged = append(changes.Unchanged, h)
+				continue
+			}
+			deleted = append(deleted, h)
+		}
+		changes.Delete = deleted
+	}
+	if opts.DryRun {
+		return changes, nil
+	}
+
+	for i, h := range changes.Create {
+		created, err := api.CreateHoliday(ctx, scheduleID, h)
+		if err != nil {
+			return changes, err
+		}
+		changes.Create[i] = created
+	}
+	for _, h := range changes.Update {
+		if _, err := api.UpdateHoliday(ctx, scheduleID, h.ID, h); err != nil {
+			return changes, err
+		}
+	}
+	for _, h := range changes.Delete {
+		if err := api.DeleteHoliday(ctx, scheduleID, h.ID); err != nil {
+			return changes, err
+		}
+	}
+	return changes, nil
+}
+
+// ExportScheduleICal writes a schedule and its holidays as an iCalendar feed.
+// loc is the IANA time zone of the schedule, and defaults to the schedule's.
+func ExportScheduleICal(ctx context.Context, api API, scheduleID int64, w io.Writer, loc *time.Location) error {
+	schedule, err := api.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	holidays, err := api.GetHolidays(ctx, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	if loc == nil {
+		loc, _ = time.LoadLocation(schedule.TimeZone)
+	}
+	return WriteScheduleICal(w, schedule, holidays, loc)
+}
+
+// WriteScheduleICal writes the holidays as all-day events, and the business
+// hours of the schedule as weekly events in loc, which is described by a
+// VTIMEZONE. Business hours are written in floating time when loc is nil.
+func WriteScheduleICal(w io.Writer, schedule Schedule, holidays []Holiday, loc *time.Location) error {
+	stamp := schedule.UpdatedAt
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	dtstamp := stamp.UTC().Format(icalDateTimeLayout) + "Z"
+
+	var lines []string
+	add := func(format string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+
+	add("BEGIN:VCALENDAR")
+	add("VERSION:2.0")
+	add("PRODID:-//nukosuke//go-zendesk//EN")
+	add("CALSCALE:GREGORIAN")
+	add("X-WR-CALNAME:%s", icalTextEscaper.Replace(schedule.Name))
+	if loc != nil {
+		add("X-WR-TIMEZONE:%s", loc.String())
+		lines = append(lines, icalTimeZone(loc, icalWeekAnchor.Year()-1)...)
+	}
+
+	for _, h := range holidays {
+		start, err := time.Parse(holidayDateLayout, h.StartDate)
+		if err != nil {
+			return err
+		}
+		end, err := time.Parse(holidayDateLayout, h.EndDate)
+		if err != nil {
+			return err
+		}
+
+		add("BEGIN:VEVENT")
+		add("UID:schedule-%d-holiday-%d@zendesk", schedule.ID, h.ID)
+		add("DTSTAMP:%s", dtstamp)
+		add("SUMMARY:%s", icalTextEscaper.Replace(h.Name))
+		add("DTSTART;VALUE=DATE:%s", start.Format(icalDateLayout))
+		add("DTEND;VALUE=DATE:%s", end.AddDate(0, 0, 1).Format(icalDateLayout))
+		add("TRANSP:TRANSPARENT")
+		add("END:VEVENT")
+	}
+
+	tz := ""
+	if loc != nil {
+		tz = ";TZID=" + loc.String()
+	}
+	for i, interval := range schedule.Intervals {
+		start := icalWeekAnchor.Add(time.Duration(interval.StartTime) * time.Minute)
+		end := icalWeekAnchor.Add(time.Duration(interval.EndTime) * time.Minute)
+
+		add("BEGIN:VEVENT")
+		add("UID:schedule-%d-interval-%d@zendesk", schedule.ID, i)
+		add("DTSTAMP:%s", dtstamp)
+		add("SUMMARY:%s", icalTextEscaper.Replace(schedule.Name+" business hours"))
+		add("DTSTART%s:%s", tz, start.Format(icalDateTimeLayout))
+		add("DTEND%s:%s", tz, end.Format(icalDateTimeLayout))
+		add("RRULE:FREQ=WEEKLY")
+		add("END:VEVENT")
+	}
+	add("END:VCALENDAR")
+
+	bw := bufio.NewWriter(w)
+	for _, line := range lines {
+		if _, err := bw.WriteString(foldICalLine(line) + "\r\n"); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// icalTimeZone describes loc as a VTIMEZONE with the offsets of year. Changes
+// of the offset repeat yearly on the same weekday of the month, so that the
+// zone also covers the weeks of the business hours after year.
+func icalTimeZone(loc *time.Location, year int) []string {
+	lines := []string{"BEGIN:VTIMEZONE", "TZID:" + loc.String()}
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	transitions := zoneTransitions(loc, start, start.AddDate(1, 0, 0))
+	if len(transitions) == 0 {
+		name, offset := start.In(loc).Zone()
+		return append(lines,
+			"BEGIN:STANDARD",
+			"DTSTART:19700101T000000",
+			"TZOFFSETFROM:"+icalOffset(offset),
+			"TZOFFSETTO:"+icalOffset(offset),
+			"TZNAME:"+name,
+			"END:STANDARD",
+			"END:VTIMEZONE",
+		)
+	}
+
+	for _, t := range transitions {
+		_, from := t.Add(-time.Second).In(loc).Zone()
+		name, to := t.In(loc).Zone()
+		kind := "STANDARD"
+		if t.In(loc).IsDST() {
+			kind = "DAYLIGHT"
+		}
+
+		// the onset is the local time before the change
+		onset := t.Add(time.Duration(from) * time.Second).UTC()
+		week := fmt.Sprint((onset.Day()-1)/7 + 1)
+		if onset.AddDate(0, 0, 7).Month() != onset.Month() {
+			week = "-1"
+		}
+		day := strings.ToUpper(onset.Weekday().String()[:2])
+
+		lines = append(lines,
+			"BEGIN:"+kind,
+			"DTSTART:"+onset.Format(icalDateTimeLayout),
+			fmt.Sprintf("RRULE:FREQ=YEARLY;BYMONTH=%d;BYDAY=%s%s", int(onset.Month()), week, day),
+			"TZOFFSETFROM:"+icalOffset(from),
+			"TZOFFSETTO:"+icalOffset(to),
+			"TZNAME:"+name,
+			"END:"+kind,
+		)
+	}
+	return append(lines, "END:VTIMEZONE")
+}
+
+// zoneTransitions lists the instants in [start, end) at which the offset of loc changes
+func zoneTransitions(loc *time.Location, start, end time.Time) []time.Time {
+	var transitions []time.Time
+	for t := start; t.Before(end); t = t.Add(24 * time.Hour) {
+		next := t.Add(24 * time.Hour)
+		_, before := t.In(loc).Zone()
+		if _, after := next.In(loc).Zone(); before == after {
+			continue
+		}
+
+		// find the first second with the new offset
+		lo, hi := t, next
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+			if _, offset := mid.In(loc).Zone(); offset == before {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		transitions = append(transitions, hi)
+	}
+	return transitions
+}
+
+// icalOffset formats an offset in seconds as in "+0100"
+func icalOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	s := fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset/60%60)
+	if offset%60 != 0 {
+		s += fmt.Sprintf("%02d", offset%60)
+	}
+	return s
+}
+
+// foldICalLine folds a content line into lines of at most 75 octets without
+// splitting UTF-8 characters
+func foldICalLine(line string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range line {
+		size := len(string(r))
+		if n+size > 75 {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	return b.String()
+}
@@ -0,0 +1,387 @@
+package zendesk
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const holidayFeed = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:new-year\r\n" +
+	"SUMMARY:New Year's Day\r\n" +
+	"DTSTART;VALUE=DATE:20250101\r\n" +
+	"DTEND;VALUE=DATE:20250102\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:christmas\r\n" +
+	"SUMMARY:Christmas\r\n" +
+	"DTSTART;VALUE=DATE:20251224\r\n" +
+	"DURATION:P4D\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:summer-party\r\n" +
+	"SUMMARY:Summer party\\, \r\n" +
+	" offices closed\r\n" +
+	"DTSTART:20250704T220000Z\r\n" +
+	"DTEND:20250704T230000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:cancelled\r\n" +
+	"SUMMARY:Cancelled\r\n" +
+	"STATUS:CANCELLED\r\n" +
+	"DTSTART;VALUE=DATE:20250301\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:standup\r\n" +
+	"SUMMARY:Standup\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20250106T093000\r\n" +
+	"DTEND;TZID=Europe/Berlin:20250106T094500\r\n" +
+	"RRULE:FREQ=DAILY\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICalEvents(t *testing.T) {
+	events, err := ParseICalEvents(strings.NewReader(holidayFeed))
+	if err != nil {
+		t.Fatalf("Failed to parse feed: %s", err)
+	}
+	if len(events) != 5 {
+		t.Fatalf("expected length of events is 5, but got %d", len(events))
+	}
+
+	christmas := events[1]
+	if !christmas.AllDay || !christmas.End.Equal(time.Date(2025, 12, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Event with a duration does not have the expected end %v", christmas)
+	}
+	if party := events[2]; party.Summary != "Summer party, offices closed" || party.AllDay || party.Floating {
+		t.Fatalf("Folded event is not parsed as expected %v", party)
+	}
+	if events[3].Status != "CANCELLED" || !events[4].Recurring {
+		t.Fatalf("Status or recurrence is not parsed %v %v", events[3], events[4])
+	}
+	if _, offset := events[4].Start.Zone(); offset != 3600 {
+		t.Fatalf("Event with TZID should be in its time zone, but got offset %d", offset)
+	}
+}
+
+func TestExpandICalEvent(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	starts := func(events []ICalEvent) []string {
+		var s []string
+		for _, e := range events {
+			s = append(s, e.Start.Format(holidayDateLayout))
+		}
+		return s
+	}
+
+	tests := []struct {
+		rrule   string
+		start   time.Time
+		exdates []time.Time
+		want    []string
+	}{
+		{"FREQ=YEARLY", day(2020, 12, 25), nil, []string{"2024-12-25", "2025-12-25", "2026-12-25"}},
+		{"FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=25", day(2020, 12, 25), []time.Time{day(2025, 12, 25)}, []string{"2024-12-25", "2026-12-25"}},
+		{"FREQ=YEARLY;INTERVAL=2", day(2020, 12, 25), nil, []string{"2024-12-25", "2026-12-25"}},
+		{"FREQ=YEARLY;COUNT=6", day(2020, 12, 25), nil, []string{"2024-12-25", "2025-12-25"}},
+		{"FREQ=YEARLY;UNTIL=20251225", day(2020, 12, 25), nil, []string{"2024-12-25", "2025-12-25"}},
+		{"FREQ=YEARLY", day(2020, 2, 29), nil, []string{"2024-02-29"}},
+	}
+	for _, test := range tests {
+		e := ICalEvent{Summary: test.rrule, AllDay: true, Start: test.start, End: test.start.AddDate(0, 0, 1), Recurring: true, RRule: test.rrule, ExDates: test.exdates}
+		events, err := ExpandICalEvent(e, day(2024, 1, 1), day(2027, 1, 1))
+		if err != nil {
+			t.Fatalf("Failed to expand %s: %s", test.rrule, err)
+		}
+		if got := starts(events); !reflect.DeepEqual(got, test.want) {
+			t.Fatalf("expected occurrences of %s are %v, but got %v", test.rrule, test.want, got)
+		}
+		if events[0].Recurring || !events[0].End.Equal(events[0].Start.AddDate(0, 0, 1)) {
+			t.Fatalf("Unexpected occurrence %v", events[0])
+		}
+	}
+
+	for _, rrule := range []string{"FREQ=WEEKLY", "FREQ=YEARLY;BYDAY=1MO", "FREQ=YEARLY;BYMONTH=1"} {
+		e := ICalEvent{Summary: rrule, AllDay: true, Start: day(2020, 12, 25), End: day(2020, 12, 26), Recurring: true, RRule: rrule}
+		if _, err := ExpandICalEvent(e, day(2024, 1, 1), day(2027, 1, 1)); err == nil {
+			t.Fatalf("Expanding %s should fail", rrule)
+		}
+	}
+}
+
+func TestHolidayFromICalEvent(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+
+	tests := []struct {
+		event ICalEvent
+		want  Holiday
+	}{
+		{
+			ICalEvent{Summary: "one day", AllDay: true, Start: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+			Holiday{Name: "one day", StartDate: "2025-01-01", EndDate: "2025-01-01"},
+		},
+		{
+			ICalEvent{Summary: "late utc", Start: time.Date(2025, 7, 4, 22, 0, 0, 0, time.UTC), End: time.Date(2025, 7, 4, 23, 0, 0, 0, time.UTC)},
+			Holiday{Name: "late utc", StartDate: "2025-07-05", EndDate: "2025-07-05"},
+		},
+		{
+			ICalEvent{Summary: "until midnight", Floating: true, Start: time.Date(2025, 7, 4, 9, 0, 0, 0, time.UTC), End: time.Date(2025, 7, 6, 0, 0, 0, 0, time.UTC)},
+			Holiday{Name: "until midnight", StartDate: "2025-07-04", EndDate: "2025-07-05"},
+		},
+	}
+	for _, test := range tests {
+		got, err := HolidayFromICalEvent(test.event, berlin)
+		if err != nil {
+			t.Fatalf("Failed to convert event %s: %s", test.event.Summary, err)
+		}
+		if got != test.want {
+			t.Fatalf("expected holiday %v, but got %v", test.want, got)
+		}
+	}
+
+	if _, err := HolidayFromICalEvent(tests[1].event, nil); err == nil {
+		t.Fatal("Timed event without time zone should fail")
+	}
+}
+
+func TestReconcileHolidays(t *testing.T) {
+	existing := []Holiday{
+		{ID: 1, Name: "Bank holiday", StartDate: "2025-05-05", EndDate: "2025-05-05"},
+		{ID: 2, Name: "Bank holiday", StartDate: "2025-08-25", EndDate: "2025-08-25"},
+		{ID: 3, Name: "Bank holiday", StartDate: "2025-12-26", EndDate: "2025-12-26"},
+		{ID: 4, Name: "Retreat", StartDate: "2025-06-10", EndDate: "2025-06-12"},
+	}
+	wanted := []Holiday{
+		{Name: "Bank holiday", StartDate: "2025-08-25", EndDate: "2025-08-25"},
+		{Name: "Bank holiday", StartDate: "2025-05-26", EndDate: "2025-05-26"},
+		{Name: "Founders day", StartDate: "2025-03-01", EndDate: "2025-03-01"},
+	}
+
+	changes := ReconcileHolidays(existing, wanted)
+	if len(changes.Unchanged) != 1 || changes.Unchanged[0].ID != 2 {
+		t.Fatalf("Unexpected unchanged holidays %v", changes.Unchanged)
+	}
+	if len(changes.Update) != 1 || changes.Update[0].ID != 1 || changes.Update[0].StartDate != "2025-05-26" {
+		t.Fatalf("Unexpected updated holidays %v", changes.Update)
+	}
+	if len(changes.Create) != 1 || changes.Create[0].Name != "Founders day" {
+		t.Fatalf("Unexpected created holidays %v", changes.Create)
+	}
+	if len(changes.Delete) != 2 || changes.Delete[0].ID != 4 || changes.Delete[1].ID != 3 {
+		t.Fatalf("Unexpected deleted holidays %v", changes.Delete)
+	}
+}
+
+func TestImportScheduleHolidays(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Berlin"); err != nil {
+		t.Skip("time zone database is not available")
+	}
+
+	var requests []string
+	var updated, created Holiday
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var data struct {
+			Holiday Holiday `json:"holiday"`
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/business_hours/schedules/31.json":
+			w.Write(readFixture("GET/schedule.json"))
+		case r.Method == http.MethodGet && r.URL.Path == "/business_hours/schedules/31/holidays.json":
+			w.Write(readFixture("GET/holidays.json"))
+		case r.Method == http.MethodPut && r.URL.Path == "/business_hours/schedules/31/holidays/2.json":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &data)
+			updated = data.Holiday
+			w.Write(body)
+		case r.Method == http.MethodPost && r.URL.Path == "/business_hours/schedules/31/holidays.json":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &data)
+			created = data.Holiday
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"holiday": {"id": 4, "name": "Summer party, offices closed", "start_date": "2025-07-05", "end_date": "2025-07-05"}}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/business_hours/schedules/31/holidays/3.json":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	changes, err := ImportScheduleHolidays(ctx, client, 31, strings.NewReader(holidayFeed), HolidayImportOptions{})
+	if err != nil {
+		t.Fatalf("Failed to import holidays: %s", err)
+	}
+
+	if len(changes.Unchanged) != 1 || changes.Unchanged[0].ID != 1 {
+		t.Fatalf("Unexpected unchanged holidays %v", changes.Unchanged)
+	}
+	if updated.EndDate != "2025-12-27" || len(changes.Update) != 1 {
+		t.Fatalf("Christmas should be updated to end on 2025-12-27, but got %v", updated)
+	}
+	// the party starts at 22:00 UTC, which is the next day in Berlin
+	if created.StartDate != "2025-07-05" || len(changes.Create) != 1 || changes.Create[0].ID != 4 {
+		t.Fatalf("Unexpected created holiday %v", created)
+	}
+	if len(changes.Delete) != 1 || changes.Delete[0].ID != 3 {
+		t.Fatalf("Unexpected deleted holidays %v", changes.Delete)
+	}
+
+	requests = nil
+	if _, err := ImportScheduleHolidays(ctx, client, 31, strings.NewReader(holidayFeed), HolidayImportOptions{DryRun: true}); err != nil {
+		t.Fatalf("Failed to import holidays: %s", err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("Dry run should only read the schedule and holidays, but sent %v", requests)
+	}
+}
+
+func TestImportScheduleHolidaysRecurring(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Berlin"); err != nil {
+		t.Skip("time zone database is not available")
+	}
+
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/business_hours/schedules/31.json":
+			w.Write(readFixture("GET/schedule.json"))
+		case r.Method == http.MethodGet && r.URL.Path == "/business_hours/schedules/31/holidays.json":
+			w.Write(readFixture("GET/holidays.json"))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	feed := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:New Year's Day\r\n" +
+		"DTSTART;VALUE=DATE:20200101\r\n" +
+		"RRULE:FREQ=YEARLY\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Christmas\r\n" +
+		"DTSTART;VALUE=DATE:20201224\r\n" +
+		"DTEND;VALUE=DATE:20201227\r\n" +
+		"RRULE:FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=24\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Company Retreat\r\n" +
+		"DTSTART;VALUE=DATE:20200610\r\n" +
+		"RRULE:FREQ=MONTHLY;BYDAY=2TU\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	changes, err := ImportScheduleHolidays(ctx, client, 31, strings.NewReader(feed), HolidayImportOptions{
+		DryRun:     true,
+		RecurFrom:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		RecurUntil: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Failed to import holidays: %s", err)
+	}
+
+	// the yearly holidays occur once in 2025, and the retreat is kept
+	if len(changes.Unchanged) != 3 || len(changes.Create) != 0 || len(changes.Update) != 0 || len(changes.Delete) != 0 {
+		t.Fatalf("Recurring holidays should be unchanged %v", changes)
+	}
+	if len(changes.Skipped) != 1 || changes.Skipped[0].Summary != "Company Retreat" {
+		t.Fatalf("The monthly retreat should be skipped %v", changes.Skipped)
+	}
+}
+
+func TestExportScheduleICal(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/business_hours/schedules/31.json":
+			w.Write(readFixture("GET/schedule.json"))
+		case "/business_hours/schedules/31/holidays.json":
+			w.Write(readFixture("GET/holidays.json"))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	var buf bytes.Buffer
+	if err := ExportScheduleICal(ctx, client, 31, &buf, nil); err != nil {
+		t.Fatalf("Failed to export schedule: %s", err)
+	}
+
+	out := buf.String()
+	for _, line := range []string{
+		"X-WR-CALNAME:Berlin Support\r\n",
+		"BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\n",
+		"BEGIN:DAYLIGHT\r\nDTSTART:20220327T020000\r\nRRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\nTZNAME:CEST\r\nEND:DAYLIGHT\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20221030T030000\r\nRRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU\r\nTZOFFSETFROM:+0200\r\nTZOFFSETTO:+0100\r\nTZNAME:CET\r\nEND:STANDARD\r\n",
+		"DTSTART;VALUE=DATE:20251224\r\nDTEND;VALUE=DATE:20251227\r\n",
+		"DTSTART;TZID=Europe/Berlin:20230102T090000\r\nDTEND;TZID=Europe/Berlin:20230102T170000\r\nRRULE:FREQ=WEEKLY\r\n",
+	} {
+		if !strings.Contains(out, line) {
+			t.Fatalf("Exported feed does not contain %q:\n%s", line, out)
+		}
+	}
+
+	// the holidays survive a round trip
+	events, err := ParseICalEvents(&buf)
+	if err != nil {
+		t.Fatalf("Failed to parse exported feed: %s", err)
+	}
+	var holidays []Holiday
+	for _, e := range events {
+		if e.Recurring {
+			continue
+		}
+		h, err := HolidayFromICalEvent(e, berlin)
+		if err != nil {
+			t.Fatalf("Failed to convert event: %s", err)
+		}
+		holidays = append(holidays, h)
+	}
+	changes := ReconcileHolidays([]Holiday{
+		{ID: 1, Name: "New Year's Day", StartDate: "2025-01-01", EndDate: "2025-01-01"},
+		{ID: 2, Name: "Christmas", StartDate: "2025-12-24", EndDate: "2025-12-26"},
+		{ID: 3, Name: "Company Retreat", StartDate: "2025-06-10", EndDate: "2025-06-12"},
+	}, holidays)
+	if len(changes.Unchanged) != 3 || !reflect.DeepEqual(changes, HolidayChanges{Unchanged: changes.Unchanged}) {
+		t.Fatalf("Exported holidays should be unchanged after a round trip %v", changes)
+	}
+}
+
+func TestFoldICalLine(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("あ", 40)
+	for _, l := range strings.Split(foldICalLine(line), "\r\n") {
+		if len(l) > 75 {
+			t.Fatalf("Folded line is longer than 75 octets: %d", len(l))
+		}
+	}
+
+	lines, err := unfoldICal(strings.NewReader(foldICalLine(line) + "\r\n"))
+	if err != nil || len(lines) != 1 || lines[0] != line {
+		t.Fatalf("Folded line does not unfold to the original %v %s", lines, err)
+	}
+}
@@ -0,0 +1,175 @@
+package zendesk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetSchedules(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "schedules.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	schedules, err := client.GetSchedules(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get schedules: %s", err)
+	}
+
+	if len(schedules) != 2 {
+		t.Fatalf("expected length of schedules is 2, but got %d", len(schedules))
+	}
+	if len(schedules[0].Intervals) != 2 {
+		t.Fatalf("expected length of intervals is 2, but got %d", len(schedules[0].Intervals))
+	}
+}
+
+func TestGetSchedule(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "schedule.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	schedule, err := client.GetSchedule(ctx, 31)
+	if err != nil {
+		t.Fatalf("Failed to get schedule: %s", err)
+	}
+
+	if schedule.ID != 31 || schedule.TimeZone != "Europe/Berlin" {
+		t.Fatalf("Returned schedule does not have the expected ID or time zone %v", schedule)
+	}
+}
+
+func TestCreateSchedule(t *testing.T) {
+	mockAPI := newMockAPIWithStatus(http.MethodPost, "schedule.json", http.StatusCreated)
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	schedule, err := client.CreateSchedule(ctx, Schedule{Name: "Berlin Support", TimeZone: "Europe/Berlin"})
+	if err != nil {
+		t.Fatalf("Failed to create schedule: %s", err)
+	}
+
+	if schedule.ID == 0 {
+		t.Fatal("Created schedule should have an ID")
+	}
+}
+
+func TestUpdateSchedule(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodPut, "schedule.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	schedule, err := client.UpdateSchedule(ctx, 31, Schedule{Name: "Berlin Support"})
+	if err != nil {
+		t.Fatalf("Failed to update schedule: %s", err)
+	}
+
+	if schedule.Name != "Berlin Support" {
+		t.Fatalf("Updated schedule does not have the expected name %s", schedule.Name)
+	}
+}
+
+func TestUpdateScheduleWorkweek(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodPut, "schedule_workweek.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	intervals, err := client.UpdateScheduleWorkweek(ctx, 31, []ScheduleInterval{{StartTime: 1980, EndTime: 2460}})
+	if err != nil {
+		t.Fatalf("Failed to update workweek: %s", err)
+	}
+
+	if len(intervals) != 2 || intervals[1].EndTime != 3900 {
+		t.Fatalf("Returned intervals are not the expected ones %v", intervals)
+	}
+}
+
+func TestDeleteSchedule(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/business_hours/schedules/31.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	if err := client.DeleteSchedule(ctx, 31); err != nil {
+		t.Fatalf("Failed to delete schedule: %s", err)
+	}
+}
+
+func TestGetHolidays(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "holidays.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	holidays, err := client.GetHolidays(ctx, 31)
+	if err != nil {
+		t.Fatalf("Failed to get holidays: %s", err)
+	}
+
+	if len(holidays) != 3 {
+		t.Fatalf("expected length of holidays is 3, but got %d", len(holidays))
+	}
+}
+
+func TestGetHoliday(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "holiday.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	holiday, err := client.GetHoliday(ctx, 31, 2)
+	if err != nil {
+		t.Fatalf("Failed to get holiday: %s", err)
+	}
+
+	if holiday.StartDate != "2025-12-24" || holiday.EndDate != "2025-12-26" {
+		t.Fatalf("Returned holiday does not have the expected dates %v", holiday)
+	}
+}
+
+func TestCreateHoliday(t *testing.T) {
+	mockAPI := newMockAPIWithStatus(http.MethodPost, "holiday.json", http.StatusCreated)
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	holiday, err := client.CreateHoliday(ctx, 31, Holiday{Name: "Christmas", StartDate: "2025-12-24", EndDate: "2025-12-26"})
+	if err != nil {
+		t.Fatalf("Failed to create holiday: %s", err)
+	}
+
+	if holiday.ID == 0 {
+		t.Fatal("Created holiday should have an ID")
+	}
+}
+
+func TestUpdateHoliday(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodPut, "holiday.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	holiday, err := client.UpdateHoliday(ctx, 31, 2, Holiday{Name: "Christmas"})
+	if err != nil {
+		t.Fatalf("Failed to update holiday: %s", err)
+	}
+
+	if holiday.ID != 2 {
+		t.Fatalf("Updated holiday does not have the expected ID %d", holiday.ID)
+	}
+}
+
+func TestDeleteHoliday(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/business_hours/schedules/31/holidays/2.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	if err := client.DeleteHoliday(ctx, 31, 2); err != nil {
+		t.Fatalf("Failed to delete holiday: %s", err)
+	}
+}
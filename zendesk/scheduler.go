@@ -0,0 +1,256 @@
+package zendesk
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Priority is the class of a request used by the client scheduler
+type Priority int
+
+const (
+	// PriorityLow is for batch work which only uses leftover rate limit budget
+	PriorityLow Priority = iota
+	// PriorityNormal is the default priority of requests
+	PriorityNormal
+	// PriorityHigh is for interactive traffic which goes before everything else
+	PriorityHigh
+)
+
+type priorityContextKey struct{}
+
+// WithPriority returns a copy of ctx which carries the request priority.
+// Requests sent with the returned context are scheduled by that priority.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityContextKey{}, p)
+}
+
+// PriorityFromContext returns the request priority carried by ctx.
+// It returns PriorityNormal if ctx has no priority.
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityContextKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityNormal
+}
+
+// SchedulerConfig is configuration of the client request scheduler
+type SchedulerConfig struct {
+	// RequestsPerMinute is the rate limit budget of the account
+	RequestsPerMinute int
+
+	// Burst is the number of requests which can be sent at once. Defaults to 1.
+	Burst int
+
+	// LowPriorityReserve is the number of requests in the budget which low priority
+	// requests leave for normal and high priority ones.
+	LowPriorityReserve int
+}
+
+// SetScheduler enables priority aware scheduling of requests. Requests wait for rate limit
+// budget, and waiting requests are sent in order of the priority carried by their context.
+// Low priority requests are only sent while more than LowPriorityReserve of the budget remains.
+func (z *Client) SetScheduler(cfg SchedulerConfig) {
+	z.scheduler = newScheduler(cfg)
+}
+
+// scheduler is a token bucket which grants tokens to waiting requests by priority
+type scheduler struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	reserve  float64
+	tokens   float64
+	last     time.Time
+	paused   time.Time
+	waiters  [PriorityHigh + 1][]chan struct{}
+	timer    *time.Timer
+	timerAt  time.Time
+	now      func() time.Time
+}
+
+func newScheduler(cfg SchedulerConfig) *scheduler {
+	burst := cfg.Burst
+	if burst < 1 {
+		burst = 1
+	}
+	perMinute := cfg.RequestsPerMinute
+	if perMinute < 1 {
+		perMinute = 1
+	}
+
+	return &scheduler{
+		interval: time.Minute / time.Duration(perMinute),
+		burst:    float64(burst),
+		reserve:  float64(cfg.LowPriorityReserve),
+		tokens:   float64(burst),
+		now:      time.Now,
+	}
+}
+
+// acquire blocks until the request may be sent
+func (s *scheduler) acquire(ctx context.Context) error {
+	p := PriorityFromContext(ctx)
+	if p < PriorityLow {
+		p = PriorityLow
+	} else if p > PriorityHigh {
+		p = PriorityHigh
+	}
+
+	s.mu.Lock()
+	now := s.now()
+	s.refillLocked(now)
+	if !s.waitingLocked(p) && s.availableLocked(p, now) {
+		s.tokens--
+		s.mu.Unlock()
+		return nil
+	}
+
+	ch := make(chan struct{})
+	s.waiters[p] = append(s.waiters[p], ch)
+	s.scheduleLocked(now)
+	s.mu.Unlock()
+
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !s.removeLocked(p, ch) {
+			// granted while canceling. give the token back to others
+			s.tokens = math.Min(s.burst, s.tokens+1)
+			s.dispatchLocked(s.now())
+		}
+		return ctx.Err()
+	}
+}
+
+// observe adjusts the scheduler by the rate limit information in the response
+func (s *scheduler) observe(resp *http.Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if err != nil || retryAfter < 1 {
+			retryAfter = 1
+		}
+		// a single request may probe the API after the pause,
+		// and the budget is accrued again from then
+		s.paused = now.Add(time.Duration(retryAfter) * time.Second)
+		s.tokens = 1
+		s.last = s.paused
+		s.scheduleLocked(now)
+		return
+	}
+
+	// other clients may share the budget of the account
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-Rate-Limit-Remaining")); err == nil {
+		if float64(remaining) < s.tokens {
+			s.tokens = float64(remaining)
+		}
+	}
+}
+
+// refillLocked adds the tokens accrued since the last refill
+func (s *scheduler) refillLocked(now time.Time) {
+	if s.last.IsZero() {
+		s.last = now
+		return
+	}
+	if now.After(s.last) {
+		s.tokens = math.Min(s.burst, s.tokens+float64(now.Sub(s.last))/float64(s.interval))
+		s.last = now
+	}
+}
+
+// waitingLocked checks if requests of priority p or higher are waiting
+func (s *scheduler) waitingLocked(p Priority) bool {
+	for q := p; q <= PriorityHigh; q++ {
+		if len(s.waiters[q]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// needLocked returns the number of tokens required to send a request of priority p
+func (s *scheduler) needLocked(p Priority) float64 {
+	if p == PriorityLow {
+		return 1 + math.Min(s.reserve, s.burst-1)
+	}
+	return 1
+}
+
+func (s *scheduler) availableLocked(p Priority, now time.Time) bool {
+	return !now.Before(s.paused) && s.tokens >= s.needLocked(p)
+}
+
+// dispatchLocked grants tokens to waiting requests from the highest priority
+func (s *scheduler) dispatchLocked(now time.Time) {
+	s.refillLocked(now)
+	for p := PriorityHigh; p >= PriorityLow; p-- {
+		for len(s.waiters[p]) > 0 {
+			if !s.availableLocked(p, now) {
+				s.scheduleLocked(now)
+				return
+			}
+			s.tokens--
+			close(s.waiters[p][0])
+			s.waiters[p] = s.waiters[p][1:]
+		}
+	}
+}
+
+// scheduleLocked arms the timer to dispatch when the first waiter can be granted
+func (s *scheduler) scheduleLocked(now time.Time) {
+	var need float64
+	for p := PriorityHigh; p >= PriorityLow; p-- {
+		if len(s.waiters[p]) > 0 {
+			need = s.needLocked(p)
+			break
+		}
+	}
+	if need == 0 {
+		return
+	}
+
+	at := now
+	if at.Before(s.paused) {
+		at = s.paused
+	}
+	if s.tokens < need {
+		at = at.Add(time.Duration((need - s.tokens) * float64(s.interval)))
+	}
+
+	if s.timer != nil && !s.timerAt.After(at) {
+		return
+	}
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.timerAt = at
+	s.timer = time.AfterFunc(at.Sub(now), func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.timer = nil
+		s.dispatchLocked(s.now())
+	})
+}
+
+// removeLocked removes the waiter and reports if it was still waiting
+func (s *scheduler) removeLocked(p Priority, ch chan struct{}) bool {
+	for i, w := range s.waiters[p] {
+		if w == ch {
+			s.waiters[p] = append(s.waiters[p][:i], s.waiters[p][i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package zendesk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPriorityFromContext(t *testing.T) {
+	if p := PriorityFromContext(ctx); p != PriorityNormal {
+		t.Fatalf("Default priority should be normal, but got %d", p)
+	}
+
+	if p := PriorityFromContext(WithPriority(ctx, PriorityHigh)); p != PriorityHigh {
+		t.Fatalf("Expected high priority, but got %d", p)
+	}
+}
+
+func TestSchedulerHighPriorityGoesFirst(t *testing.T) {
+	s := newScheduler(SchedulerConfig{RequestsPerMinute: 1200})
+
+	// use the initial budget
+	if err := s.acquire(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu    sync.Mutex
+		order []Priority
+		wg    sync.WaitGroup
+	)
+	start := func(p Priority) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.acquire(WithPriority(ctx, p)); err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			order = append(order, p)
+			mu.Unlock()
+		}()
+	}
+
+	start(PriorityLow)
+	time.Sleep(10 * time.Millisecond)
+	start(PriorityNormal)
+	time.Sleep(10 * time.Millisecond)
+	start(PriorityHigh)
+	wg.Wait()
+
+	expected := []Priority{PriorityHigh, PriorityNormal, PriorityLow}
+	for i, p := range expected {
+		if order[i] != p {
+			t.Fatalf("Expected order %v, but got %v", expected, order)
+		}
+	}
+}
+
+func TestSchedulerLowPriorityLeavesReserve(t *testing.T) {
+	s := newScheduler(SchedulerConfig{RequestsPerMinute: 60, Burst: 3, LowPriorityReserve: 2})
+	now := time.Unix(0, 0)
+	s.now = func() time.Time { return now }
+
+	low, cancel := context.WithTimeout(WithPriority(ctx, PriorityLow), 10*time.Millisecond)
+	defer cancel()
+
+	if err := s.acquire(low); err != nil {
+		t.Fatalf("Low priority request should use leftover budget: %s", err)
+	}
+	if err := s.acquire(low); err == nil {
+		t.Fatal("Low priority request should not use reserved budget")
+	}
+	if err := s.acquire(WithPriority(ctx, PriorityHigh)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.acquire(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSchedulerPausesOnTooManyRequests(t *testing.T) {
+	s := newScheduler(SchedulerConfig{RequestsPerMinute: 6000, Burst: 10})
+	now := time.Unix(0, 0)
+	s.now = func() time.Time { return now }
+
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"30"}},
+	}
+	s.observe(resp)
+
+	timeout, cancel := context.WithTimeout(WithPriority(ctx, PriorityHigh), 10*time.Millisecond)
+	defer cancel()
+	if err := s.acquire(timeout); err == nil {
+		t.Fatal("Request should wait until Retry-After")
+	}
+
+	now = now.Add(30 * time.Second)
+	if err := s.acquire(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientWithScheduler(t *testing.T) {
+	calls := 0
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-Rate-Limit-Remaining", "0")
+		w.Write(readFixture("GET/ticket.json"))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	client.SetScheduler(SchedulerConfig{RequestsPerMinute: 60, Burst: 5})
+
+	if _, err := client.GetTicket(WithPriority(ctx, PriorityHigh), 2); err != nil {
+		t.Fatalf("Failed to get ticket: %s", err)
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetTicket(timeout, 2); err == nil {
+		t.Fatal("Request should wait because no budget remains in the account")
+	}
+	if calls != 1 {
+		t.Fatalf("Expected 1 call, but got %d", calls)
+	}
+}
@@ -0,0 +1,472 @@
+package schemagen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// Options is options of Generate
+type Options struct {
+	// Package is the package name of the generated file. Defaults to "zendeskschema".
+	Package string
+
+	// Command is written in the header of the generated file. Defaults to "zendesk-schemagen".
+	Command string
+
+	// Inactive includes inactive fields and custom statuses
+	Inactive bool
+}
+
+// system ticket fields which are set directly on the ticket
+var systemTicketFieldTypes = map[string]bool{
+	"subject":       true,
+	"description":   true,
+	"status":        true,
+	"custom_status": true,
+	"tickettype":    true,
+	"priority":      true,
+	"group":         true,
+	"assignee":      true,
+}
+
+// field is a custom field in the generated code
+type field struct {
+	name       string // Go name of the struct field
+	constName  string // name of the constant of the ID or key
+	id         int64
+	key        string
+	title      string
+	typ        string
+	kind       string // string, int, float, bool, option or options
+	optionType string
+	options    []option
+}
+
+type option struct {
+	name  string
+	value string
+	title string
+}
+
+// goType returns the type of the struct field
+func (f field) goType() string {
+	switch f.kind {
+	case "option":
+		return f.optionType
+	case "options":
+		return "[]" + f.optionType
+	case "int":
+		return "*int64"
+	case "float":
+		return "*float64"
+	case "bool":
+		return "*bool"
+	}
+	return "*string"
+}
+
+// Generate generates the Go source of the types and constants of the snapshot.
+// For the ticket, user and organization fields and each custom object it emits
+// constants of the field IDs or keys, a string type with constants for the options
+// of each dropdown and multiselect field, and a struct of the field values with
+// methods to convert it from and to the custom field values of the API.
+func Generate(s Snapshot, opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		opts.Package = "zendeskschema"
+	}
+	if opts.Command == "" {
+		opts.Command = "zendesk-schemagen"
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by %s. DO NOT EDIT.\n\n", opts.Command)
+	fmt.Fprintf(&b, "package %s\n\n", opts.Package)
+	b.WriteString("import (\n\t\"encoding/json\"\n\t\"strconv\"\n\n\t\"github.com/nukosuke/go-zendesk/zendesk\"\n)\n\n")
+
+	names := map[string]bool{}
+	for _, name := range []string{"TicketFields", "ParseTicketFields", "UserFields", "ParseUserFields", "OrganizationFields", "ParseOrganizationFields"} {
+		names[name] = true
+	}
+	// struct fields are named after their constants without the prefix, so
+	// they must not be named like the methods of their struct
+	for _, name := range []string{"TicketFieldCustomFields", "UserFieldMap", "OrganizationFieldMap"} {
+		names[name] = true
+	}
+
+	// ticket fields
+	var ticketFields []field
+	for _, f := range s.TicketFields {
+		if systemTicketFieldTypes[f.Type] || (!f.Active && !opts.Inactive) {
+			continue
+		}
+		ticketFields = append(ticketFields, newField(names, "TicketField", f.Title, f.ID, "", f.Title, f.Type, f.CustomFieldOptions))
+	}
+	writeIDConsts(&b, "ticket fields", ticketFields)
+	writeFields(&b, "TicketFields", "the custom ticket fields", ticketFields)
+	writeTicketMethods(&b, ticketFields)
+
+	// user fields
+	var userFields []field
+	for _, f := range s.UserFields {
+		if f.System || (!f.Active && !opts.Inactive) {
+			continue
+		}
+		userFields = append(userFields, newField(names, "UserField", f.Key, 0, f.Key, f.Title, f.Type, f.CustomFieldOptions))
+	}
+	writeKeyConsts(&b, "user fields", userFields)
+	writeFields(&b, "UserFields", "the custom user fields", userFields)
+	writeKeyMethods(&b, "UserFields", "zendesk.UserFields", "user_fields of a user", userFields)
+
+	// organization fields
+	var orgFields []field
+	for _, f := range s.OrganizationFields {
+		if f.System || (!f.Active && !opts.Inactive) {
+			continue
+		}
+		orgFields = append(orgFields, newField(names, "OrganizationField", f.Key, 0, f.Key, f.Title, f.Type, f.CustomFieldOptions))
+	}
+	writeKeyConsts(&b, "organization fields", orgFields)
+	writeFields(&b, "OrganizationFields", "the custom organization fields", orgFields)
+	writeKeyMethods(&b, "OrganizationFields", "map[string]interface{}", "organization_fields of an organization", orgFields)
+
+	// custom statuses
+	var statuses []zendesk.CustomStatus
+	for _, cs := range s.CustomStatuses {
+		if cs.Active || opts.Inactive {
+			statuses = append(statuses, cs)
+		}
+	}
+	if len(statuses) > 0 {
+		b.WriteString("// IDs of the custom ticket statuses\nconst (\n")
+		for _, cs := range statuses {
+			name := uniqueName(names, "CustomStatus"+goName(cs.StatusCategory)+goName(cs.AgentLabel))
+			fmt.Fprintf(&b, "\t// %s is %s (%s)\n", name, strconv.Quote(cs.AgentLabel), cs.StatusCategory)
+			fmt.Fprintf(&b, "\t%s int64 = %d\n", name, cs.ID)
+		}
+		b.WriteString(")\n\n")
+	}
+
+	// custom objects
+	objects := append([]CustomObject(nil), s.CustomObjects...)
+	sort.SliceStable(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	for _, o := range objects {
+		prefix := goName(o.Key)
+		objectConst := uniqueName(names, "CustomObject"+prefix+"Key")
+		fmt.Fprintf(&b, "// %s is the key of the custom object %s\n", objectConst, strconv.Quote(o.Title))
+		fmt.Fprintf(&b, "const %s = %s\n\n", objectConst, strconv.Quote(o.Key))
+
+		names[prefix+"FieldMap"] = true
+		var fields []field
+		for _, f := range o.Fields {
+			if f.System || (!f.Active && !opts.Inactive) {
+				continue
+			}
+			fields = append(fields, newField(names, prefix+"Field", f.Key, 0, f.Key, f.Title, f.Type, f.CustomFieldOptions))
+		}
+		structName := uniqueName(names, prefix+"Fields")
+		writeKeyConsts(&b, "fields of the custom object "+strconv.Quote(o.Title), fields)
+		writeFields(&b, structName, "the custom_object_fields of the custom object "+strconv.Quote(o.Title), fields)
+		writeKeyMethods(&b, structName, "map[string]interface{}", "custom_object_fields of a record", fields)
+	}
+
+	b.WriteString(helpers)
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("schemagen: failed to format the generated code: %w", err)
+	}
+	return src, nil
+}
+
+func newField(names map[string]bool, prefix, name string, id int64, key, title, typ string, options []zendesk.CustomFieldOption) field {
+	f := field{id: id, key: key, title: title, typ: typ}
+	base := uniqueName(names, prefix+goName(name))
+	f.name = strings.TrimPrefix(base, prefix)
+	if id != 0 {
+		f.constName = uniqueName(names, base+"ID")
+	} else {
+		f.constName = uniqueName(names, base+"Key")
+	}
+
+	switch typ {
+	case "integer":
+		f.kind = "int"
+	case "decimal":
+		f.kind = "float"
+	case "checkbox":
+		f.kind = "bool"
+	case "tagger", "dropdown", "multiselect":
+		if len(options) == 0 {
+			f.kind = "string"
+			break
+		}
+		f.kind = "option"
+		if typ == "multiselect" {
+			f.kind = "options"
+		}
+		f.optionType = uniqueName(names, base+"Option")
+		for _, o := range options {
+			label := o.Name
+			if label == "" {
+				label = o.Value
+			}
+			f.options = append(f.options, option{name: uniqueName(names, base+goName(label)), value: o.Value, title: label})
+		}
+	default:
+		f.kind = "string"
+	}
+	return f
+}
+
+func writeIDConsts(b *bytes.Buffer, what string, fields []field) {
+	if len(fields) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "// IDs of the %s\nconst (\n", what)
+	for _, f := range fields {
+		fmt.Fprintf(b, "\t// %s is the ID of %s (%s)\n", f.constName, strconv.Quote(f.title), f.typ)
+		fmt.Fprintf(b, "\t%s int64 = %d\n", f.constName, f.id)
+	}
+	b.WriteString(")\n\n")
+	writeOptions(b, fields)
+}
+
+func writeKeyConsts(b *bytes.Buffer, what string, fields []field) {
+	if len(fields) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "// keys of the %s\nconst (\n", what)
+	for _, f := range fields {
+		fmt.Fprintf(b, "\t// %s is the key of %s (%s)\n", f.constName, strconv.Quote(f.title), f.typ)
+		fmt.Fprintf(b, "\t%s = %s\n", f.constName, strconv.Quote(f.key))
+	}
+	b.WriteString(")\n\n")
+	writeOptions(b, fields)
+}
+
+func writeOptions(b *bytes.Buffer, fields []field) {
+	for _, f := range fields {
+		if f.optionType == "" {
+			continue
+		}
+		fmt.Fprintf(b, "// %s is an option of %s\ntype %s string\n\n", f.optionType, strconv.Quote(f.title), f.optionType)
+		fmt.Fprintf(b, "// options of %s\nconst (\n", strconv.Quote(f.title))
+		for _, o := range f.options {
+			fmt.Fprintf(b, "\t%s %s = %s // %s\n", o.name, f.optionType, strconv.Quote(o.value), strconv.Quote(o.title))
+		}
+		b.WriteString(")\n\n")
+	}
+}
+
+func writeFields(b *bytes.Buffer, name, what string, fields []field) {
+	fmt.Fprintf(b, "// %s are the values of %s.\n// Nil and empty values are not set.\n", name, what)
+	fmt.Fprintf(b, "type %s struct {\n", name)
+	for _, f := range fields {
+		fmt.Fprintf(b, "\t%s %s\n", f.name, f.goType())
+	}
+	b.WriteString("}\n\n")
+}
+
+func writeTicketMethods(b *bytes.Buffer, fields []field) {
+	b.WriteString("// CustomFields returns the values which are set as custom fields of a ticket\n")
+	b.WriteString("func (f TicketFields) CustomFields() []zendesk.CustomField {\n\tvar cfs []zendesk.CustomField\n")
+	for _, f := range fields {
+		fmt.Fprintf(b, "\tif %s {\n", isSet(f))
+		fmt.Fprintf(b, "\t\tcfs = append(cfs, zendesk.CustomField{ID: %s, Value: %s})\n\t}\n", f.constName, value(f, true))
+	}
+	b.WriteString("\treturn cfs\n}\n\n")
+
+	b.WriteString("// ParseTicketFields reads the values of the custom fields of a ticket\n")
+	b.WriteString("func ParseTicketFields(cfs []zendesk.CustomField) TicketFields {\n\tvar f TicketFields\n")
+	if len(fields) > 0 {
+		b.WriteString("\tfor _, cf := range cfs {\n\t\tswitch cf.ID {\n")
+		for _, f := range fields {
+			fmt.Fprintf(b, "\t\tcase %s:\n%s", f.constName, parse(f, "cf.Value", "\t\t\t"))
+		}
+		b.WriteString("\t\t}\n\t}\n")
+	}
+	b.WriteString("\treturn f\n}\n\n")
+}
+
+func writeKeyMethods(b *bytes.Buffer, name, mapType, what string, fields []field) {
+	fmt.Fprintf(b, "// Map returns the values which are set as %s\n", what)
+	fmt.Fprintf(b, "func (f %s) Map() %s {\n\tm := %s{}\n", name, mapType, mapType)
+	for _, f := range fields {
+		fmt.Fprintf(b, "\tif %s {\n\t\tm[%s] = %s\n\t}\n", isSet(f), f.constName, value(f, false))
+	}
+	b.WriteString("\treturn m\n}\n\n")
+
+	fmt.Fprintf(b, "// Parse%s reads the values of %s\n", name, what)
+	fmt.Fprintf(b, "func Parse%s(m %s) %s {\n\tvar f %s\n", name, mapType, name, name)
+	for _, f := range fields {
+		fmt.Fprintf(b, "\tif v, ok := m[%s]; ok {\n%s\t}\n", f.constName, parse(f, "v", "\t\t"))
+	}
+	b.WriteString("\treturn f\n}\n\n")
+}
+
+// isSet returns the expression which reports whether the struct field is set
+func isSet(f field) string {
+	switch f.kind {
+	case "option":
+		return fmt.Sprintf("f.%s != \"\"", f.name)
+	case "options":
+		return fmt.Sprintf("len(f.%s) > 0", f.name)
+	}
+	return fmt.Sprintf("f.%s != nil", f.name)
+}
+
+// value returns the expression of the API value of the struct field.
+// Numbers are strings in the custom fields of tickets.
+func value(f field, ticket bool) string {
+	switch {
+	case f.kind == "option":
+		return fmt.Sprintf("string(f.%s)", f.name)
+	case f.kind == "options":
+		return fmt.Sprintf("schemaOptionValues(f.%s)", f.name)
+	case f.kind == "int" && ticket:
+		return fmt.Sprintf("strconv.FormatInt(*f.%s, 10)", f.name)
+	case f.kind == "float" && ticket:
+		return fmt.Sprintf("strconv.FormatFloat(*f.%s, 'f', -1, 64)", f.name)
+	}
+	return "*f." + f.name
+}
+
+// parse returns the statements which set the struct field from the API value
+func parse(f field, v, indent string) string {
+	switch f.kind {
+	case "option":
+		return fmt.Sprintf("%sif s := schemaString(%s); s != nil {\n%s\tf.%s = %s(*s)\n%s}\n", indent, v, indent, f.name, f.optionType, indent)
+	case "options":
+		return fmt.Sprintf("%sfor _, s := range schemaStrings(%s) {\n%s\tf.%s = append(f.%s, %s(s))\n%s}\n",
+			indent, v, indent, f.name, f.name, f.optionType, indent)
+	case "int":
+		return fmt.Sprintf("%sf.%s = schemaInt(%s)\n", indent, f.name, v)
+	case "float":
+		return fmt.Sprintf("%sf.%s = schemaFloat(%s)\n", indent, f.name, v)
+	case "bool":
+		return fmt.Sprintf("%sf.%s = schemaBool(%s)\n", indent, f.name, v)
+	}
+	return fmt.Sprintf("%sf.%s = schemaString(%s)\n", indent, f.name, v)
+}
+
+// goName converts a title or key to an exported Go identifier
+func goName(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, c := range s {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			upper = true
+			continue
+		}
+		if c > unicode.MaxASCII {
+			upper = true
+			continue
+		}
+		if upper {
+			c = unicode.ToUpper(c)
+			upper = false
+		}
+		b.WriteRune(c)
+	}
+	name := b.String()
+	if name == "" {
+		return "X"
+	}
+	if unicode.IsDigit(rune(name[0])) {
+		return "X" + name
+	}
+	return name
+}
+
+// uniqueName returns name, or name with a number when it is already used
+func uniqueName(names map[string]bool, name string) string {
+	unique := name
+	for i := 2; names[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	names[unique] = true
+	return unique
+}
+
+// helpers are the functions used by the generated code
+const helpers = `func schemaString(v interface{}) *string {
+	switch v := v.(type) {
+	case string:
+		return &v
+	case float64:
+		s := strconv.FormatFloat(v, 'f', -1, 64)
+		return &s
+	case json.Number:
+		s := v.String()
+		return &s
+	}
+	return nil
+}
+
+func schemaStrings(v interface{}) []string {
+	var values []string
+	switch v := v.(type) {
+	case []string:
+		values = v
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
+func schemaInt(v interface{}) *int64 {
+	s := schemaString(v)
+	if s == nil {
+		return nil
+	}
+	n, err := strconv.ParseInt(*s, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func schemaFloat(v interface{}) *float64 {
+	s := schemaString(v)
+	if s == nil {
+		return nil
+	}
+	n, err := strconv.ParseFloat(*s, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func schemaBool(v interface{}) *bool {
+	switch v := v.(type) {
+	case bool:
+		return &v
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err == nil {
+			return &b
+		}
+	}
+	return nil
+}
+
+func schemaOptionValues[T ~string](options []T) []string {
+	values := make([]string, len(options))
+	for i, o := range options {
+		values[i] = string(o)
+	}
+	return values
+}
+`
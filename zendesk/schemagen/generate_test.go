@@ -0,0 +1,158 @@
+package schemagen
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+func readTestSnapshot(t *testing.T) Snapshot {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", "schema.json"))
+	if err != nil {
+		t.Fatalf("Failed to open snapshot: %s", err)
+	}
+	defer f.Close()
+
+	s, err := ReadSnapshot(f)
+	if err != nil {
+		t.Fatalf("Failed to read snapshot: %s", err)
+	}
+	return s
+}
+
+// The example package is generated from the test snapshot by go generate,
+// so it is compiled with the module and must be up to date
+func TestGenerateMatchesExample(t *testing.T) {
+	src, err := Generate(readTestSnapshot(t), Options{Package: "example"})
+	if err != nil {
+		t.Fatalf("Failed to generate: %s", err)
+	}
+
+	expected, err := os.ReadFile(filepath.Join("internal", "example", "zendesk_schema.go"))
+	if err != nil {
+		t.Fatalf("Failed to read example: %s", err)
+	}
+	if !bytes.Equal(src, expected) {
+		t.Fatal("Generated code differs from internal/example/zendesk_schema.go. Run go generate ./...")
+	}
+}
+
+func TestGenerateSkipsSystemAndInactive(t *testing.T) {
+	s := readTestSnapshot(t)
+
+	src, _ := Generate(s, Options{})
+	code := string(src)
+	for _, name := range []string{"TicketFieldSubject", "TicketFieldStatus", "TicketFieldLegacyID", "CustomStatusHoldOldStatus", "CarFieldStandardName"} {
+		if strings.Contains(code, name) {
+			t.Fatalf("%s should not be generated", name)
+		}
+	}
+	if !strings.HasPrefix(code, "// Code generated by zendesk-schemagen. DO NOT EDIT.\n\npackage zendeskschema\n") {
+		t.Fatalf("Unexpected header %s", code[:80])
+	}
+
+	src, _ = Generate(s, Options{Inactive: true})
+	if code := string(src); !strings.Contains(code, "TicketFieldLegacyIDID int64 = 360006") || !strings.Contains(code, "CustomStatusHoldOldStatus") {
+		t.Fatal("Inactive fields and statuses should be generated with Inactive")
+	}
+}
+
+func TestGenerateUniqueNames(t *testing.T) {
+	s := Snapshot{
+		TicketFields: []zendesk.TicketField{
+			{ID: 1, Type: "text", Title: "Plan", Active: true},
+			{ID: 2, Type: "text", Title: "plan", Active: true},
+		},
+		CustomObjects: []CustomObject{{CustomObject: zendesk.CustomObject{Key: "user", Title: "User"}}},
+	}
+
+	src, err := Generate(s, Options{})
+	if err != nil {
+		t.Fatalf("Failed to generate: %s", err)
+	}
+	code := string(src)
+	if !strings.Contains(code, "TicketFieldPlanID int64 = 1") || !strings.Contains(code, "TicketFieldPlan2ID int64 = 2") {
+		t.Fatal("Fields with the same name should get unique names")
+	}
+	if !strings.Contains(code, "type UserFields2 struct") {
+		t.Fatal("Custom object types should not collide with the user fields")
+	}
+}
+
+func TestGenerateFieldsDoNotCollideWithMethods(t *testing.T) {
+	s := Snapshot{
+		TicketFields:       []zendesk.TicketField{{ID: 1, Type: "text", Title: "Custom Fields", Active: true}},
+		UserFields:         []zendesk.UserField{{Key: "map", Type: "text", Title: "Map", Active: true}},
+		OrganizationFields: []zendesk.OrganizationField{{Key: "map", Type: "text", Title: "Map", Active: true}},
+		CustomObjects: []CustomObject{{
+			CustomObject: zendesk.CustomObject{Key: "car", Title: "Car"},
+			Fields:       []zendesk.CustomObjectField{{Key: "map", Type: "text", Title: "Map", Active: true}},
+		}},
+	}
+
+	src, err := Generate(s, Options{})
+	if err != nil {
+		t.Fatalf("Failed to generate: %s", err)
+	}
+	code := string(src)
+	for _, name := range []string{"TicketFieldCustomFields2ID int64 = 1", "\tCustomFields2 *string\n", "UserFieldMap2Key", "OrganizationFieldMap2Key", "CarFieldMap2Key", "\tMap2 *string\n"} {
+		if !strings.Contains(code, name) {
+			t.Fatalf("Generated code does not contain %q:\n%s", name, code)
+		}
+	}
+	if strings.Contains(code, "\tCustomFields *string\n") || strings.Contains(code, "\tMap *string\n") {
+		t.Fatal("Struct fields should not be named like the methods of their struct")
+	}
+}
+
+func TestFetch(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ticket_fields.json":
+			w.Write([]byte(`{"ticket_fields":[{"id":1,"type":"text","title":"Plan","active":true}]}`))
+		case "/user_fields.json":
+			w.Write([]byte(`{"user_fields":[{"key":"plan","type":"text","title":"Plan","active":true}],"meta":{"has_more":false}}`))
+		case "/organization_fields.json":
+			w.Write([]byte(`{"organization_fields":[],"meta":{"has_more":false}}`))
+		case "/custom_statuses.json":
+			w.Write([]byte(`{"custom_statuses":[{"id":100,"status_category":"open","agent_label":"Open","active":true}]}`))
+		case "/custom_objects.json":
+			w.Write([]byte(`{"custom_objects":[{"key":"car","title":"Car"}]}`))
+		case "/custom_objects/car/fields.json":
+			w.Write([]byte(`{"custom_object_fields":[{"key":"color","type":"text","title":"Color","active":true}]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockAPI.Close()
+
+	client, _ := zendesk.NewClient(nil)
+	client.SetEndpointURL(mockAPI.URL)
+
+	s, err := Fetch(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Failed to fetch schema: %s", err)
+	}
+	if len(s.TicketFields) != 1 || len(s.UserFields) != 1 || len(s.CustomStatuses) != 1 ||
+		len(s.CustomObjects) != 1 || s.CustomObjects[0].Fields[0].Key != "color" {
+		t.Fatalf("Unexpected snapshot %+v", s)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSnapshot(&buf, s); err != nil {
+		t.Fatalf("Failed to write snapshot: %s", err)
+	}
+	read, err := ReadSnapshot(&buf)
+	if err != nil || read.CustomObjects[0].Key != "car" || read.CustomObjects[0].Fields[0].Key != "color" {
+		t.Fatalf("Snapshot should round-trip: %+v %v", read, err)
+	}
+}
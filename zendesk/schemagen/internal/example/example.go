@@ -0,0 +1,4 @@
+// Package example is generated from the test snapshot to check the generated code compiles
+package example
+
+//go:generate go run ../../../../cmd/zendesk-schemagen -snapshot ../../testdata/schema.json -o zendesk_schema.go
@@ -0,0 +1,48 @@
+package example
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+func TestTicketFieldsRoundTrip(t *testing.T) {
+	seats, vip := int64(5), true
+	fields := TicketFields{
+		Plan:             TicketFieldPlanPro,
+		Seats:            &seats,
+		VIPCustomer:      &vip,
+		AffectedProducts: []TicketFieldAffectedProductsOption{TicketFieldAffectedProductsWebApp},
+	}
+
+	// through JSON like the values of a ticket from the API
+	b, _ := json.Marshal(zendesk.Ticket{CustomFields: fields.CustomFields()})
+	var ticket zendesk.Ticket
+	if err := json.Unmarshal(b, &ticket); err != nil {
+		t.Fatalf("Failed to unmarshal ticket: %s", err)
+	}
+
+	parsed := ParseTicketFields(ticket.CustomFields)
+	if parsed.Plan != TicketFieldPlanPro || parsed.Seats == nil || *parsed.Seats != 5 || parsed.VIPCustomer == nil || !*parsed.VIPCustomer {
+		t.Fatalf("Unexpected fields %+v", parsed)
+	}
+	if len(parsed.AffectedProducts) != 1 || parsed.AffectedProducts[0] != TicketFieldAffectedProductsWebApp {
+		t.Fatalf("Unexpected multiselect values %v", parsed.AffectedProducts)
+	}
+	if parsed.OrderTotal != nil || parsed.X2ndFollowUp != nil {
+		t.Fatal("Unset fields should be nil")
+	}
+}
+
+func TestUserFieldsParse(t *testing.T) {
+	fields := ParseUserFields(zendesk.UserFields{UserFieldPlanKey: "user_plan_free", UserFieldEmployeeNumberKey: "42"})
+	if fields.Plan != UserFieldPlanFree || fields.EmployeeNumber == nil || *fields.EmployeeNumber != 42 {
+		t.Fatalf("Unexpected fields %+v", fields)
+	}
+
+	m := fields.Map()
+	if len(m) != 2 || m[UserFieldPlanKey] != "user_plan_free" {
+		t.Fatalf("Unexpected map %v", m)
+	}
+}
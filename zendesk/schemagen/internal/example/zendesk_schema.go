@@ -0,0 +1,332 @@
+// Code generated by zendesk-schemagen. DO NOT EDIT.
+
+package example
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// IDs of the ticket fields
+const (
+	// TicketFieldPlanID is the ID of "Plan" (tagger)
+	TicketFieldPlanID int64 = 360001
+	// TicketFieldSeatsID is the ID of "Seats" (integer)
+	TicketFieldSeatsID int64 = 360002
+	// TicketFieldVIPCustomerID is the ID of "VIP customer?" (checkbox)
+	TicketFieldVIPCustomerID int64 = 360003
+	// TicketFieldAffectedProductsID is the ID of "Affected products" (multiselect)
+	TicketFieldAffectedProductsID int64 = 360004
+	// TicketFieldOrderTotalID is the ID of "Order total" (decimal)
+	TicketFieldOrderTotalID int64 = 360005
+	// TicketFieldX2ndFollowUpID is the ID of "2nd follow-up" (date)
+	TicketFieldX2ndFollowUpID int64 = 360007
+)
+
+// TicketFieldPlanOption is an option of "Plan"
+type TicketFieldPlanOption string
+
+// options of "Plan"
+const (
+	TicketFieldPlanFree             TicketFieldPlanOption = "plan_free"       // "Free"
+	TicketFieldPlanPro              TicketFieldPlanOption = "plan_pro"        // "Pro"
+	TicketFieldPlanEnterpriseAnnual TicketFieldPlanOption = "plan_enterprise" // "Enterprise (annual)"
+)
+
+// TicketFieldAffectedProductsOption is an option of "Affected products"
+type TicketFieldAffectedProductsOption string
+
+// options of "Affected products"
+const (
+	TicketFieldAffectedProductsWebApp    TicketFieldAffectedProductsOption = "product_web"    // "Web app"
+	TicketFieldAffectedProductsMobileApp TicketFieldAffectedProductsOption = "product_mobile" // "Mobile app"
+)
+
+// TicketFields are the values of the custom ticket fields.
+// Nil and empty values are not set.
+type TicketFields struct {
+	Plan             TicketFieldPlanOption
+	Seats            *int64
+	VIPCustomer      *bool
+	AffectedProducts []TicketFieldAffectedProductsOption
+	OrderTotal       *float64
+	X2ndFollowUp     *string
+}
+
+// CustomFields returns the values which are set as custom fields of a ticket
+func (f TicketFields) CustomFields() []zendesk.CustomField {
+	var cfs []zendesk.CustomField
+	if f.Plan != "" {
+		cfs = append(cfs, zendesk.CustomField{ID: TicketFieldPlanID, Value: string(f.Plan)})
+	}
+	if f.Seats != nil {
+		cfs = append(cfs, zendesk.CustomField{ID: TicketFieldSeatsID, Value: strconv.FormatInt(*f.Seats, 10)})
+	}
+	if f.VIPCustomer != nil {
+		cfs = append(cfs, zendesk.CustomField{ID: TicketFieldVIPCustomerID, Value: *f.VIPCustomer})
+	}
+	if len(f.AffectedProducts) > 0 {
+		cfs = append(cfs, zendesk.CustomField{ID: TicketFieldAffectedProductsID, Value: schemaOptionValues(f.AffectedProducts)})
+	}
+	if f.OrderTotal != nil {
+		cfs = append(cfs, zendesk.CustomField{ID: TicketFieldOrderTotalID, Value: strconv.FormatFloat(*f.OrderTotal, 'f', -1, 64)})
+	}
+	if f.X2ndFollowUp != nil {
+		cfs = append(cfs, zendesk.CustomField{ID: TicketFieldX2ndFollowUpID, Value: *f.X2ndFollowUp})
+	}
+	return cfs
+}
+
+// ParseTicketFields reads the values of the custom fields of a ticket
+func ParseTicketFields(cfs []zendesk.CustomField) TicketFields {
+	var f TicketFields
+	for _, cf := range cfs {
+		switch cf.ID {
+		case TicketFieldPlanID:
+			if s := schemaString(cf.Value); s != nil {
+				f.Plan = TicketFieldPlanOption(*s)
+			}
+		case TicketFieldSeatsID:
+			f.Seats = schemaInt(cf.Value)
+		case TicketFieldVIPCustomerID:
+			f.VIPCustomer = schemaBool(cf.Value)
+		case TicketFieldAffectedProductsID:
+			for _, s := range schemaStrings(cf.Value) {
+				f.AffectedProducts = append(f.AffectedProducts, TicketFieldAffectedProductsOption(s))
+			}
+		case TicketFieldOrderTotalID:
+			f.OrderTotal = schemaFloat(cf.Value)
+		case TicketFieldX2ndFollowUpID:
+			f.X2ndFollowUp = schemaString(cf.Value)
+		}
+	}
+	return f
+}
+
+// keys of the user fields
+const (
+	// UserFieldPlanKey is the key of "Plan" (dropdown)
+	UserFieldPlanKey = "plan"
+	// UserFieldEmployeeNumberKey is the key of "Employee number" (integer)
+	UserFieldEmployeeNumberKey = "employee_number"
+)
+
+// UserFieldPlanOption is an option of "Plan"
+type UserFieldPlanOption string
+
+// options of "Plan"
+const (
+	UserFieldPlanFree UserFieldPlanOption = "user_plan_free" // "Free"
+	UserFieldPlanPro  UserFieldPlanOption = "user_plan_pro"  // "Pro"
+)
+
+// UserFields are the values of the custom user fields.
+// Nil and empty values are not set.
+type UserFields struct {
+	Plan           UserFieldPlanOption
+	EmployeeNumber *int64
+}
+
+// Map returns the values which are set as user_fields of a user
+func (f UserFields) Map() zendesk.UserFields {
+	m := zendesk.UserFields{}
+	if f.Plan != "" {
+		m[UserFieldPlanKey] = string(f.Plan)
+	}
+	if f.EmployeeNumber != nil {
+		m[UserFieldEmployeeNumberKey] = *f.EmployeeNumber
+	}
+	return m
+}
+
+// ParseUserFields reads the values of user_fields of a user
+func ParseUserFields(m zendesk.UserFields) UserFields {
+	var f UserFields
+	if v, ok := m[UserFieldPlanKey]; ok {
+		if s := schemaString(v); s != nil {
+			f.Plan = UserFieldPlanOption(*s)
+		}
+	}
+	if v, ok := m[UserFieldEmployeeNumberKey]; ok {
+		f.EmployeeNumber = schemaInt(v)
+	}
+	return f
+}
+
+// keys of the organization fields
+const (
+	// OrganizationFieldRegionKey is the key of "Region" (text)
+	OrganizationFieldRegionKey = "region"
+	// OrganizationFieldPremiumKey is the key of "Premium" (checkbox)
+	OrganizationFieldPremiumKey = "premium"
+)
+
+// OrganizationFields are the values of the custom organization fields.
+// Nil and empty values are not set.
+type OrganizationFields struct {
+	Region  *string
+	Premium *bool
+}
+
+// Map returns the values which are set as organization_fields of an organization
+func (f OrganizationFields) Map() map[string]interface{} {
+	m := map[string]interface{}{}
+	if f.Region != nil {
+		m[OrganizationFieldRegionKey] = *f.Region
+	}
+	if f.Premium != nil {
+		m[OrganizationFieldPremiumKey] = *f.Premium
+	}
+	return m
+}
+
+// ParseOrganizationFields reads the values of organization_fields of an organization
+func ParseOrganizationFields(m map[string]interface{}) OrganizationFields {
+	var f OrganizationFields
+	if v, ok := m[OrganizationFieldRegionKey]; ok {
+		f.Region = schemaString(v)
+	}
+	if v, ok := m[OrganizationFieldPremiumKey]; ok {
+		f.Premium = schemaBool(v)
+	}
+	return f
+}
+
+// IDs of the custom ticket statuses
+const (
+	// CustomStatusOpenOpen is "Open" (open)
+	CustomStatusOpenOpen int64 = 100
+	// CustomStatusOpenInProgress is "In progress" (open)
+	CustomStatusOpenInProgress int64 = 101
+	// CustomStatusPendingWaitingOnVendor is "Waiting on vendor" (pending)
+	CustomStatusPendingWaitingOnVendor int64 = 102
+)
+
+// CustomObjectCarKey is the key of the custom object "Car"
+const CustomObjectCarKey = "car"
+
+// keys of the fields of the custom object "Car"
+const (
+	// CarFieldColorKey is the key of "Color" (dropdown)
+	CarFieldColorKey = "color"
+	// CarFieldMileageKey is the key of "Mileage" (decimal)
+	CarFieldMileageKey = "mileage"
+)
+
+// CarFieldColorOption is an option of "Color"
+type CarFieldColorOption string
+
+// options of "Color"
+const (
+	CarFieldColorRed  CarFieldColorOption = "red"  // "Red"
+	CarFieldColorBlue CarFieldColorOption = "blue" // "Blue"
+)
+
+// CarFields are the values of the custom_object_fields of the custom object "Car".
+// Nil and empty values are not set.
+type CarFields struct {
+	Color   CarFieldColorOption
+	Mileage *float64
+}
+
+// Map returns the values which are set as custom_object_fields of a record
+func (f CarFields) Map() map[string]interface{} {
+	m := map[string]interface{}{}
+	if f.Color != "" {
+		m[CarFieldColorKey] = string(f.Color)
+	}
+	if f.Mileage != nil {
+		m[CarFieldMileageKey] = *f.Mileage
+	}
+	return m
+}
+
+// ParseCarFields reads the values of custom_object_fields of a record
+func ParseCarFields(m map[string]interface{}) CarFields {
+	var f CarFields
+	if v, ok := m[CarFieldColorKey]; ok {
+		if s := schemaString(v); s != nil {
+			f.Color = CarFieldColorOption(*s)
+		}
+	}
+	if v, ok := m[CarFieldMileageKey]; ok {
+		f.Mileage = schemaFloat(v)
+	}
+	return f
+}
+
+func schemaString(v interface{}) *string {
+	switch v := v.(type) {
+	case string:
+		return &v
+	case float64:
+		s := strconv.FormatFloat(v, 'f', -1, 64)
+		return &s
+	case json.Number:
+		s := v.String()
+		return &s
+	}
+	return nil
+}
+
+func schemaStrings(v interface{}) []string {
+	var values []string
+	switch v := v.(type) {
+	case []string:
+		values = v
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
+func schemaInt(v interface{}) *int64 {
+	s := schemaString(v)
+	if s == nil {
+		return nil
+	}
+	n, err := strconv.ParseInt(*s, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func schemaFloat(v interface{}) *float64 {
+	s := schemaString(v)
+	if s == nil {
+		return nil
+	}
+	n, err := strconv.ParseFloat(*s, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func schemaBool(v interface{}) *bool {
+	switch v := v.(type) {
+	case bool:
+		return &v
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err == nil {
+			return &b
+		}
+	}
+	return nil
+}
+
+func schemaOptionValues[T ~string](options []T) []string {
+	values := make([]string, len(options))
+	for i, o := range options {
+		values[i] = string(o)
+	}
+	return values
+}
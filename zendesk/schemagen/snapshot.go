@@ -0,0 +1,98 @@
+// Package schemagen generates Go types and constants from the schema of an account:
+// ticket fields, user fields, organization fields, custom statuses and custom objects.
+// The schema is fetched from the account or read from a JSON snapshot, so application
+// code can refer to fields and options with compile-time checks instead of magic numbers.
+//
+// Use it from go generate with the zendesk-schemagen command:
+//
+//	//go:generate go run github.com/nukosuke/go-zendesk/cmd/zendesk-schemagen -snapshot schema.json -o zendesk_schema.go
+package schemagen
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// Snapshot is the schema of an account
+type Snapshot struct {
+	TicketFields       []zendesk.TicketField       `json:"ticket_fields"`
+	UserFields         []zendesk.UserField         `json:"user_fields"`
+	OrganizationFields []zendesk.OrganizationField `json:"organization_fields"`
+	CustomStatuses     []zendesk.CustomStatus      `json:"custom_statuses"`
+	CustomObjects      []CustomObject              `json:"custom_objects"`
+}
+
+// CustomObject is a custom object with its fields
+type CustomObject struct {
+	zendesk.CustomObject
+	Fields []zendesk.CustomObjectField `json:"fields"`
+}
+
+// Fetch reads the schema of the account
+func Fetch(ctx context.Context, api zendesk.API) (Snapshot, error) {
+	var s Snapshot
+
+	ticketFields := api.GetTicketFieldsIterator(ctx, zendesk.NewPaginationOptions())
+	for ticketFields.HasMore() {
+		page, err := ticketFields.GetNext()
+		if err != nil {
+			return s, err
+		}
+		s.TicketFields = append(s.TicketFields, page...)
+	}
+
+	userFields := api.GetUserFieldsIterator(ctx, zendesk.NewPaginationOptions())
+	for userFields.HasMore() {
+		page, err := userFields.GetNext()
+		if err != nil {
+			return s, err
+		}
+		s.UserFields = append(s.UserFields, page...)
+	}
+
+	orgFields := api.GetOrganizationFieldsIterator(ctx, zendesk.NewPaginationOptions())
+	for orgFields.HasMore() {
+		page, err := orgFields.GetNext()
+		if err != nil {
+			return s, err
+		}
+		s.OrganizationFields = append(s.OrganizationFields, page...)
+	}
+
+	var err error
+	s.CustomStatuses, err = api.GetCustomStatuses(ctx, nil)
+	if err != nil {
+		return s, err
+	}
+
+	objects, err := api.ListCustomObjects(ctx)
+	if err != nil {
+		return s, err
+	}
+	for _, o := range objects {
+		fields, err := api.ListCustomObjectFields(ctx, o.Key)
+		if err != nil {
+			return s, err
+		}
+		s.CustomObjects = append(s.CustomObjects, CustomObject{CustomObject: o, Fields: fields})
+	}
+
+	return s, nil
+}
+
+// ReadSnapshot reads a snapshot written by WriteSnapshot
+func ReadSnapshot(r io.Reader) (Snapshot, error) {
+	var s Snapshot
+	err := json.NewDecoder(r).Decode(&s)
+	return s, err
+}
+
+// WriteSnapshot writes the snapshot as JSON
+func WriteSnapshot(w io.Writer, s Snapshot) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
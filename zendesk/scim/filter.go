@@ -0,0 +1,654 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// attrPath is a parsed SCIM attribute path such as "name.givenName",
+// `emails[type eq "work"].value` or
+// "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department"
+type attrPath struct {
+	// urn is the schema of an extension attribute, empty for core attributes
+	urn string
+	// attr is the top level attribute name
+	attr string
+	// filter selects values of a multi-valued attribute
+	filter expr
+	// sub is the sub-attribute name
+	sub string
+}
+
+// coreSchemas are stripped from fully qualified core attribute paths
+var coreSchemas = []string{SchemaUser, SchemaGroup}
+
+func parsePath(s string) (attrPath, error) {
+	var p attrPath
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return p, fmt.Errorf("empty attribute path")
+	}
+
+	if strings.HasPrefix(strings.ToLower(s), "urn:") {
+		head := s
+		if i := strings.Index(head, "["); i >= 0 {
+			head = head[:i]
+		}
+		i := strings.LastIndex(head, ":")
+		p.urn = s[:i]
+		s = s[i+1:]
+		for _, schema := range coreSchemas {
+			if strings.EqualFold(p.urn, schema) {
+				p.urn = ""
+			}
+		}
+	}
+
+	if i := strings.Index(s, "["); i >= 0 {
+		j := strings.LastIndex(s, "]")
+		if j < i {
+			return p, fmt.Errorf("unterminated value filter in %q", s)
+		}
+		f, err := parseFilter(s[i+1 : j])
+		if err != nil {
+			return p, err
+		}
+		p.attr, p.filter = s[:i], f
+		rest := s[j+1:]
+		if rest != "" {
+			if !strings.HasPrefix(rest, ".") {
+				return p, fmt.Errorf("unexpected %q after value filter", rest)
+			}
+			p.sub = rest[1:]
+		}
+	} else if i := strings.Index(s, "."); i >= 0 {
+		p.attr, p.sub = s[:i], s[i+1:]
+	} else {
+		p.attr = s
+	}
+
+	if p.attr == "" || strings.ContainsAny(p.attr+p.sub, " .[]()\"") {
+		return p, fmt.Errorf("invalid attribute path %q", s)
+	}
+	return p, nil
+}
+
+// container returns the object holding the attribute, creating an
+// extension object when create is set
+func (p attrPath) container(res map[string]interface{}, create bool) map[string]interface{} {
+	if p.urn == "" {
+		return res
+	}
+	key, v, ok := lookup(res, p.urn)
+	if m, isMap := v.(map[string]interface{}); ok && isMap {
+		return m
+	}
+	if !create {
+		return nil
+	}
+	m := map[string]interface{}{}
+	res[key] = m
+	return m
+}
+
+// values returns every value the path refers to. Values of multi-valued
+// attributes are flattened.
+func (p attrPath) values(res map[string]interface{}) []interface{} {
+	obj := p.container(res, false)
+	if obj == nil {
+		return nil
+	}
+	_, v, ok := lookup(obj, p.attr)
+	if !ok || v == nil {
+		return nil
+	}
+
+	items, multi := v.([]interface{})
+	if !multi {
+		items = []interface{}{v}
+	}
+
+	var values []interface{}
+	for _, item := range items {
+		m, isMap := item.(map[string]interface{})
+		if p.filter != nil && !(isMap && p.filter.match(m)) {
+			continue
+		}
+		if p.sub == "" {
+			values = append(values, item)
+			continue
+		}
+		if !isMap {
+			continue
+		}
+		if _, sv, ok := lookup(m, p.sub); ok && sv != nil {
+			values = append(values, sv)
+		}
+	}
+	return values
+}
+
+// get returns the single value of the path. The primary value is preferred
+// when the path refers to a multi-valued attribute.
+func (p attrPath) get(res map[string]interface{}) (interface{}, bool) {
+	obj := p.container(res, false)
+	if obj == nil {
+		return nil, false
+	}
+	_, v, ok := lookup(obj, p.attr)
+	if !ok {
+		return nil, false
+	}
+	if p.filter == nil && p.sub == "" {
+		return v, true
+	}
+
+	var candidates []interface{}
+	if items, multi := v.([]interface{}); multi {
+		for _, item := range items {
+			if m, isMap := item.(map[string]interface{}); isMap && (p.filter == nil || p.filter.match(m)) {
+				candidates = append(candidates, m)
+			}
+		}
+	} else if m, isMap := v.(map[string]interface{}); isMap && p.filter == nil {
+		candidates = []interface{}{m}
+	}
+	if len(candidates) == 0 {
+		return nil, false
+	}
+
+	chosen := candidates[0].(map[string]interface{})
+	for _, c := range candidates {
+		if primary, _ := lookupValue(c.(map[string]interface{}), "primary").(bool); primary {
+			chosen = c.(map[string]interface{})
+			break
+		}
+	}
+	if p.sub == "" {
+		return chosen, true
+	}
+	_, sv, ok := lookup(chosen, p.sub)
+	return sv, ok
+}
+
+// set assigns the value to the path. When a value filter matches no value,
+// a new value is created from the equality comparisons of the filter.
+// It returns false when nothing could be assigned.
+func (p attrPath) set(res map[string]interface{}, value interface{}) bool {
+	obj := p.container(res, true)
+	key, v, _ := lookup(obj, p.attr)
+
+	if p.filter == nil {
+		if p.sub == "" {
+			obj[key] = value
+			return true
+		}
+		m, isMap := v.(map[string]interface{})
+		if !isMap {
+			m = map[string]interface{}{}
+			obj[key] = m
+		}
+		subKey, _, _ := lookup(m, p.sub)
+		m[subKey] = value
+		return true
+	}
+
+	items, _ := v.([]interface{})
+	matched := false
+	for i, item := range items {
+		m, isMap := item.(map[string]interface{})
+		if !isMap || !p.filter.match(m) {
+			continue
+		}
+		matched = true
+		if p.sub == "" {
+			items[i] = value
+		} else {
+			subKey, _, _ := lookup(m, p.sub)
+			m[subKey] = value
+		}
+	}
+	if matched {
+		return true
+	}
+
+	m := map[string]interface{}{}
+	if !p.filter.seed(m) {
+		return false
+	}
+	if p.sub == "" {
+		vm, isMap := value.(map[string]interface{})
+		if !isMap {
+			return false
+		}
+		for k, v := range vm {
+			m[k] = v
+		}
+	} else {
+		m[p.sub] = value
+	}
+	obj[key] = append(items, m)
+	return true
+}
+
+// remove deletes the values the path refers to. It returns false when
+// the path does not refer to any value.
+func (p attrPath) remove(res map[string]interface{}) bool {
+	obj := p.container(res, false)
+	if obj == nil {
+		return false
+	}
+	key, v, ok := lookup(obj, p.attr)
+	if !ok {
+		return false
+	}
+
+	if p.filter == nil {
+		if p.sub == "" {
+			delete(obj, key)
+			return true
+		}
+		m, isMap := v.(map[string]interface{})
+		if !isMap {
+			return false
+		}
+		subKey, _, ok := lookup(m, p.sub)
+		delete(m, subKey)
+		return ok
+	}
+
+	items, _ := v.([]interface{})
+	kept := make([]interface{}, 0, len(items))
+	removed := false
+	for _, item := range items {
+		m, isMap := item.(map[string]interface{})
+		if !isMap || !p.filter.match(m) {
+			kept = append(kept, item)
+			continue
+		}
+		removed = true
+		if p.sub != "" {
+			subKey, _, _ := lookup(m, p.sub)
+			delete(m, subKey)
+			kept = append(kept, m)
+		}
+	}
+	obj[key] = kept
+	return removed
+}
+
+// lookup finds the key of an object case-insensitively as SCIM attribute
+// names are case-insensitive. The key is returned as given when it is absent.
+func lookup(obj map[string]interface{}, key string) (string, interface{}, bool) {
+	if v, ok := obj[key]; ok {
+		return key, v, true
+	}
+	for k, v := range obj {
+		if strings.EqualFold(k, key) {
+			return k, v, true
+		}
+	}
+	return key, nil, false
+}
+
+func lookupValue(obj map[string]interface{}, key string) interface{} {
+	_, v, _ := lookup(obj, key)
+	return v
+}
+
+// expr is a node of a parsed SCIM filter
+type expr interface {
+	match(res map[string]interface{}) bool
+	// seed fills the attributes which equality comparisons of the
+	// expression require into a new value of a multi-valued attribute
+	seed(m map[string]interface{}) bool
+}
+
+type logicalExpr struct {
+	and         bool
+	left, right expr
+}
+
+func (e logicalExpr) match(res map[string]interface{}) bool {
+	if e.and {
+		return e.left.match(res) && e.right.match(res)
+	}
+	return e.left.match(res) || e.right.match(res)
+}
+
+func (e logicalExpr) seed(m map[string]interface{}) bool {
+	if e.and {
+		return e.left.seed(m) && e.right.seed(m)
+	}
+	return false
+}
+
+type notExpr struct {
+	e expr
+}
+
+func (e notExpr) match(res map[string]interface{}) bool {
+	return !e.e.match(res)
+}
+
+func (e notExpr) seed(map[string]interface{}) bool {
+	return false
+}
+
+type compareExpr struct {
+	path  attrPath
+	op    string
+	value interface{}
+}
+
+func (e compareExpr) match(res map[string]interface{}) bool {
+	values := e.path.values(res)
+	if e.op == "pr" {
+		for _, v := range values {
+			if s, ok := v.(string); !ok || s != "" {
+				return true
+			}
+		}
+		return false
+	}
+	if e.op == "ne" {
+		return !(compareExpr{e.path, "eq", e.value}).match(res)
+	}
+
+	for _, v := range values {
+		if compare(v, e.op, e.value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e compareExpr) seed(m map[string]interface{}) bool {
+	if e.op != "eq" || e.path.urn != "" || e.path.filter != nil || e.path.sub != "" {
+		return false
+	}
+	m[e.path.attr] = e.value
+	return true
+}
+
+// valuePathExpr matches when any value of a multi-valued attribute matches
+// the filter, e.g. `emails[type eq "work" and value co "@example.com"]`
+type valuePathExpr struct {
+	path attrPath
+}
+
+func (e valuePathExpr) match(res map[string]interface{}) bool {
+	return len(e.path.values(res)) > 0
+}
+
+func (e valuePathExpr) seed(map[string]interface{}) bool {
+	return false
+}
+
+// compare applies a SCIM comparison operator. Strings are compared
+// case-insensitively.
+func compare(actual interface{}, op string, expected interface{}) bool {
+	switch a := actual.(type) {
+	case string:
+		e, ok := expected.(string)
+		if !ok {
+			return false
+		}
+		a, e = strings.ToLower(a), strings.ToLower(e)
+		switch op {
+		case "eq":
+			return a == e
+		case "co":
+			return strings.Contains(a, e)
+		case "sw":
+			return strings.HasPrefix(a, e)
+		case "ew":
+			return strings.HasSuffix(a, e)
+		case "gt":
+			return a > e
+		case "ge":
+			return a >= e
+		case "lt":
+			return a < e
+		case "le":
+			return a <= e
+		}
+	case bool:
+		e, ok := expected.(bool)
+		return ok && op == "eq" && a == e
+	case nil:
+		return op == "eq" && expected == nil
+	default:
+		a1, ok1 := toFloat(actual)
+		e1, ok2 := toFloat(expected)
+		if !ok1 || !ok2 {
+			return false
+		}
+		switch op {
+		case "eq":
+			return a1 == e1
+		case "gt":
+			return a1 > e1
+		case "ge":
+			return a1 >= e1
+		case "lt":
+			return a1 < e1
+		case "le":
+			return a1 <= e1
+		}
+	}
+	return false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// parseFilter parses a SCIM filter expression (RFC 7644 section 3.4.2.2)
+func parseFilter(s string) (expr, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in filter", p.tokens[p.pos].text)
+	}
+	return e, nil
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, token{text: string(c)})
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(s); j++ {
+				if s[j] == '\\' {
+					j++
+				} else if s[j] == '"' {
+					break
+				}
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string in filter")
+			}
+			var text string
+			if err := json.Unmarshal([]byte(s[i:j+1]), &text); err != nil {
+				return nil, fmt.Errorf("invalid string in filter: %w", err)
+			}
+			tokens = append(tokens, token{text: text, quoted: true})
+			i = j + 1
+		default:
+			// a word runs until whitespace or a parenthesis; brackets of
+			// value paths are kept within the word
+			j, depth := i, 0
+			for ; j < len(s); j++ {
+				if s[j] == '[' {
+					depth++
+				} else if s[j] == ']' {
+					depth--
+				} else if s[j] == '"' && depth > 0 {
+					for j++; j < len(s) && s[j] != '"'; j++ {
+						if s[j] == '\\' {
+							j++
+						}
+					}
+				} else if depth == 0 && (s[j] == ' ' || s[j] == '(' || s[j] == ')') {
+					break
+				}
+			}
+			tokens = append(tokens, token{text: s[i:j]})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *filterParser) peekKeyword(kw string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && strings.EqualFold(p.tokens[p.pos].text, kw)
+}
+
+func (p *filterParser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalExpr{and: false, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalExpr{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (expr, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of filter")
+	}
+
+	if p.peekKeyword("not") {
+		p.pos++
+		e, err := p.parseGroup()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{e}, nil
+	}
+	if p.tokens[p.pos].text == "(" && !p.tokens[p.pos].quoted {
+		return p.parseGroup()
+	}
+
+	path, err := parsePath(p.tokens[p.pos].text)
+	if err != nil {
+		return nil, err
+	}
+	p.pos++
+
+	if path.filter != nil && path.sub == "" {
+		return valuePathExpr{path}, nil
+	}
+
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("missing operator after %q", p.tokens[p.pos-1].text)
+	}
+	op := strings.ToLower(p.tokens[p.pos].text)
+	p.pos++
+	switch op {
+	case "pr":
+		return compareExpr{path: path, op: op}, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, fmt.Errorf("unknown operator %q", op)
+	}
+
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("missing value after %q", op)
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	if t.quoted {
+		return compareExpr{path, op, t.text}, nil
+	}
+	switch strings.ToLower(t.text) {
+	case "true":
+		return compareExpr{path, op, true}, nil
+	case "false":
+		return compareExpr{path, op, false}, nil
+	case "null":
+		return compareExpr{path, op, nil}, nil
+	}
+	n, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q", t.text)
+	}
+	return compareExpr{path, op, n}, nil
+}
+
+func (p *filterParser) parseGroup() (expr, error) {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].text != "(" || p.tokens[p.pos].quoted {
+		return nil, fmt.Errorf("expected ( in filter")
+	}
+	p.pos++
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].text != ")" || p.tokens[p.pos].quoted {
+		return nil, fmt.Errorf("expected ) in filter")
+	}
+	p.pos++
+	return e, nil
+}
@@ -0,0 +1,144 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func testResource(t *testing.T) map[string]interface{} {
+	t.Helper()
+
+	var res map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"userName": "Alice@example.com",
+		"name": {"givenName": "Alice", "familyName": "Smith"},
+		"active": true,
+		"emails": [
+			{"value": "alice@example.com", "type": "work", "primary": true},
+			{"value": "alice@home.example", "type": "home"}
+		],
+		"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"department": "Support"},
+		"meta": {"lastModified": "2024-05-01T00:00:00Z"}
+	}`), &res)
+	if err != nil {
+		t.Fatalf("Failed to decode resource: %s", err)
+	}
+	return res
+}
+
+func TestParseFilter(t *testing.T) {
+	res := testResource(t)
+
+	cases := []struct {
+		filter string
+		want   bool
+	}{
+		{`userName eq "alice@example.com"`, true},
+		{`USERNAME Eq "alice@example.com"`, true},
+		{`userName ne "alice@example.com"`, false},
+		{`name.familyName sw "smi"`, true},
+		{`name.familyName co "mit" and active eq true`, true},
+		{`name.familyName ew "x" or active eq false`, false},
+		{`not (active eq false)`, true},
+		{`emails[type eq "work" and value co "@example.com"]`, true},
+		{`emails[type eq "other"]`, false},
+		{`emails.value eq "alice@home.example"`, true},
+		{`emails[type eq "home"].value eq "alice@home.example"`, true},
+		{`title pr`, false},
+		{`name pr`, true},
+		{`urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department eq "support"`, true},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName sw "alice"`, true},
+		{`meta.lastModified gt "2024-01-01T00:00:00Z"`, true},
+		{`(active eq true or userName eq "x") and name.givenName eq "Bob"`, false},
+	}
+	for _, c := range cases {
+		f, err := parseFilter(c.filter)
+		if err != nil {
+			t.Fatalf("Failed to parse filter %s: %s", c.filter, err)
+		}
+		if got := f.match(res); got != c.want {
+			t.Fatalf("%s: expected %v, but got %v", c.filter, c.want, got)
+		}
+	}
+
+	for _, invalid := range []string{
+		`userName`,
+		`userName eq`,
+		`userName foo "x"`,
+		`(userName eq "x"`,
+		`userName eq "x`,
+		`userName eq x`,
+	} {
+		if _, err := parseFilter(invalid); err == nil {
+			t.Fatalf("Invalid filter %s should not be parsed", invalid)
+		}
+	}
+}
+
+func TestAttrPathSet(t *testing.T) {
+	res := testResource(t)
+
+	set := func(path string, v interface{}) {
+		p, err := parsePath(path)
+		if err != nil {
+			t.Fatalf("Failed to parse path %s: %s", path, err)
+		}
+		if !p.set(res, v) {
+			t.Fatalf("Failed to set %s", path)
+		}
+	}
+	set(`emails[type eq "work"].value`, "alice@corp.example")
+	set(`phoneNumbers[type eq "work"].value`, "+81 3 0000 0000")
+	set("urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:costCenter", "42")
+	set("name.middleName", "B")
+
+	if v := pathValue(res, "emails.value"); v != "alice@corp.example" {
+		t.Fatalf("Primary email should be updated, but got %v", v)
+	}
+	if v := pathValue(res, `phoneNumbers[type eq "work"].value`); v != "+81 3 0000 0000" {
+		t.Fatalf("Phone number should be created from the filter, but got %v", v)
+	}
+	ext := res["urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"].(map[string]interface{})
+	if ext["costCenter"] != "42" || ext["department"] != "Support" {
+		t.Fatalf("Extension attributes are not set %v", ext)
+	}
+
+	p, _ := parsePath(`emails[type eq "home"]`)
+	if !p.remove(res) || len(res["emails"].([]interface{})) != 1 {
+		t.Fatalf("Home email should be removed %v", res["emails"])
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	res := testResource(t)
+
+	var ops []patchOp
+	err := json.Unmarshal([]byte(`[
+		{"op": "replace", "value": {"active": false, "name.givenName": "Alicia"}},
+		{"op": "add", "path": "emails", "value": [{"value": "a@other.example", "type": "other"}]},
+		{"op": "replace", "value": {"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"division": "EMEA"}}},
+		{"op": "remove", "path": "emails[type eq \"home\"]"}
+	]`), &ops)
+	if err != nil {
+		t.Fatalf("Failed to decode operations: %s", err)
+	}
+
+	if err := applyPatch(res, ops); err != nil {
+		t.Fatalf("Failed to apply patch: %s", err)
+	}
+	if res["active"] != false || pathValue(res, "name.givenName") != "Alicia" {
+		t.Fatalf("Attributes are not replaced %v", res)
+	}
+	if emails := res["emails"].([]interface{}); len(emails) != 2 {
+		t.Fatalf("Expected 2 emails, but got %v", emails)
+	}
+	ext := res["urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"].(map[string]interface{})
+	if ext["division"] != "EMEA" || ext["department"] != "Support" {
+		t.Fatalf("Extension attributes are not merged %v", ext)
+	}
+
+	err = applyPatch(res, []patchOp{{Op: "replace", Path: `emails[type eq "fax"]`, Value: "x"}})
+	if serr, ok := err.(scimError); !ok || serr.scimType != scimTypeNoTarget {
+		t.Fatalf("Expected noTarget error, but got %v", err)
+	}
+}
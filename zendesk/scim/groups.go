@@ -0,0 +1,728 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// groupRef identifies the Zendesk resource of a SCIM group. SCIM group IDs
+// are the kind and the Zendesk ID joined by a hyphen, e.g. "group-123".
+type groupRef struct {
+	kind GroupKind
+	id   int64
+}
+
+func (g groupRef) String() string {
+	return string(g.kind) + "-" + strconv.FormatInt(g.id, 10)
+}
+
+func (s *Server) parseGroupID(v string) (groupRef, bool) {
+	i := strings.LastIndex(v, "-")
+	if i < 0 {
+		return groupRef{}, false
+	}
+	id, err := strconv.ParseInt(v[i+1:], 10, 64)
+	if err != nil {
+		return groupRef{}, false
+	}
+	ref := groupRef{kind: GroupKind(v[:i]), id: id}
+	return ref, s.exposes(ref.kind)
+}
+
+func (s *Server) exposes(kind GroupKind) bool {
+	for _, k := range s.groupKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// group is a Zendesk group or organization
+type group struct {
+	ref        groupRef
+	name       string
+	externalID string
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+func (s *Server) serveGroups(r *http.Request, rest []string) (int, interface{}, error) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			return s.listGroups(r)
+		case http.MethodPost:
+			return s.createGroup(r)
+		}
+		return 0, nil, scimError{status: http.StatusMethodNotAllowed, detail: "method not allowed"}
+	}
+
+	ref, ok := s.parseGroupID(rest[0])
+	if !ok {
+		return 0, nil, notFound("Group", rest[0])
+	}
+	switch r.Method {
+	case http.MethodGet:
+		g, err := s.getGroup(r.Context(), ref)
+		if err != nil {
+			return 0, nil, err
+		}
+		members, err := s.memberships(r.Context(), ref)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, s.groupResource(g, members), nil
+	case http.MethodPut:
+		return s.replaceGroup(r, ref)
+	case http.MethodPatch:
+		return s.patchGroup(r, ref)
+	case http.MethodDelete:
+		var err error
+		if ref.kind == GroupKindOrganization {
+			err = s.api.DeleteOrganization(r.Context(), ref.id)
+		} else {
+			err = s.api.DeleteGroup(r.Context(), ref.id)
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusNoContent, nil, nil
+	}
+	return 0, nil, scimError{status: http.StatusMethodNotAllowed, detail: "method not allowed"}
+}
+
+// listGroups lists the groups of every exposed kind. Lists without a
+// filter are paged through the Zendesk API. Filters are answered with the
+// few groups they can match, so equality filters on the ID, externalId
+// and displayName and filters on the value of members are supported.
+// Members are only fetched for the groups of the page when the filter or
+// the requested attributes refer to them, as it takes a request per group.
+func (s *Server) listGroups(r *http.Request) (int, interface{}, error) {
+	q, err := s.parseListQuery(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	withMembers := strings.Contains(strings.ToLower(r.URL.Query().Get("filter")), "members") ||
+		strings.Contains(strings.ToLower(q.attributes), "members")
+
+	var (
+		groups []group
+		total  int
+	)
+	if q.filter == nil {
+		groups, total, err = s.pageGroups(r.Context(), q)
+	} else {
+		groups, err = s.findGroups(r.Context(), q.filter)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	resources := make([]map[string]interface{}, len(groups))
+	for i, g := range groups {
+		var members map[int64]int64
+		if withMembers {
+			if members, err = s.memberships(r.Context(), g.ref); err != nil {
+				return 0, nil, err
+			}
+		}
+		resources[i] = s.groupResource(g, members)
+	}
+	if q.filter == nil {
+		return http.StatusOK, pagedResponse(resources, total, q), nil
+	}
+	return http.StatusOK, listResponse(resources, q), nil
+}
+
+// pageGroups fetches the groups of a list page and the number of groups.
+// The kinds are listed one after another, so a page may hold the last
+// groups of one kind and the first of the next.
+func (s *Server) pageGroups(ctx context.Context, q listQuery) ([]group, int, error) {
+	var (
+		groups []group
+		total  int
+	)
+	for _, kind := range s.groupKinds {
+		sub := listQuery{startIndex: 1, count: q.count - len(groups)}
+		if start := q.startIndex - total; start > 1 {
+			sub.startIndex = start
+		}
+		found, n, err := s.pageKind(ctx, kind, sub)
+		if err != nil {
+			return nil, 0, err
+		}
+		groups = append(groups, found...)
+		total += n
+	}
+	return groups, total, nil
+}
+
+func (s *Server) pageKind(ctx context.Context, kind GroupKind, q listQuery) ([]group, int, error) {
+	var groups []group
+	if kind == GroupKindOrganization {
+		orgs, total, err := offsetPage(q, func(page, perPage int) ([]zendesk.Organization, int64, error) {
+			orgs, p, err := s.api.GetOrganizations(ctx, &zendesk.OrganizationListOptions{
+				PageOptions: zendesk.PageOptions{Page: page, PerPage: perPage},
+			})
+			return orgs, p.Count, err
+		})
+		for _, o := range orgs {
+			groups = append(groups, organizationGroup(o))
+		}
+		return groups, total, err
+	}
+
+	found, total, err := offsetPage(q, func(page, perPage int) ([]zendesk.Group, int64, error) {
+		found, p, err := s.api.GetGroups(ctx, &zendesk.GroupListOptions{
+			PageOptions: zendesk.PageOptions{Page: page, PerPage: perPage},
+		})
+		return found, p.Count, err
+	})
+	for _, g := range found {
+		groups = append(groups, zendeskGroup(g))
+	}
+	return groups, total, err
+}
+
+// findGroups fetches the candidates of a filter, which are then matched by
+// listResponse. Filters which could only be answered by listing every
+// group are rejected.
+func (s *Server) findGroups(ctx context.Context, filter expr) ([]group, error) {
+	var (
+		path  attrPath
+		value interface{}
+	)
+	switch e := filter.(type) {
+	case compareExpr:
+		if e.op == "eq" && e.path.filter == nil {
+			path, value = e.path, e.value
+		}
+	case valuePathExpr:
+		// members[value eq "1"]
+		if cmp, ok := e.path.filter.(compareExpr); ok && cmp.op == "eq" && cmp.path.sub == "" {
+			path, value = attrPath{attr: e.path.attr, sub: cmp.path.attr}, cmp.value
+		}
+	}
+	v, isString := value.(string)
+	if !isString || path.urn != "" {
+		return nil, badRequest(scimTypeInvalidFilter, "only eq filters on id, externalId, displayName and members are supported for groups")
+	}
+
+	switch attr := strings.ToLower(path.attr); {
+	case attr == "id" && path.sub == "":
+		ref, ok := s.parseGroupID(v)
+		if !ok {
+			return nil, nil
+		}
+		g, err := s.getGroup(ctx, ref)
+		if err != nil {
+			if upstreamError(err).status == http.StatusNotFound {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return []group{g}, nil
+	case attr == "externalid" && path.sub == "":
+		// only organizations keep an external ID
+		if !s.exposes(GroupKindOrganization) {
+			return nil, nil
+		}
+		orgs, _, err := s.api.GetOrganizationByExternalID(ctx, v)
+		groups := make([]group, len(orgs))
+		for i, o := range orgs {
+			groups[i] = organizationGroup(o)
+		}
+		return groups, err
+	case attr == "displayname" && path.sub == "":
+		return s.searchGroups(ctx, v)
+	case attr == "members" && strings.EqualFold(path.sub, "value"):
+		userID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, nil
+		}
+		return s.userGroups(ctx, userID)
+	}
+	return nil, badRequest(scimTypeInvalidFilter, "only eq filters on id, externalId, displayName and members are supported for groups")
+}
+
+// searchGroups searches the groups of the exposed kinds by name
+func (s *Server) searchGroups(ctx context.Context, name string) ([]group, error) {
+	var groups []group
+	for _, kind := range s.groupKinds {
+		query := "type:" + string(kind) + ` name:"` + strings.ReplaceAll(name, `"`, "") + `"`
+		results, _, err := s.api.Search(ctx, &zendesk.SearchOptions{Query: query})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range results.List() {
+			switch v := r.(type) {
+			case zendesk.Group:
+				groups = append(groups, zendeskGroup(v))
+			case zendesk.Organization:
+				groups = append(groups, organizationGroup(v))
+			}
+		}
+	}
+	return groups, nil
+}
+
+// userGroups returns the groups of the exposed kinds which the user is a member of
+func (s *Server) userGroups(ctx context.Context, userID int64) ([]group, error) {
+	var refs []groupRef
+	opts := zendesk.NewPaginationOptions()
+	opts.UserID = userID
+	for _, kind := range s.groupKinds {
+		if kind == GroupKindOrganization {
+			it := s.api.GetOrganizationMembershipsIterator(ctx, opts)
+			for it.HasMore() {
+				page, err := it.GetNext()
+				if err != nil {
+					return nil, err
+				}
+				for _, m := range page {
+					if m.UserID == userID {
+						refs = append(refs, groupRef{kind: kind, id: m.OrganizationID})
+					}
+				}
+			}
+			continue
+		}
+
+		it := s.api.GetGroupMembershipsIterator(ctx, opts)
+		for it.HasMore() {
+			page, err := it.GetNext()
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range page {
+				if m.UserID == userID {
+					refs = append(refs, groupRef{kind: kind, id: m.GroupID})
+				}
+			}
+		}
+	}
+
+	groups := make([]group, 0, len(refs))
+	for _, ref := range refs {
+		g, err := s.getGroup(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+func zendeskGroup(g zendesk.Group) group {
+	return group{
+		ref:       groupRef{kind: GroupKindGroup, id: g.ID},
+		name:      g.Name,
+		createdAt: g.CreatedAt,
+		updatedAt: g.UpdatedAt,
+	}
+}
+
+func organizationGroup(o zendesk.Organization) group {
+	return group{
+		ref:        groupRef{kind: GroupKindOrganization, id: o.ID},
+		name:       o.Name,
+		externalID: o.ExternalID,
+		createdAt:  o.CreatedAt,
+		updatedAt:  o.UpdatedAt,
+	}
+}
+
+func (s *Server) getGroup(ctx context.Context, ref groupRef) (group, error) {
+	if ref.kind == GroupKindOrganization {
+		o, err := s.api.GetOrganization(ctx, ref.id)
+		return organizationGroup(o), err
+	}
+	g, err := s.api.GetGroup(ctx, ref.id)
+	return zendeskGroup(g), err
+}
+
+// groupResource converts a group into a SCIM group resource. Members are
+// omitted when members is nil.
+func (s *Server) groupResource(g group, members map[int64]int64) map[string]interface{} {
+	res := map[string]interface{}{
+		"schemas":     []interface{}{SchemaGroup},
+		"id":          g.ref.String(),
+		"displayName": g.name,
+		"meta":        s.meta("Group", "/Groups/"+g.ref.String(), g.createdAt, g.updatedAt),
+	}
+	if g.externalID != "" {
+		res["externalId"] = g.externalID
+	}
+	if members != nil {
+		ids := make([]int64, 0, len(members))
+		for id := range members {
+			ids = append(ids, id)
+		}
+		sortIDs(ids)
+
+		values := make([]interface{}, len(ids))
+		for i, id := range ids {
+			m := map[string]interface{}{
+				"value": strconv.FormatInt(id, 10),
+				"type":  "User",
+			}
+			if s.baseURL != "" {
+				m["$ref"] = s.baseURL + "/Users/" + strconv.FormatInt(id, 10)
+			}
+			values[i] = m
+		}
+		res["members"] = values
+	}
+	return res
+}
+
+// memberships returns the membership IDs of the group keyed by user ID
+func (s *Server) memberships(ctx context.Context, ref groupRef) (map[int64]int64, error) {
+	members := map[int64]int64{}
+	opts := zendesk.NewPaginationOptions()
+
+	if ref.kind == GroupKindOrganization {
+		opts.OrganizationID = ref.id
+		it := s.api.GetOrganizationMembershipsIterator(ctx, opts)
+		for it.HasMore() {
+			page, err := it.GetNext()
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range page {
+				if m.OrganizationID == ref.id {
+					members[m.UserID] = m.ID
+				}
+			}
+		}
+		return members, nil
+	}
+
+	opts.GroupID = ref.id
+	it := s.api.GetGroupMembershipsIterator(ctx, opts)
+	for it.HasMore() {
+		page, err := it.GetNext()
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range page {
+			if m.GroupID == ref.id {
+				members[m.UserID] = m.ID
+			}
+		}
+	}
+	return members, nil
+}
+
+// addMember creates a membership and returns its ID
+func (s *Server) addMember(ctx context.Context, ref groupRef, userID int64) (int64, error) {
+	if ref.kind == GroupKindOrganization {
+		m, err := s.api.CreateOrganizationMembership(ctx, zendesk.OrganizationMembershipOptions{
+			OrganizationID: ref.id,
+			UserID:         userID,
+		})
+		return m.ID, err
+	}
+	m, err := s.api.CreateGroupMembership(ctx, zendesk.GroupMembership{GroupID: ref.id, UserID: userID})
+	return m.ID, err
+}
+
+func (s *Server) removeMember(ctx context.Context, ref groupRef, membershipID int64) error {
+	if ref.kind == GroupKindOrganization {
+		return s.api.DeleteOrganizationMembership(ctx, membershipID)
+	}
+	return s.api.DeleteGroupMembership(ctx, membershipID)
+}
+
+// syncMembers adds and removes memberships so that the group has exactly
+// the users. current is updated with the applied changes.
+func (s *Server) syncMembers(ctx context.Context, ref groupRef, current map[int64]int64, userIDs []int64) error {
+	want := map[int64]bool{}
+	for _, id := range userIDs {
+		want[id] = true
+		if _, ok := current[id]; ok {
+			continue
+		}
+		membershipID, err := s.addMember(ctx, ref, id)
+		if err != nil {
+			return err
+		}
+		current[id] = membershipID
+	}
+	for userID, membershipID := range current {
+		if want[userID] {
+			continue
+		}
+		if err := s.removeMember(ctx, ref, membershipID); err != nil {
+			return err
+		}
+		delete(current, userID)
+	}
+	return nil
+}
+
+func (s *Server) createGroup(r *http.Request) (int, interface{}, error) {
+	res, err := decodeResource(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	name, _ := toString(lookupValue(res, "displayName"))
+	if name == "" {
+		return 0, nil, badRequest(scimTypeInvalidValue, "displayName is required")
+	}
+	userIDs, err := memberIDs(lookupValue(res, "members"))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	kind := s.mapGroup(name)
+	if !s.exposes(kind) {
+		return 0, nil, badRequest(scimTypeInvalidValue, "group %q is mapped to %q which is not exposed", name, kind)
+	}
+
+	var g group
+	if kind == GroupKindOrganization {
+		externalID, _ := toString(lookupValue(res, "externalId"))
+		o, err := s.api.CreateOrganization(r.Context(), zendesk.Organization{Name: name, ExternalID: externalID})
+		if err != nil {
+			return 0, nil, err
+		}
+		g = organizationGroup(o)
+	} else {
+		created, err := s.api.CreateGroup(r.Context(), zendesk.Group{Name: name})
+		if err != nil {
+			return 0, nil, err
+		}
+		g = zendeskGroup(created)
+	}
+
+	members := map[int64]int64{}
+	if err := s.syncMembers(r.Context(), g.ref, members, userIDs); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, s.groupResource(g, members), nil
+}
+
+func (s *Server) replaceGroup(r *http.Request, ref groupRef) (int, interface{}, error) {
+	res, err := decodeResource(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	name, _ := toString(lookupValue(res, "displayName"))
+	if name == "" {
+		return 0, nil, badRequest(scimTypeInvalidValue, "displayName is required")
+	}
+	userIDs, err := memberIDs(lookupValue(res, "members"))
+	if err != nil {
+		return 0, nil, err
+	}
+	externalID, _ := toString(lookupValue(res, "externalId"))
+
+	g, err := s.updateGroup(r.Context(), ref, name, externalID)
+	if err != nil {
+		return 0, nil, err
+	}
+	current, err := s.memberships(r.Context(), ref)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := s.syncMembers(r.Context(), ref, current, userIDs); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, s.groupResource(g, current), nil
+}
+
+// updateGroup renames the group. The external ID is only kept by organizations.
+func (s *Server) updateGroup(ctx context.Context, ref groupRef, name, externalID string) (group, error) {
+	if ref.kind == GroupKindOrganization {
+		// the payload of an organization sends its domains, tags and sharing
+		// settings even when empty, so they are kept from the current one
+		o, err := s.api.GetOrganization(ctx, ref.id)
+		if err != nil {
+			return group{}, err
+		}
+		o.Name, o.ExternalID = name, externalID
+		o, err = s.api.UpdateOrganization(ctx, ref.id, o)
+		return organizationGroup(o), err
+	}
+	g, err := s.api.UpdateGroup(ctx, ref.id, zendesk.Group{Name: name})
+	return zendeskGroup(g), err
+}
+
+// patchGroup applies PATCH operations to the display name and members of
+// the group. It answers 204 No Content as identity providers do not
+// request the group back.
+func (s *Server) patchGroup(r *http.Request, ref groupRef) (int, interface{}, error) {
+	ops, err := decodePatch(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var (
+		name, externalID string
+		renamed          bool
+		current          map[int64]int64
+	)
+	members := func() (map[int64]int64, error) {
+		if current == nil {
+			current, err = s.memberships(r.Context(), ref)
+		}
+		return current, err
+	}
+
+	for _, op := range ops {
+		values := map[string]interface{}{op.Path: op.Value}
+		if op.Path == "" {
+			v, ok := op.Value.(map[string]interface{})
+			if !ok || op.Op == "remove" {
+				return 0, nil, badRequest(scimTypeNoTarget, "path is required")
+			}
+			values = v
+		}
+
+		for p, v := range values {
+			path, err := parsePath(p)
+			if err != nil {
+				return 0, nil, badRequest(scimTypeInvalidPath, "%s", err)
+			}
+
+			switch strings.ToLower(path.attr) {
+			case "displayname":
+				if op.Op == "remove" {
+					return 0, nil, badRequest(scimTypeInvalidValue, "displayName is required")
+				}
+				name, _ = toString(v)
+				renamed = true
+			case "externalid":
+				externalID, _ = toString(v)
+				renamed = true
+			case "members":
+				current, err := members()
+				if err != nil {
+					return 0, nil, err
+				}
+				if err := s.patchMembers(r.Context(), ref, current, op.Op, path, v); err != nil {
+					return 0, nil, err
+				}
+			default:
+				return 0, nil, badRequest(scimTypeInvalidPath, "unsupported attribute %q", p)
+			}
+		}
+	}
+
+	if renamed {
+		if name == "" || externalID == "" {
+			g, err := s.getGroup(r.Context(), ref)
+			if err != nil {
+				return 0, nil, err
+			}
+			if name == "" {
+				name = g.name
+			}
+			if externalID == "" {
+				externalID = g.externalID
+			}
+		}
+		if _, err := s.updateGroup(r.Context(), ref, name, externalID); err != nil {
+			return 0, nil, err
+		}
+	}
+	return http.StatusNoContent, nil, nil
+}
+
+// patchMembers applies an operation on the members attribute. current is
+// kept up to date with the applied changes.
+func (s *Server) patchMembers(ctx context.Context, ref groupRef, current map[int64]int64, op string, path attrPath, v interface{}) error {
+	switch op {
+	case "add":
+		userIDs, err := memberIDs(v)
+		if err != nil {
+			return err
+		}
+		for _, id := range userIDs {
+			if _, ok := current[id]; ok {
+				continue
+			}
+			membershipID, err := s.addMember(ctx, ref, id)
+			if err != nil {
+				return err
+			}
+			current[id] = membershipID
+		}
+	case "replace":
+		userIDs, err := memberIDs(v)
+		if err != nil {
+			return err
+		}
+		return s.syncMembers(ctx, ref, current, userIDs)
+	case "remove":
+		var remove []int64
+		switch {
+		case path.filter != nil:
+			for id := range current {
+				if path.filter.match(map[string]interface{}{"value": strconv.FormatInt(id, 10)}) {
+					remove = append(remove, id)
+				}
+			}
+		case v != nil:
+			userIDs, err := memberIDs(v)
+			if err != nil {
+				return err
+			}
+			remove = userIDs
+		default:
+			for id := range current {
+				remove = append(remove, id)
+			}
+		}
+
+		for _, id := range remove {
+			membershipID, ok := current[id]
+			if !ok {
+				continue
+			}
+			if err := s.removeMember(ctx, ref, membershipID); err != nil {
+				return err
+			}
+			delete(current, id)
+		}
+	}
+	return nil
+}
+
+// memberIDs reads the user IDs of a members value
+func memberIDs(v interface{}) ([]int64, error) {
+	if v == nil {
+		return nil, nil
+	}
+	items, ok := v.([]interface{})
+	if !ok {
+		items = []interface{}{v}
+	}
+
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, badRequest(scimTypeInvalidValue, "members must be objects with a value")
+		}
+		s, _ := toString(lookupValue(m, "value"))
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, badRequest(scimTypeInvalidValue, "invalid member %q", s)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func sortIDs(ids []int64) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+}
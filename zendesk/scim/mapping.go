@@ -0,0 +1,264 @@
+package scim
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// Attribute maps a SCIM user attribute onto an attribute of zendesk.User
+type Attribute struct {
+	// SCIM is the attribute path, e.g. "userName", "name.formatted",
+	// `emails[type eq "work"].value` or an extension attribute such as
+	// "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department"
+	SCIM string
+
+	// Zendesk is one of "name", "email", "external_id", "alias", "phone",
+	// "locale", "time_zone", "iana_time_zone", "notes", "details", "role",
+	// "signature", "tags", "organization_id", "default_group_id" or
+	// "user_fields.<key>" for a custom user field
+	Zendesk string
+}
+
+// DefaultUserMapping is used when Config.UserMapping is empty
+var DefaultUserMapping = []Attribute{
+	{SCIM: "externalId", Zendesk: "external_id"},
+	{SCIM: "userName", Zendesk: "email"},
+	{SCIM: "name.formatted", Zendesk: "name"},
+	{SCIM: "displayName", Zendesk: "name"},
+	{SCIM: "nickName", Zendesk: "alias"},
+	{SCIM: `phoneNumbers[type eq "work"].value`, Zendesk: "phone"},
+	{SCIM: "locale", Zendesk: "locale"},
+	{SCIM: "timezone", Zendesk: "iana_time_zone"},
+}
+
+// userAttribute reads and writes an attribute of zendesk.User
+type userAttribute struct {
+	get func(u zendesk.User) interface{}
+	set func(u *zendesk.User, v interface{}) error
+}
+
+func stringAttribute(field func(u *zendesk.User) *string) userAttribute {
+	return userAttribute{
+		get: func(u zendesk.User) interface{} {
+			if s := *field(&u); s != "" {
+				return s
+			}
+			return nil
+		},
+		set: func(u *zendesk.User, v interface{}) error {
+			s, err := toString(v)
+			*field(u) = s
+			return err
+		},
+	}
+}
+
+func idAttribute(field func(u *zendesk.User) *int64) userAttribute {
+	return userAttribute{
+		get: func(u zendesk.User) interface{} {
+			if id := *field(&u); id != 0 {
+				return strconv.FormatInt(id, 10)
+			}
+			return nil
+		},
+		set: func(u *zendesk.User, v interface{}) error {
+			s, err := toString(v)
+			if err != nil || s == "" {
+				*field(u) = 0
+				return err
+			}
+			id, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid ID %q", s)
+			}
+			*field(u) = id
+			return nil
+		},
+	}
+}
+
+var userAttributes = map[string]userAttribute{
+	"name":             stringAttribute(func(u *zendesk.User) *string { return &u.Name }),
+	"email":            stringAttribute(func(u *zendesk.User) *string { return &u.Email }),
+	"external_id":      stringAttribute(func(u *zendesk.User) *string { return &u.ExternalID }),
+	"alias":            stringAttribute(func(u *zendesk.User) *string { return &u.Alias }),
+	"phone":            stringAttribute(func(u *zendesk.User) *string { return &u.Phone }),
+	"locale":           stringAttribute(func(u *zendesk.User) *string { return &u.Locale }),
+	"time_zone":        stringAttribute(func(u *zendesk.User) *string { return &u.Timezone }),
+	"iana_time_zone":   stringAttribute(func(u *zendesk.User) *string { return &u.IanaTimezone }),
+	"notes":            stringAttribute(func(u *zendesk.User) *string { return &u.Notes }),
+	"details":          stringAttribute(func(u *zendesk.User) *string { return &u.Details }),
+	"role":             stringAttribute(func(u *zendesk.User) *string { return &u.Role }),
+	"signature":        stringAttribute(func(u *zendesk.User) *string { return &u.Signature }),
+	"organization_id":  idAttribute(func(u *zendesk.User) *int64 { return &u.OrganizationID }),
+	"default_group_id": idAttribute(func(u *zendesk.User) *int64 { return &u.DefaultGroupID }),
+	"tags": {
+		get: func(u zendesk.User) interface{} {
+			if len(u.Tags) == 0 {
+				return nil
+			}
+			tags := make([]interface{}, len(u.Tags))
+			for i, t := range u.Tags {
+				tags[i] = t
+			}
+			return tags
+		},
+		set: func(u *zendesk.User, v interface{}) error {
+			u.Tags = nil
+			switch t := v.(type) {
+			case nil:
+			case string:
+				u.Tags = strings.Fields(strings.ReplaceAll(t, ",", " "))
+			case []interface{}:
+				for _, item := range t {
+					s, err := toString(item)
+					if err != nil {
+						return err
+					}
+					u.Tags = append(u.Tags, s)
+				}
+			default:
+				return fmt.Errorf("tags must be a string or a list of strings")
+			}
+			return nil
+		},
+	},
+}
+
+const userFieldPrefix = "user_fields."
+
+// mapping is a validated set of attribute mappings
+type mapping []mappedAttribute
+
+type mappedAttribute struct {
+	path   attrPath
+	target string
+	attr   userAttribute
+}
+
+func newMapping(attrs []Attribute) (mapping, error) {
+	m := make(mapping, 0, len(attrs))
+	for _, a := range attrs {
+		path, err := parsePath(a.SCIM)
+		if err != nil {
+			return nil, fmt.Errorf("scim: mapping of %q: %w", a.SCIM, err)
+		}
+
+		attr, ok := userAttributes[a.Zendesk]
+		if !ok && strings.HasPrefix(a.Zendesk, userFieldPrefix) && len(a.Zendesk) > len(userFieldPrefix) {
+			attr, ok = userFieldAttribute(strings.TrimPrefix(a.Zendesk, userFieldPrefix)), true
+		}
+		if !ok {
+			return nil, fmt.Errorf("scim: mapping of %q: unknown Zendesk attribute %q", a.SCIM, a.Zendesk)
+		}
+		m = append(m, mappedAttribute{path: path, target: a.Zendesk, attr: attr})
+	}
+	return m, nil
+}
+
+func userFieldAttribute(key string) userAttribute {
+	return userAttribute{
+		get: func(u zendesk.User) interface{} {
+			return u.UserFields[key]
+		},
+		set: func(u *zendesk.User, v interface{}) error {
+			if u.UserFields == nil {
+				u.UserFields = zendesk.UserFields{}
+			}
+			u.UserFields[key] = v
+			return nil
+		},
+	}
+}
+
+// userResource converts a Zendesk user into a SCIM user resource
+func (s *Server) userResource(u zendesk.User) map[string]interface{} {
+	res := map[string]interface{}{
+		"id":     strconv.FormatInt(u.ID, 10),
+		"active": !u.Suspended,
+		"meta":   s.meta("User", "/Users/"+strconv.FormatInt(u.ID, 10), u.CreatedAt, u.UpdatedAt),
+	}
+	for _, m := range s.mapping {
+		if _, ok := m.path.get(res); ok {
+			// an earlier mapping of the same SCIM attribute wins
+			continue
+		}
+		if v := m.attr.get(u); v != nil {
+			m.path.set(res, v)
+		}
+	}
+
+	schemas := []interface{}{SchemaUser}
+	for k := range res {
+		if strings.HasPrefix(strings.ToLower(k), "urn:") {
+			schemas = append(schemas, k)
+		}
+	}
+	res["schemas"] = schemas
+	return res
+}
+
+// active reads the active attribute, which some identity providers send
+// as a string
+func active(res map[string]interface{}) (bool, bool, error) {
+	_, v, ok := lookup(res, "active")
+	if !ok || v == nil {
+		return false, false, nil
+	}
+	switch a := v.(type) {
+	case bool:
+		return a, true, nil
+	case string:
+		b, err := strconv.ParseBool(strings.ToLower(a))
+		if err != nil {
+			return false, false, badRequest(scimTypeInvalidValue, "active must be a boolean")
+		}
+		return b, true, nil
+	}
+	return false, false, badRequest(scimTypeInvalidValue, "active must be a boolean")
+}
+
+func pathValue(res map[string]interface{}, path string) interface{} {
+	p, err := parsePath(path)
+	if err != nil {
+		return nil
+	}
+	v, _ := p.get(res)
+	return v
+}
+
+func (s *Server) meta(resourceType, location string, created, updated time.Time) map[string]interface{} {
+	meta := map[string]interface{}{
+		"resourceType": resourceType,
+	}
+	if !created.IsZero() {
+		meta["created"] = created.UTC().Format(time.RFC3339)
+	}
+	if !updated.IsZero() {
+		meta["lastModified"] = updated.UTC().Format(time.RFC3339)
+	}
+	if s.baseURL != "" {
+		meta["location"] = s.baseURL + location
+	}
+	return meta
+}
+
+func toString(v interface{}) (string, error) {
+	switch s := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return s, nil
+	case bool:
+		return strconv.FormatBool(s), nil
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64), nil
+	case int64:
+		return strconv.FormatInt(s, 10), nil
+	}
+	return "", fmt.Errorf("expected a string value")
+}
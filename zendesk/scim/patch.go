@@ -0,0 +1,115 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// patchOp is an operation of a PATCH request (RFC 7644 section 3.5.2)
+type patchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+func decodePatch(r *http.Request) ([]patchOp, error) {
+	var req struct {
+		Schemas    []string  `json:"schemas"`
+		Operations []patchOp `json:"Operations"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, badRequest(scimTypeInvalidSyntax, "invalid JSON body: %s", err)
+	}
+	for i := range req.Operations {
+		// some identity providers capitalize the operation
+		req.Operations[i].Op = strings.ToLower(req.Operations[i].Op)
+		switch req.Operations[i].Op {
+		case "add", "replace", "remove":
+		default:
+			return nil, badRequest(scimTypeInvalidSyntax, "unknown operation %q", req.Operations[i].Op)
+		}
+	}
+	return req.Operations, nil
+}
+
+// applyPatch applies the operations to a resource
+func applyPatch(res map[string]interface{}, ops []patchOp) error {
+	for _, op := range ops {
+		if op.Path == "" {
+			if op.Op == "remove" {
+				return badRequest(scimTypeNoTarget, "remove requires a path")
+			}
+			values, ok := op.Value.(map[string]interface{})
+			if !ok {
+				return badRequest(scimTypeInvalidValue, "value must be an object when path is omitted")
+			}
+			for k, v := range values {
+				if err := applyValue(res, op.Op, k, v); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if op.Op == "remove" {
+			path, err := parsePath(op.Path)
+			if err != nil {
+				return badRequest(scimTypeInvalidPath, "%s", err)
+			}
+			path.remove(res)
+			continue
+		}
+		if err := applyValue(res, op.Op, op.Path, op.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyValue adds or replaces the value of an attribute. Values added to a
+// multi-valued attribute are appended.
+func applyValue(res map[string]interface{}, op, p string, v interface{}) error {
+	// an extension object merges its attributes
+	if ext, ok := v.(map[string]interface{}); ok && isSchemaURN(p) {
+		for k, ev := range ext {
+			if err := applyValue(res, op, p+":"+k, ev); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	path, err := parsePath(p)
+	if err != nil {
+		return badRequest(scimTypeInvalidPath, "%s", err)
+	}
+
+	if op == "add" && path.filter == nil && path.sub == "" {
+		obj := path.container(res, true)
+		key, current, _ := lookup(obj, path.attr)
+		if items, ok := current.([]interface{}); ok {
+			if added, ok := v.([]interface{}); ok {
+				obj[key] = append(items, added...)
+				return nil
+			}
+		}
+	}
+
+	if !path.set(res, v) {
+		return badRequest(scimTypeNoTarget, "no value matches %q", p)
+	}
+	return nil
+}
+
+// isSchemaURN reports whether the path is a schema URN rather than an
+// extension attribute. Schema URNs end with the resource type, e.g.
+// "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User", while
+// attribute names start with a lower case letter.
+func isSchemaURN(p string) bool {
+	if !strings.HasPrefix(strings.ToLower(p), "urn:") {
+		return false
+	}
+	last := p[strings.LastIndex(p, ":")+1:]
+	return last != "" && last[0] >= 'A' && last[0] <= 'Z'
+}
@@ -0,0 +1,650 @@
+package scim
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+const testToken = "secret"
+
+// fakeZendesk is an in-memory Zendesk API which serves the endpoints used by the server
+type fakeZendesk struct {
+	mu          sync.Mutex
+	users       map[int64]zendesk.User
+	groups      map[int64]zendesk.Group
+	orgs        map[int64]zendesk.Organization
+	memberships map[int64]zendesk.GroupMembership
+	orgMembers  map[int64]zendesk.OrganizationMembership
+	nextID      int64
+	requests    []string
+	// body is the body of the last request
+	body map[string]json.RawMessage
+}
+
+func newFakeZendesk() *fakeZendesk {
+	return &fakeZendesk{
+		users: map[int64]zendesk.User{
+			1: {ID: 1, Name: "Alice Smith", Email: "alice@example.com", ExternalID: "a-1", Role: "agent", Phone: "+1 555 0100",
+				UserFields: zendesk.UserFields{"department": "Support"}},
+			2: {ID: 2, Name: "Bob Jones", Email: "bob@example.com", Role: "agent", Suspended: true},
+		},
+		groups: map[int64]zendesk.Group{10: {ID: 10, Name: "Support"}},
+		orgs: map[int64]zendesk.Organization{
+			20: {ID: 20, Name: "Acme", ExternalID: "acme", DomainNames: []string{"acme.com"}, GroupID: 10,
+				SharedTickets: true, SharedComments: true, Tags: []string{"enterprise"}},
+		},
+		memberships: map[int64]zendesk.GroupMembership{
+			100: {ID: 100, UserID: 1, GroupID: 10},
+		},
+		orgMembers: map[int64]zendesk.OrganizationMembership{},
+		nextID:     1000,
+	}
+}
+
+func (f *fakeZendesk) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	segments := strings.Split(strings.TrimSuffix(strings.Trim(r.URL.Path, "/"), ".json"), "/")
+	var id int64
+	if len(segments) == 2 {
+		id, _ = strconv.ParseInt(segments[1], 10, 64)
+	}
+	var body map[string]json.RawMessage
+	json.NewDecoder(r.Body).Decode(&body)
+	f.body = body
+
+	reply := func(key string, v interface{}) {
+		json.NewEncoder(w).Encode(map[string]interface{}{key: v, "meta": map[string]bool{"has_more": false}})
+	}
+	f.nextID++
+
+	switch route := r.Method + " " + segments[0]; {
+	case route == "GET users" && len(segments) == 2 && segments[1] == "search":
+		var found []zendesk.User
+		for _, id := range sortedIDs(f.users) {
+			if u := f.users[id]; searchUser(u, r.URL.Query()) {
+				found = append(found, u)
+			}
+		}
+		replyPage(w, r, "users", found)
+	case route == "GET users" && len(segments) == 1:
+		var users []zendesk.User
+		for _, id := range sortedIDs(f.users) {
+			users = append(users, f.users[id])
+		}
+		replyPage(w, r, "users", users)
+	case route == "GET users":
+		u, ok := f.users[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		reply("user", u)
+	case route == "POST users":
+		var u zendesk.User
+		json.Unmarshal(body["user"], &u)
+		for _, existing := range f.users {
+			if existing.Email == u.Email {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(`{"error":"RecordInvalid","details":{"email":[{"error":"DuplicateValue"}]}}`))
+				return
+			}
+		}
+		u.ID = f.nextID
+		f.users[u.ID] = u
+		w.WriteHeader(http.StatusCreated)
+		reply("user", u)
+	case route == "PUT users":
+		var u zendesk.User
+		overlay(f.users[id], body["user"], &u)
+		f.users[id] = u
+		reply("user", u)
+	case route == "DELETE users":
+		u := f.users[id]
+		delete(f.users, id)
+		reply("user", u)
+	case route == "GET groups" && len(segments) == 1:
+		var groups []zendesk.Group
+		for _, id := range sortedIDs(f.groups) {
+			groups = append(groups, f.groups[id])
+		}
+		replyPage(w, r, "groups", groups)
+	case route == "GET groups":
+		reply("group", f.groups[id])
+	case route == "POST groups":
+		var g zendesk.Group
+		json.Unmarshal(body["group"], &g)
+		g.ID = f.nextID
+		f.groups[g.ID] = g
+		w.WriteHeader(http.StatusCreated)
+		reply("group", g)
+	case route == "PUT groups":
+		var g zendesk.Group
+		json.Unmarshal(body["group"], &g)
+		g.ID = id
+		f.groups[id] = g
+		reply("group", g)
+	case route == "GET organizations" && len(segments) == 1:
+		var orgs []zendesk.Organization
+		for _, id := range sortedIDs(f.orgs) {
+			orgs = append(orgs, f.orgs[id])
+		}
+		replyPage(w, r, "organizations", orgs)
+	case route == "GET search":
+		// type:group name:"Support"
+		query := r.URL.Query().Get("query")
+		name := strings.Trim(query[strings.Index(query, "name:")+len("name:"):], `"`)
+		var results []map[string]interface{}
+		if strings.HasPrefix(query, "type:group ") {
+			for _, id := range sortedIDs(f.groups) {
+				if g := f.groups[id]; strings.EqualFold(g.Name, name) {
+					results = append(results, map[string]interface{}{"result_type": "group", "id": g.ID, "name": g.Name})
+				}
+			}
+		} else {
+			for _, id := range sortedIDs(f.orgs) {
+				if o := f.orgs[id]; strings.EqualFold(o.Name, name) {
+					results = append(results, map[string]interface{}{"result_type": "organization", "id": o.ID, "name": o.Name})
+				}
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results, "count": len(results)})
+	case route == "GET organizations":
+		reply("organization", f.orgs[id])
+	case route == "PUT organizations":
+		var o zendesk.Organization
+		overlay(f.orgs[id], body["organization"], &o)
+		f.orgs[id] = o
+		reply("organization", o)
+	case route == "POST organizations":
+		var o zendesk.Organization
+		json.Unmarshal(body["organization"], &o)
+		o.ID = f.nextID
+		f.orgs[o.ID] = o
+		w.WriteHeader(http.StatusCreated)
+		reply("organization", o)
+	case route == "GET group_memberships":
+		groupID, _ := strconv.ParseInt(r.URL.Query().Get("group_id"), 10, 64)
+		userID, _ := strconv.ParseInt(r.URL.Query().Get("user_id"), 10, 64)
+		var memberships []zendesk.GroupMembership
+		for _, m := range f.memberships {
+			if m.GroupID == groupID || m.UserID == userID {
+				memberships = append(memberships, m)
+			}
+		}
+		reply("group_memberships", memberships)
+	case route == "POST group_memberships":
+		var m zendesk.GroupMembership
+		json.Unmarshal(body["group_membership"], &m)
+		m.ID = f.nextID
+		f.memberships[m.ID] = m
+		w.WriteHeader(http.StatusCreated)
+		reply("group_membership", m)
+	case route == "DELETE group_memberships":
+		delete(f.memberships, id)
+		w.WriteHeader(http.StatusNoContent)
+	case route == "GET organization_memberships":
+		orgID, _ := strconv.ParseInt(r.URL.Query().Get("organization_id"), 10, 64)
+		userID, _ := strconv.ParseInt(r.URL.Query().Get("user_id"), 10, 64)
+		var memberships []zendesk.OrganizationMembership
+		for _, m := range f.orgMembers {
+			if m.OrganizationID == orgID || m.UserID == userID {
+				memberships = append(memberships, m)
+			}
+		}
+		reply("organization_memberships", memberships)
+	case route == "POST organization_memberships":
+		var m zendesk.OrganizationMembership
+		json.Unmarshal(body["organization_membership"], &m)
+		m.ID = f.nextID
+		f.orgMembers[m.ID] = m
+		w.WriteHeader(http.StatusCreated)
+		reply("organization_membership", m)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+// replyPage answers an offset paginated listing of the items
+func replyPage[T any](w http.ResponseWriter, r *http.Request, key string, items []T) {
+	count := len(items)
+	if perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page")); perPage > 0 {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		start := (page - 1) * perPage
+		if start > len(items) {
+			start = len(items)
+		}
+		end := start + perPage
+		if end > len(items) {
+			end = len(items)
+		}
+		items = items[start:end]
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{key: items, "count": count})
+}
+
+func sortedIDs[T any](records map[int64]T) []int64 {
+	ids := make([]int64, 0, len(records))
+	for id := range records {
+		ids = append(ids, id)
+	}
+	sortIDs(ids)
+	return ids
+}
+
+// searchUser matches a user like the user search does for the queries sent by the server
+func searchUser(u zendesk.User, q url.Values) bool {
+	if id := q.Get("external_id"); id != "" {
+		return u.ExternalID == id
+	}
+	query := q.Get("query")
+	switch {
+	case strings.HasPrefix(query, "is_suspended:"):
+		return strconv.FormatBool(u.Suspended) == strings.TrimPrefix(query, "is_suspended:")
+	case strings.HasPrefix(query, "name:"):
+		return strings.Contains(strings.ToLower(u.Name), strings.ToLower(strings.Trim(strings.TrimPrefix(query, "name:"), `"`)))
+	}
+	return u.Email == query
+}
+
+// overlay updates a record like the API does: the sent attributes replace
+// the current ones, nulls clear them and omitted attributes are kept
+func overlay(current interface{}, sent json.RawMessage, out interface{}) {
+	var record, update map[string]interface{}
+	b, _ := json.Marshal(current)
+	json.Unmarshal(b, &record)
+	json.Unmarshal(sent, &update)
+	for k, v := range update {
+		record[k] = v
+	}
+	b, _ = json.Marshal(record)
+	json.Unmarshal(b, out)
+}
+
+func newTestServer(t *testing.T, cfg Config) (*httptest.Server, *fakeZendesk) {
+	t.Helper()
+
+	fake := newFakeZendesk()
+	upstream := httptest.NewServer(fake)
+	t.Cleanup(upstream.Close)
+
+	client, _ := zendesk.NewClient(nil)
+	client.SetEndpointURL(upstream.URL)
+
+	cfg.Token = testToken
+	s, err := NewServer(client, cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %s", err)
+	}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	return srv, fake
+}
+
+func doRequest(t *testing.T, method, url string, body interface{}) (int, map[string]interface{}) {
+	t.Helper()
+
+	var reader bytes.Buffer
+	if body != nil {
+		if s, ok := body.(string); ok {
+			reader.WriteString(s)
+		} else {
+			json.NewEncoder(&reader).Encode(body)
+		}
+	}
+	req, _ := http.NewRequest(method, url, &reader)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	req.Header.Set("Content-Type", "application/scim+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	var data map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&data)
+	return resp.StatusCode, data
+}
+
+func TestNewServer(t *testing.T) {
+	client, _ := zendesk.NewClient(nil)
+
+	if _, err := NewServer(client, Config{}); err == nil {
+		t.Fatal("Server without token should not be created")
+	}
+	if _, err := NewServer(client, Config{Token: "x", UserMapping: []Attribute{{SCIM: "userName", Zendesk: "password"}}}); err == nil {
+		t.Fatal("Mapping to an unknown attribute should be rejected")
+	}
+	if _, err := NewServer(client, Config{Token: "x", GroupKinds: []GroupKind{"team"}}); err == nil {
+		t.Fatal("Unknown group kind should be rejected")
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	srv, _ := newTestServer(t, Config{})
+
+	resp, err := http.Get(srv.URL + "/Users")
+	if err != nil {
+		t.Fatalf("Failed to send request: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Expected 401, but got %d", resp.StatusCode)
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	srv, fake := newTestServer(t, Config{})
+
+	status, data := doRequest(t, http.MethodGet, srv.URL+`/Users?filter=userName+eq+"alice@example.com"`, nil)
+	if status != http.StatusOK || data["totalResults"] != float64(1) {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+	user := data["Resources"].([]interface{})[0].(map[string]interface{})
+	if user["id"] != "1" || user["externalId"] != "a-1" || user["active"] != true {
+		t.Fatalf("Unexpected user %v", user)
+	}
+	if fake.requests[0] != "GET /users/search.json" {
+		t.Fatalf("userName lookup should use the user search, but sent %v", fake.requests)
+	}
+
+	status, data = doRequest(t, http.MethodGet, srv.URL+"/Users?filter=active+eq+false&count=10", nil)
+	if status != http.StatusOK || data["totalResults"] != float64(1) {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+
+	// lists are paged through the API instead of listing every user
+	fake.requests = nil
+	status, data = doRequest(t, http.MethodGet, srv.URL+"/Users?startIndex=2&count=1", nil)
+	if status != http.StatusOK || data["totalResults"] != float64(2) || data["itemsPerPage"] != float64(1) {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+	if user := data["Resources"].([]interface{})[0].(map[string]interface{}); user["id"] != "2" {
+		t.Fatalf("Unexpected user %v", user)
+	}
+	if len(fake.requests) != 1 {
+		t.Fatalf("Expected a single page of users, but sent %v", fake.requests)
+	}
+
+	// other equality filters are answered with a user search
+	status, data = doRequest(t, http.MethodGet, srv.URL+`/Users?filter=displayName+eq+"bob+jones"`, nil)
+	if status != http.StatusOK || data["itemsPerPage"] != float64(1) {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+	status, data = doRequest(t, http.MethodGet, srv.URL+`/Users?filter=displayName+eq+"bob"`, nil)
+	if status != http.StatusOK || data["itemsPerPage"] != float64(0) {
+		t.Fatalf("Search hits which do not equal the value should be dropped %d %v", status, data)
+	}
+	status, data = doRequest(t, http.MethodGet, srv.URL+`/Users?filter=locale+eq+"en"`, nil)
+	if status != http.StatusBadRequest || data["scimType"] != scimTypeInvalidFilter {
+		t.Fatalf("Filter which the search cannot match should be rejected %d %v", status, data)
+	}
+
+	status, data = doRequest(t, http.MethodGet, srv.URL+"/Users?filter=userName+zz+1", nil)
+	if status != http.StatusBadRequest || data["scimType"] != scimTypeInvalidFilter {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+
+	// filters other than eq would scan every user of the account
+	fake.requests = nil
+	status, data = doRequest(t, http.MethodGet, srv.URL+`/Users?filter=userName+sw+"alice"`, nil)
+	if status != http.StatusBadRequest || data["scimType"] != scimTypeInvalidFilter {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+	if len(fake.requests) != 0 {
+		t.Fatalf("Rejected filter should not send requests, but sent %v", fake.requests)
+	}
+}
+
+func TestCreateUser(t *testing.T) {
+	srv, fake := newTestServer(t, Config{
+		UserMapping: append([]Attribute{
+			{SCIM: "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department", Zendesk: "user_fields.department"},
+		}, DefaultUserMapping...),
+	})
+
+	status, data := doRequest(t, http.MethodPost, srv.URL+"/Users", `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "carol@example.com",
+		"externalId": "c-3",
+		"name": {"givenName": "Carol", "familyName": "White"},
+		"active": true,
+		"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"department": "Sales"}
+	}`)
+	if status != http.StatusCreated {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+
+	id, _ := strconv.ParseInt(data["id"].(string), 10, 64)
+	u := fake.users[id]
+	if u.Email != "carol@example.com" || u.Name != "Carol White" || u.ExternalID != "c-3" ||
+		u.UserFields["department"] != "Sales" {
+		t.Fatalf("Unexpected user %+v", u)
+	}
+	schemas := fmt.Sprint(data["schemas"])
+	if !strings.Contains(schemas, "enterprise") {
+		t.Fatalf("Extension schema should be listed %v", schemas)
+	}
+
+	status, data = doRequest(t, http.MethodPost, srv.URL+"/Users", `{"userName": "carol@example.com"}`)
+	if status != http.StatusConflict || data["scimType"] != scimTypeUniqueness {
+		t.Fatalf("Duplicate user should conflict, but got %d %v", status, data)
+	}
+}
+
+func TestPatchUser(t *testing.T) {
+	srv, fake := newTestServer(t, Config{
+		UserMapping: append([]Attribute{
+			{SCIM: "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department", Zendesk: "user_fields.department"},
+		}, DefaultUserMapping...),
+	})
+
+	status, data := doRequest(t, http.MethodPatch, srv.URL+"/Users/1", `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "Replace", "path": "active", "value": "False"},
+			{"op": "replace", "path": "displayName", "value": "Alice Brown"},
+			{"op": "remove", "path": "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department"}
+		]
+	}`)
+	if status != http.StatusOK {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+
+	u := fake.users[1]
+	if !u.Suspended || u.Name != "Alice Brown" {
+		t.Fatalf("Unexpected user %+v", u)
+	}
+	if v, ok := u.UserFields["department"]; !ok || v != nil {
+		t.Fatalf("Removed user field should be cleared %+v", u.UserFields)
+	}
+
+	status, data = doRequest(t, http.MethodPatch, srv.URL+"/Users/1", `{"Operations": [
+		{"op": "remove", "path": "phoneNumbers[type eq \"work\"].value"},
+		{"op": "replace", "path": "externalId", "value": ""}
+	]}`)
+	if status != http.StatusOK {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+	if u := fake.users[1]; u.Phone != "" || u.ExternalID != "" || u.Name != "Alice Brown" {
+		t.Fatalf("Removed phone and emptied external ID should be cleared %+v", u)
+	}
+	if _, ok := data["externalId"]; ok {
+		t.Fatalf("Cleared external ID should not be returned %v", data)
+	}
+
+	// reactivation only unsuspends the user
+	fake.requests = nil
+	status, _ = doRequest(t, http.MethodPatch, srv.URL+"/Users/2",
+		`{"Operations": [{"op": "replace", "value": {"active": true}}]}`)
+	if status != http.StatusOK || fake.users[2].Suspended {
+		t.Fatalf("User should be unsuspended %d %+v", status, fake.users[2])
+	}
+	if len(fake.requests) != 2 {
+		t.Fatalf("Expected GET and PUT of the user, but sent %v", fake.requests)
+	}
+}
+
+func TestReplaceUser(t *testing.T) {
+	srv, fake := newTestServer(t, Config{})
+
+	status, data := doRequest(t, http.MethodPut, srv.URL+"/Users/1", `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "alice@example.com",
+		"externalId": "a-1",
+		"displayName": "Alice Smith"
+	}`)
+	if status != http.StatusOK {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+
+	// the phone number missing from the replacement is cleared
+	var sent map[string]interface{}
+	json.Unmarshal(fake.body["user"], &sent)
+	if phone, ok := sent["phone"]; !ok || phone != nil {
+		t.Fatalf("Expected phone to be sent as null, but sent %v", sent)
+	}
+	if _, ok := sent["name"]; ok {
+		t.Fatalf("Unchanged name should not be sent %v", sent)
+	}
+	if u := fake.users[1]; u.Phone != "" || u.ExternalID != "a-1" || u.Name != "Alice Smith" {
+		t.Fatalf("Unexpected user %+v", u)
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	srv, fake := newTestServer(t, Config{})
+
+	status, _ := doRequest(t, http.MethodDelete, srv.URL+"/Users/2", nil)
+	if status != http.StatusNoContent {
+		t.Fatalf("Expected 204, but got %d", status)
+	}
+	if _, ok := fake.users[2]; ok {
+		t.Fatal("User should be deleted")
+	}
+
+	status, data := doRequest(t, http.MethodGet, srv.URL+"/Users/2", nil)
+	if status != http.StatusNotFound || data["status"] != "404" {
+		t.Fatalf("Expected 404, but got %d %v", status, data)
+	}
+}
+
+func TestGroups(t *testing.T) {
+	srv, fake := newTestServer(t, Config{
+		GroupKinds: []GroupKind{GroupKindGroup, GroupKindOrganization},
+		MapGroup: func(name string) GroupKind {
+			if strings.HasPrefix(name, "org:") {
+				return GroupKindOrganization
+			}
+			return GroupKindGroup
+		},
+	})
+
+	status, data := doRequest(t, http.MethodGet, srv.URL+"/Groups/group-10", nil)
+	if status != http.StatusOK || data["displayName"] != "Support" || len(data["members"].([]interface{})) != 1 {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+
+	status, data = doRequest(t, http.MethodPost, srv.URL+"/Groups", `{
+		"displayName": "Escalations",
+		"members": [{"value": "1"}, {"value": "2"}]
+	}`)
+	if status != http.StatusCreated || len(data["members"].([]interface{})) != 2 {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+	id := data["id"].(string)
+	if !strings.HasPrefix(id, "group-") {
+		t.Fatalf("Group should be stored as a Zendesk group, but got %s", id)
+	}
+
+	status, _ = doRequest(t, http.MethodPatch, srv.URL+"/Groups/"+id, `{"Operations": [
+		{"op": "remove", "path": "members[value eq \"2\"]"},
+		{"op": "replace", "value": {"displayName": "Tier 2"}}
+	]}`)
+	if status != http.StatusNoContent {
+		t.Fatalf("Expected 204, but got %d", status)
+	}
+	status, data = doRequest(t, http.MethodGet, srv.URL+"/Groups/"+id, nil)
+	members := data["members"].([]interface{})
+	if status != http.StatusOK || data["displayName"] != "Tier 2" || len(members) != 1 ||
+		members[0].(map[string]interface{})["value"] != "1" {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+
+	status, data = doRequest(t, http.MethodGet, srv.URL+`/Groups?filter=displayName+eq+"tier+2"`, nil)
+	if status != http.StatusOK || data["totalResults"] != float64(1) {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+
+	// members are only fetched for the groups of the page
+	fake.requests = nil
+	status, data = doRequest(t, http.MethodGet, srv.URL+"/Groups?startIndex=2&count=2&attributes=members", nil)
+	if status != http.StatusOK || data["totalResults"] != float64(3) || data["itemsPerPage"] != float64(2) {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+	page := data["Resources"].([]interface{})
+	if page[0].(map[string]interface{})["id"] != id || page[1].(map[string]interface{})["id"] != "organization-20" {
+		t.Fatalf("Groups should be followed by organizations %v", page)
+	}
+	memberRequests := 0
+	for _, r := range fake.requests {
+		if strings.Contains(r, "memberships") {
+			memberRequests++
+		}
+	}
+	if memberRequests != 2 {
+		t.Fatalf("Expected members of the two groups of the page, but sent %v", fake.requests)
+	}
+
+	status, data = doRequest(t, http.MethodGet, srv.URL+`/Groups?filter=members[value+eq+"1"]`, nil)
+	if status != http.StatusOK || data["totalResults"] != float64(2) {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+	status, data = doRequest(t, http.MethodGet, srv.URL+`/Groups?filter=displayName+sw+"tier"`, nil)
+	if status != http.StatusBadRequest || data["scimType"] != scimTypeInvalidFilter {
+		t.Fatalf("Filter which would list every group should be rejected %d %v", status, data)
+	}
+
+	status, data = doRequest(t, http.MethodPost, srv.URL+"/Groups", `{
+		"displayName": "org:Acme",
+		"externalId": "acme",
+		"members": [{"value": "1"}]
+	}`)
+	if status != http.StatusCreated || !strings.HasPrefix(data["id"].(string), "organization-") {
+		t.Fatalf("Unexpected response %d %v", status, data)
+	}
+	if len(fake.orgMembers) != 1 {
+		t.Fatalf("Expected an organization membership, but got %v", fake.orgMembers)
+	}
+}
+
+func TestRenameOrganizationGroup(t *testing.T) {
+	srv, fake := newTestServer(t, Config{GroupKinds: []GroupKind{GroupKindOrganization}})
+
+	status, data := doRequest(t, http.MethodPatch, srv.URL+"/Groups/organization-20",
+		`{"Operations": [{"op": "replace", "path": "displayName", "value": "Acme Corp"}]}`)
+	if status != http.StatusNoContent {
+		t.Fatalf("Expected 204, but got %d %v", status, data)
+	}
+
+	o := fake.orgs[20]
+	if o.Name != "Acme Corp" || o.ExternalID != "acme" {
+		t.Fatalf("Organization should be renamed %+v", o)
+	}
+	if len(o.DomainNames) != 1 || !o.SharedTickets || !o.SharedComments || o.GroupID != 10 || len(o.Tags) != 1 {
+		t.Fatalf("Domains, tags and sharing of the organization should survive a rename %+v", o)
+	}
+}
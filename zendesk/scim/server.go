@@ -0,0 +1,408 @@
+// Package scim provides a SCIM 2.0 server (RFC 7643, RFC 7644) which lets
+// an identity provider provision Zendesk users and groups. SCIM users are
+// translated into zendesk.User with a configurable attribute mapping and
+// SCIM groups into Zendesk groups or organizations, whose members are
+// managed with group and organization memberships.
+//
+// The server implements the Users and Groups resources with filtering,
+// pagination and PATCH operations. Lists are paged through the Zendesk API
+// and filters are limited to the equality filters which can be answered
+// without listing the whole account. Mount it under the base URL given to
+// the identity provider:
+//
+//	srv, err := scim.NewServer(client, scim.Config{Token: token})
+//	http.Handle("/scim/v2/", http.StripPrefix("/scim/v2", srv))
+package scim
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// Schema URNs
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+// scimType values of error responses
+const (
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeInvalidPath   = "invalidPath"
+	scimTypeInvalidValue  = "invalidValue"
+	scimTypeInvalidSyntax = "invalidSyntax"
+	scimTypeNoTarget      = "noTarget"
+	scimTypeUniqueness    = "uniqueness"
+)
+
+const defaultMaxResults = 100
+
+// GroupKind is the Zendesk resource which backs a SCIM group
+type GroupKind string
+
+const (
+	// GroupKindGroup stores SCIM groups as Zendesk agent groups
+	GroupKindGroup GroupKind = "group"
+	// GroupKindOrganization stores SCIM groups as Zendesk organizations
+	GroupKindOrganization GroupKind = "organization"
+)
+
+// Config is configuration of the SCIM Server
+type Config struct {
+	// Token is the bearer token the identity provider authenticates with. Required.
+	Token string
+
+	// UserMapping maps SCIM user attributes onto Zendesk users.
+	// Defaults to DefaultUserMapping.
+	UserMapping []Attribute
+
+	// GroupKinds are the Zendesk resources exposed as SCIM groups.
+	// Defaults to GroupKindGroup only.
+	GroupKinds []GroupKind
+
+	// MapGroup decides which kind of Zendesk resource a group created by
+	// the identity provider is stored as. It must return one of GroupKinds.
+	// Defaults to the first of GroupKinds.
+	MapGroup func(displayName string) GroupKind
+
+	// BaseURL is the public URL of the server, e.g. https://idp-bridge.example.com/scim/v2.
+	// It is used for meta.location of resources. Optional.
+	BaseURL string
+
+	// MaxResults is the maximum number of resources of a list response.
+	// Defaults to 100.
+	MaxResults int
+}
+
+// Server is an http.Handler which serves the SCIM protocol backed by Zendesk
+type Server struct {
+	api        zendesk.API
+	token      string
+	mapping    mapping
+	groupKinds []GroupKind
+	mapGroup   func(displayName string) GroupKind
+	baseURL    string
+	maxResults int
+}
+
+// NewServer creates a SCIM Server backed by api
+func NewServer(api zendesk.API, cfg Config) (*Server, error) {
+	if api == nil {
+		return nil, errors.New("scim: api is required")
+	}
+	if cfg.Token == "" {
+		return nil, errors.New("scim: token is required")
+	}
+
+	attrs := cfg.UserMapping
+	if len(attrs) == 0 {
+		attrs = DefaultUserMapping
+	}
+	m, err := newMapping(attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		api:        api,
+		token:      cfg.Token,
+		mapping:    m,
+		groupKinds: cfg.GroupKinds,
+		mapGroup:   cfg.MapGroup,
+		baseURL:    strings.TrimSuffix(cfg.BaseURL, "/"),
+		maxResults: cfg.MaxResults,
+	}
+	if len(s.groupKinds) == 0 {
+		s.groupKinds = []GroupKind{GroupKindGroup}
+	}
+	for _, k := range s.groupKinds {
+		if k != GroupKindGroup && k != GroupKindOrganization {
+			return nil, fmt.Errorf("scim: unknown group kind %q", k)
+		}
+	}
+	if s.mapGroup == nil {
+		s.mapGroup = func(string) GroupKind { return s.groupKinds[0] }
+	}
+	if s.maxResults <= 0 {
+		s.maxResults = defaultMaxResults
+	}
+	return s, nil
+}
+
+// ServeHTTP dispatches the request to the resource handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authenticate(r) {
+		writeError(w, scimError{status: http.StatusUnauthorized, detail: "invalid bearer token"})
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	var (
+		status int
+		data   interface{}
+		err    error
+	)
+	switch {
+	case len(segments) == 1 && segments[0] == "ServiceProviderConfig" && r.Method == http.MethodGet:
+		status, data = http.StatusOK, s.serviceProviderConfig()
+	case segments[0] == "Users" && len(segments) <= 2:
+		status, data, err = s.serveUsers(r, segments[1:])
+	case segments[0] == "Groups" && len(segments) <= 2:
+		status, data, err = s.serveGroups(r, segments[1:])
+	default:
+		err = scimError{status: http.StatusNotFound, detail: "no such resource"}
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, status, data)
+}
+
+// authenticate compares the bearer token in constant time
+func (s *Server) authenticate(r *http.Request) bool {
+	const prefix = "Bearer "
+
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, prefix) {
+		return false
+	}
+	token := strings.TrimPrefix(header, prefix)
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+func (s *Server) serviceProviderConfig() map[string]interface{} {
+	supported := func(b bool) map[string]interface{} {
+		return map[string]interface{}{"supported": b}
+	}
+	return map[string]interface{}{
+		"schemas": []string{SchemaServiceProviderConfig},
+		"patch":   supported(true),
+		"bulk": map[string]interface{}{
+			"supported": false, "maxOperations": 0, "maxPayloadSize": 0,
+		},
+		"filter": map[string]interface{}{
+			"supported": true, "maxResults": s.maxResults,
+		},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with a bearer token",
+		}},
+	}
+}
+
+// listQuery is the query of a list request
+type listQuery struct {
+	filter     expr
+	startIndex int
+	count      int
+	attributes string
+}
+
+func (s *Server) parseListQuery(r *http.Request) (listQuery, error) {
+	q := listQuery{startIndex: 1, count: s.maxResults}
+	values := r.URL.Query()
+
+	if f := values.Get("filter"); f != "" {
+		e, err := parseFilter(f)
+		if err != nil {
+			return q, badRequest(scimTypeInvalidFilter, "%s", err)
+		}
+		q.filter = e
+	}
+	if v := values.Get("startIndex"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return q, badRequest(scimTypeInvalidValue, "invalid startIndex %q", v)
+		}
+		if n > 1 {
+			q.startIndex = n
+		}
+	}
+	if v := values.Get("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return q, badRequest(scimTypeInvalidValue, "invalid count %q", v)
+		}
+		if n < 0 {
+			n = 0
+		}
+		if n < q.count {
+			q.count = n
+		}
+	}
+	q.attributes = values.Get("attributes")
+	return q, nil
+}
+
+// listResponse filters and paginates resources
+func listResponse(resources []map[string]interface{}, q listQuery) map[string]interface{} {
+	matched := make([]map[string]interface{}, 0, len(resources))
+	for _, res := range resources {
+		if q.filter == nil || q.filter.match(res) {
+			matched = append(matched, res)
+		}
+	}
+
+	start := q.startIndex - 1
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + q.count
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return pagedResponse(matched[start:end], len(matched), q)
+}
+
+// pagedResponse answers a page of resources which was paginated by the
+// Zendesk API. total is the number of resources of all pages.
+func pagedResponse(page []map[string]interface{}, total int, q listQuery) map[string]interface{} {
+	return map[string]interface{}{
+		"schemas":      []string{SchemaListResponse},
+		"totalResults": total,
+		"startIndex":   q.startIndex,
+		"itemsPerPage": len(page),
+		"Resources":    page,
+	}
+}
+
+// maxPerPage is the largest page of offset paginated Zendesk listings
+const maxPerPage = 100
+
+// offsetPage fetches the resources from startIndex to startIndex+count of
+// an offset paginated Zendesk listing. fetch returns a page of the listing,
+// numbered from 1, and the number of resources of the listing. Only the
+// pages which hold the requested resources are fetched.
+func offsetPage[T any](q listQuery, fetch func(page, perPage int) ([]T, int64, error)) ([]T, int, error) {
+	perPage := q.count
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+	if perPage < 1 {
+		perPage = 1
+	}
+	offset := q.startIndex - 1
+	page, skip := offset/perPage+1, offset%perPage
+
+	var (
+		items []T
+		total int64
+	)
+	for {
+		found, n, err := fetch(page, perPage)
+		if err != nil {
+			return nil, 0, err
+		}
+		total = n
+		items = append(items, found...)
+		if len(found) < perPage || len(items) >= skip+q.count {
+			break
+		}
+		page++
+	}
+
+	if skip > len(items) {
+		skip = len(items)
+	}
+	items = items[skip:]
+	if len(items) > q.count {
+		items = items[:q.count]
+	}
+	return items, int(total), nil
+}
+
+// decodeResource reads a JSON object from the request body
+func decodeResource(r *http.Request) (map[string]interface{}, error) {
+	var res map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		return nil, badRequest(scimTypeInvalidSyntax, "invalid JSON body: %s", err)
+	}
+	return res, nil
+}
+
+// scimError is an error which is answered with a SCIM error response
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (e scimError) Error() string {
+	return e.detail
+}
+
+func badRequest(scimType, format string, args ...interface{}) scimError {
+	return scimError{status: http.StatusBadRequest, scimType: scimType, detail: fmt.Sprintf(format, args...)}
+}
+
+func notFound(resourceType, id string) scimError {
+	return scimError{status: http.StatusNotFound, detail: fmt.Sprintf("%s %s not found", resourceType, id)}
+}
+
+// upstreamError translates an error of the Zendesk API into a SCIM error
+func upstreamError(err error) scimError {
+	var serr scimError
+	if errors.As(err, &serr) {
+		return serr
+	}
+
+	var zerr zendesk.Error
+	if errors.As(err, &zerr) {
+		body, _ := io.ReadAll(zerr.Body())
+		switch status := zerr.Status(); {
+		case status == http.StatusNotFound:
+			return scimError{status: status, detail: "resource not found"}
+		case status == http.StatusUnprocessableEntity && strings.Contains(string(body), "DuplicateValue"):
+			return scimError{status: http.StatusConflict, scimType: scimTypeUniqueness, detail: string(body)}
+		case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+			return scimError{status: http.StatusBadRequest, scimType: scimTypeInvalidValue, detail: string(body)}
+		case status == http.StatusTooManyRequests:
+			return scimError{status: status, detail: zerr.Error()}
+		}
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return scimError{status: http.StatusServiceUnavailable, detail: err.Error()}
+	}
+	return scimError{status: http.StatusBadGateway, detail: err.Error()}
+}
+
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+	if data != nil {
+		json.NewEncoder(w).Encode(data)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	e := upstreamError(err)
+	body := map[string]interface{}{
+		"schemas": []string{SchemaError},
+		"status":  strconv.Itoa(e.status),
+		"detail":  e.detail,
+	}
+	if e.scimType != "" {
+		body["scimType"] = e.scimType
+	}
+	writeJSON(w, e.status, body)
+}
@@ -0,0 +1,420 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+func (s *Server) serveUsers(r *http.Request, rest []string) (int, interface{}, error) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			return s.listUsers(r)
+		case http.MethodPost:
+			return s.createUser(r)
+		}
+		return 0, nil, scimError{status: http.StatusMethodNotAllowed, detail: "method not allowed"}
+	}
+
+	id, err := strconv.ParseInt(rest[0], 10, 64)
+	if err != nil {
+		return 0, nil, notFound("User", rest[0])
+	}
+	switch r.Method {
+	case http.MethodGet:
+		u, err := s.api.GetUser(r.Context(), id)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, s.userResource(u), nil
+	case http.MethodPut:
+		return s.replaceUser(r, id)
+	case http.MethodPatch:
+		return s.patchUser(r, id)
+	case http.MethodDelete:
+		if _, err := s.api.DeleteUser(r.Context(), id); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusNoContent, nil, nil
+	}
+	return 0, nil, scimError{status: http.StatusMethodNotAllowed, detail: "method not allowed"}
+}
+
+// listUsers answers a list of users. Identity providers look users up by
+// an equality filter on userName or externalId, which is answered with a
+// lookup by ID, email or external ID. Other equality filters are
+// translated to a user search, and lists without a filter are paged
+// through the users API, so that no request scans the whole account.
+// Other filters are rejected.
+func (s *Server) listUsers(r *http.Request) (int, interface{}, error) {
+	q, err := s.parseListQuery(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	ctx := r.Context()
+
+	if q.filter == nil {
+		users, total, err := offsetPage(q, func(page, perPage int) ([]zendesk.User, int64, error) {
+			users, p, err := s.api.GetUsers(ctx, &zendesk.UserListOptions{
+				PageOptions: zendesk.PageOptions{Page: page, PerPage: perPage},
+			})
+			return users, p.Count, err
+		})
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, pagedResponse(s.userResources(users), total, q), nil
+	}
+
+	cmp, ok := q.filter.(compareExpr)
+	if !ok || cmp.op != "eq" || cmp.path.filter != nil {
+		return 0, nil, badRequest(scimTypeInvalidFilter, "only eq filters are supported for users")
+	}
+
+	users, found, err := s.lookupUsers(ctx, cmp)
+	if err != nil {
+		return 0, nil, err
+	}
+	if found {
+		return http.StatusOK, listResponse(s.userResources(users), q), nil
+	}
+
+	query, err := s.userSearchQuery(cmp)
+	if err != nil {
+		return 0, nil, err
+	}
+	users, total, err := offsetPage(q, func(page, perPage int) ([]zendesk.User, int64, error) {
+		users, p, err := s.api.SearchUsers(ctx, &zendesk.SearchUsersOptions{
+			PageOptions: zendesk.PageOptions{Page: page, PerPage: perPage},
+			Query:       query,
+		})
+		return users, p.Count, err
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// the search matches words, so the hits which do not equal the value
+	// are dropped from the page. totalResults is the number of hits.
+	resources := make([]map[string]interface{}, 0, len(users))
+	for _, res := range s.userResources(users) {
+		if cmp.match(res) {
+			resources = append(resources, res)
+		}
+	}
+	return http.StatusOK, pagedResponse(resources, total, q), nil
+}
+
+func (s *Server) userResources(users []zendesk.User) []map[string]interface{} {
+	resources := make([]map[string]interface{}, len(users))
+	for i, u := range users {
+		resources[i] = s.userResource(u)
+	}
+	return resources
+}
+
+// lookupUsers answers an equality filter on the ID, or on an attribute
+// mapped onto the email or external ID, with the few users it can match.
+// It reports false for other filters.
+func (s *Server) lookupUsers(ctx context.Context, cmp compareExpr) ([]zendesk.User, bool, error) {
+	value, isString := cmp.value.(string)
+	if !isString {
+		return nil, false, nil
+	}
+
+	if cmp.path.urn == "" && strings.EqualFold(cmp.path.attr, "id") && cmp.path.sub == "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, true, nil
+		}
+		u, err := s.api.GetUser(ctx, id)
+		if err != nil {
+			if upstreamError(err).status == http.StatusNotFound {
+				return nil, true, nil
+			}
+			return nil, true, err
+		}
+		return []zendesk.User{u}, true, nil
+	}
+
+	for _, m := range s.mapping {
+		if !samePath(m.path, cmp.path) {
+			continue
+		}
+		switch m.target {
+		case "email":
+			users, _, err := s.api.SearchUsers(ctx, &zendesk.SearchUsersOptions{Query: value})
+			return users, true, err
+		case "external_id":
+			users, _, err := s.api.SearchUsers(ctx, &zendesk.SearchUsersOptions{ExternalIDs: value})
+			return users, true, err
+		}
+	}
+	return nil, false, nil
+}
+
+// searchKeywords are the keywords of the user search for the Zendesk
+// attributes it can match. Custom user fields are matched by their key.
+var searchKeywords = map[string]string{
+	"name":    "name",
+	"phone":   "phone",
+	"role":    "role",
+	"notes":   "notes",
+	"details": "details",
+	"tags":    "tags",
+}
+
+// userSearchQuery translates an equality filter into a user search query.
+// Filters on attributes which the search cannot match are rejected.
+func (s *Server) userSearchQuery(cmp compareExpr) (string, error) {
+	if cmp.path.urn == "" && strings.EqualFold(cmp.path.attr, "active") && cmp.path.sub == "" {
+		if active, ok := cmp.value.(bool); ok {
+			return "is_suspended:" + strconv.FormatBool(!active), nil
+		}
+	}
+
+	if value, ok := cmp.value.(string); ok {
+		for _, m := range s.mapping {
+			if !samePath(m.path, cmp.path) {
+				continue
+			}
+			keyword, ok := searchKeywords[m.target]
+			if strings.HasPrefix(m.target, userFieldPrefix) {
+				keyword, ok = strings.TrimPrefix(m.target, userFieldPrefix), true
+			}
+			if ok {
+				return keyword + `:"` + strings.ReplaceAll(value, `"`, "") + `"`, nil
+			}
+		}
+	}
+	return "", badRequest(scimTypeInvalidFilter, "filter on %q is not supported for users", cmp.path.attr)
+}
+
+func samePath(a, b attrPath) bool {
+	return a.filter == nil && b.filter == nil &&
+		strings.EqualFold(a.urn, b.urn) && strings.EqualFold(a.attr, b.attr) && strings.EqualFold(a.sub, b.sub)
+}
+
+func (s *Server) createUser(r *http.Request) (int, interface{}, error) {
+	res, err := decodeResource(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	if userName, _ := toString(pathValue(res, "userName")); userName == "" {
+		return 0, nil, badRequest(scimTypeInvalidValue, "userName is required")
+	}
+
+	var u zendesk.User
+	if _, _, err := s.userFromResource(res, nil, &u); err != nil {
+		return 0, nil, err
+	}
+	isActive, ok, err := active(res)
+	if err != nil {
+		return 0, nil, err
+	}
+	u.Suspended = ok && !isActive
+
+	created, err := s.api.CreateUser(r.Context(), u)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, s.userResource(created), nil
+}
+
+func (s *Server) replaceUser(r *http.Request, id int64) (int, interface{}, error) {
+	res, err := decodeResource(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// PUT replaces the user, so the mapped attributes missing from the
+	// resource are cleared like removed ones
+	current, err := s.api.GetUser(r.Context(), id)
+	if err != nil {
+		return 0, nil, err
+	}
+	var u zendesk.User
+	changed, cleared, err := s.userFromResource(res, s.userResource(current), &u)
+	if err != nil {
+		return 0, nil, err
+	}
+	if changed {
+		current, err = s.updateUser(r.Context(), id, u, cleared)
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+
+	current, err = s.setActive(r.Context(), current, res)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, s.userResource(current), nil
+}
+
+func (s *Server) patchUser(r *http.Request, id int64) (int, interface{}, error) {
+	ops, err := decodePatch(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	current, err := s.api.GetUser(r.Context(), id)
+	if err != nil {
+		return 0, nil, err
+	}
+	prev := s.userResource(current)
+	res := s.userResource(current)
+	if err := applyPatch(res, ops); err != nil {
+		return 0, nil, err
+	}
+
+	var u zendesk.User
+	changed, cleared, err := s.userFromResource(res, prev, &u)
+	if err != nil {
+		return 0, nil, err
+	}
+	if changed {
+		current, err = s.updateUser(r.Context(), id, u, cleared)
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+
+	current, err = s.setActive(r.Context(), current, res)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, s.userResource(current), nil
+}
+
+// setActive suspends or unsuspends the user when the active attribute of
+// the resource differs from the user
+func (s *Server) setActive(ctx context.Context, u zendesk.User, res map[string]interface{}) (zendesk.User, error) {
+	isActive, ok, err := active(res)
+	if err != nil || !ok || isActive != u.Suspended {
+		return u, err
+	}
+	return s.api.SetUserSuspended(ctx, u.ID, !isActive)
+}
+
+// updateUser updates the user and clears the cleared attributes. UpdateUser
+// can not clear them because empty values are omitted from its payload, so
+// the payload is built here with explicit nulls. The name and user fields,
+// which UpdateUser always sends, are left out when they were not applied.
+func (s *Server) updateUser(ctx context.Context, id int64, u zendesk.User, cleared []string) (zendesk.User, error) {
+	b, err := json.Marshal(u)
+	if err != nil {
+		return zendesk.User{}, err
+	}
+	var user map[string]interface{}
+	if err := json.Unmarshal(b, &user); err != nil {
+		return zendesk.User{}, err
+	}
+	if u.Name == "" {
+		delete(user, "name")
+	}
+	if u.UserFields == nil {
+		delete(user, "user_fields")
+	}
+	for _, attr := range cleared {
+		user[attr] = nil
+	}
+
+	body, err := s.api.Put(ctx, fmt.Sprintf("/users/%d.json", id), map[string]interface{}{"user": user})
+	if err != nil {
+		return zendesk.User{}, err
+	}
+	var result struct {
+		User zendesk.User `json:"user"`
+	}
+	err = json.Unmarshal(body, &result)
+	return result.User, err
+}
+
+// userFromResource applies the mapped attributes of a SCIM user resource
+// to the Zendesk user. When prev is given only the attributes which differ
+// from it are applied. It reports whether any attribute was applied, and
+// the Zendesk attributes which were removed or set to an empty value and
+// have to be cleared.
+func (s *Server) userFromResource(res, prev map[string]interface{}, u *zendesk.User) (bool, []string, error) {
+	changed := false
+	emptied := map[string]bool{}
+	for _, m := range s.mapping {
+		v, ok := m.path.get(res)
+		if prev != nil {
+			old, had := m.path.get(prev)
+			if ok == had && reflect.DeepEqual(v, old) {
+				continue
+			}
+		}
+		if !ok && prev == nil {
+			continue
+		}
+		if err := m.attr.set(u, v); err != nil {
+			return false, nil, badRequest(scimTypeInvalidValue, "%s: %s", m.path.attr, err)
+		}
+		changed = true
+		// user fields are sent with their null values already
+		if !strings.HasPrefix(m.target, userFieldPrefix) && m.attr.get(*u) == nil {
+			emptied[m.target] = true
+		}
+	}
+
+	var cleared []string
+	for _, m := range s.mapping {
+		if !emptied[m.target] {
+			continue
+		}
+		// another SCIM attribute mapped onto the same Zendesk attribute may
+		// still carry a value, e.g. displayName and name.formatted
+		if m.attr.get(*u) == nil && !s.mappedValue(res, m.target) {
+			cleared = append(cleared, m.target)
+		}
+		delete(emptied, m.target)
+	}
+
+	// Zendesk users require a name, so a missing one is made up from the
+	// name parts or the userName rather than cleared
+	if u.Name == "" && (prev == nil || removeString(&cleared, "name")) {
+		given, _ := toString(pathValue(res, "name.givenName"))
+		family, _ := toString(pathValue(res, "name.familyName"))
+		u.Name = strings.TrimSpace(given + " " + family)
+		if u.Name == "" {
+			u.Name, _ = toString(pathValue(res, "userName"))
+		}
+	}
+	return changed, cleared, nil
+}
+
+// mappedValue reports whether any SCIM attribute mapped onto the Zendesk
+// attribute has a non-empty value in the resource
+func (s *Server) mappedValue(res map[string]interface{}, target string) bool {
+	for _, m := range s.mapping {
+		if m.target != target {
+			continue
+		}
+		var u zendesk.User
+		if v, ok := m.path.get(res); ok && m.attr.set(&u, v) == nil && m.attr.get(u) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// removeString removes s from the list and reports whether it was there
+func removeString(list *[]string, s string) bool {
+	for i, v := range *list {
+		if v == s {
+			*list = append((*list)[:i], (*list)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
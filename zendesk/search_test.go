@@ -136,3 +136,26 @@ func TestSearchQueryParam(t *testing.T) {
 		t.Fatalf("Received error from search api")
 	}
 }
+
+func TestSearchExport(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/export.json" || r.URL.Query().Get("filter[type]") != "ticket" ||
+			r.URL.Query().Get("page[after]") != "abc" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write([]byte(`{"results":[{"id":4,"result_type":"ticket"}],"meta":{"has_more":true,"after_cursor":"def"}}`))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	opts := &SearchExportOptions{Query: "status:open", FilterType: "ticket"}
+	opts.PageAfter = "abc"
+	results, meta, err := client.SearchExport(ctx, opts)
+	if err != nil {
+		t.Fatalf("Failed to export search results: %s", err)
+	}
+
+	if len(results.List()) != 1 || !meta.HasMore || meta.AfterCursor != "def" {
+		t.Fatalf("Unexpected export results %v %+v", results.List(), meta)
+	}
+}
@@ -0,0 +1,24 @@
+package zendesk
+
+import "time"
+
+// Section is a Help Center section which groups articles of a category
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/sections/
+type Section struct {
+	ID              int64     `json:"id,omitempty"`
+	URL             string    `json:"url,omitempty"`
+	HTMLURL         string    `json:"html_url,omitempty"`
+	CategoryID      int64     `json:"category_id,omitempty"`
+	ParentSectionID int64     `json:"parent_section_id,omitempty"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description,omitempty"`
+	Locale          string    `json:"locale,omitempty"`
+	SourceLocale    string    `json:"source_locale,omitempty"`
+	Position        int       `json:"position,omitempty"`
+	Sorting         string    `json:"sorting,omitempty"`
+	Outdated        bool      `json:"outdated,omitempty"`
+	ThemeTemplate   string    `json:"theme_template,omitempty"`
+	CreatedAt       time.Time `json:"created_at,omitempty"`
+	UpdatedAt       time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,269 @@
+package seeder
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+var (
+	orgAdjectives = []string{"Northwind", "Blue Harbor", "Silverline", "Redwood", "Brightpath", "Ironclad", "Summit", "Evergreen", "Golden Gate", "Clearwater"}
+	orgNouns      = []string{"Logistics", "Analytics", "Foods", "Health", "Robotics", "Media", "Outfitters", "Energy", "Travel", "Software"}
+
+	firstNames = []string{"Olivia", "Liam", "Emma", "Noah", "Ava", "Mateo", "Sofia", "Hiro", "Amara", "Lucas", "Mia", "Arjun", "Chloe", "Kwame", "Ingrid", "Diego"}
+	lastNames  = []string{"Smith", "Garcia", "Nguyen", "Tanaka", "Okafor", "Müller", "Rossi", "Kowalski", "Silva", "Johansson", "Patel", "Dubois", "Kim", "Haddad"}
+
+	statuses   = []string{"new", "open", "open", "pending", "hold", "solved", "solved", "closed"}
+	priorities = []string{"low", "normal", "normal", "normal", "high", "urgent"}
+	types      = []string{"question", "question", "incident", "problem", "task"}
+
+	fieldWords = []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel"}
+)
+
+// topic is a kind of support request with its subjects and comment templates
+type topic struct {
+	tag       string
+	subjects  []string
+	questions []string
+	replies   []string
+}
+
+var topics = []topic{
+	{
+		tag:       "billing",
+		subjects:  []string{"Charged twice this month", "Invoice is missing", "Question about my bill", "Refund request"},
+		questions: []string{"I was charged twice for my subscription. Can you check?", "I can't find the invoice for last month.", "Could you explain the extra fee on my bill?"},
+		replies:   []string{"Thanks for reaching out. I've checked your account and issued a refund for the duplicate charge.", "I've resent the invoice to your email address.", "The fee is for the add-on enabled last month. I can disable it if you like."},
+	},
+	{
+		tag:       "login",
+		subjects:  []string{"Cannot log in", "Password reset email not arriving", "Two-factor code rejected"},
+		questions: []string{"I can't log in since this morning. It says my password is wrong.", "The password reset email never arrives.", "My two-factor code is always rejected."},
+		replies:   []string{"I've unlocked your account. Please try again.", "Please check your spam folder. I've also resent the email.", "Please make sure the clock on your phone is synchronized."},
+	},
+	{
+		tag:       "shipping",
+		subjects:  []string{"Where is my order?", "Package arrived damaged", "Change delivery address"},
+		questions: []string{"My order hasn't arrived yet. It's been two weeks.", "The package arrived damaged. What should I do?", "Can I change the delivery address of my order?"},
+		replies:   []string{"Your order is on its way. Here is the tracking number.", "Sorry about that! We'll send a replacement right away.", "I've updated the delivery address."},
+	},
+	{
+		tag:       "bug",
+		subjects:  []string{"Error when exporting report", "App crashes on startup", "Dashboard shows wrong numbers"},
+		questions: []string{"Exporting a report fails with an error.", "The app crashes right after I open it.", "The numbers on the dashboard don't match my data."},
+		replies:   []string{"Thanks for the report. Our engineers are looking into it.", "Could you send us the version of the app and your device model?", "We've found the cause and a fix will be released this week."},
+	},
+	{
+		tag:       "feature_request",
+		subjects:  []string{"Feature request: dark mode", "Can you add CSV import?", "Suggestion for the mobile app"},
+		questions: []string{"It would be great to have a dark mode.", "Please add a way to import data from CSV.", "I'd love to see offline support in the mobile app."},
+		replies:   []string{"Thanks for the suggestion! I've passed it on to our product team.", "This is on our roadmap. I'll let you know when it's available."},
+	},
+}
+
+var followUps = []string{
+	"Thanks, that worked!",
+	"It's still not working for me.",
+	"Any update on this?",
+	"Great, thank you for the quick help.",
+}
+
+// Dataset is the generated data before it is created in the account.
+// The same seed and ticket fields always generate the same Dataset.
+type Dataset struct {
+	Organizations []zendesk.Organization
+
+	// Users are the end users. OrganizationIndex is the index in Organizations.
+	Users []DatasetUser
+
+	Tickets []DatasetTicket
+}
+
+// DatasetUser is a generated end user
+type DatasetUser struct {
+	zendesk.User
+	OrganizationIndex int
+}
+
+// DatasetTicket is a generated ticket. RequesterIndex is the index in Users.
+type DatasetTicket struct {
+	zendesk.TicketImport
+	RequesterIndex int
+
+	// AgentComments are the indexes of Comments which are written by an agent
+	AgentComments []int
+}
+
+// Generate generates the dataset from the configuration and the ticket fields of the account
+func Generate(cfg Config, fields []zendesk.TicketField) Dataset {
+	cfg = cfg.withDefaults()
+	r := rand.New(rand.NewSource(cfg.Seed))
+	var ds Dataset
+
+	names := map[string]int{}
+	for i := 0; i < cfg.Organizations; i++ {
+		name := pick(r, orgAdjectives) + " " + pick(r, orgNouns)
+		names[name]++
+		if names[name] > 1 {
+			name += " " + strconv.Itoa(names[name])
+		}
+
+		ds.Organizations = append(ds.Organizations, zendesk.Organization{
+			Name:        name,
+			ExternalID:  fmt.Sprintf("%s-%d-org-%d", cfg.MarkerTag, cfg.Seed, i),
+			DomainNames: []string{slug(name) + "." + cfg.Domain},
+			Tags:        []string{cfg.MarkerTag},
+		})
+	}
+
+	for i := 0; i < cfg.Organizations*cfg.UsersPerOrganization; i++ {
+		orgIndex := i / cfg.UsersPerOrganization
+		first, last := pick(r, firstNames), pick(r, lastNames)
+
+		ds.Users = append(ds.Users, DatasetUser{
+			User: zendesk.User{
+				Name:       first + " " + last,
+				Email:      fmt.Sprintf("%s.%s.%d.%d@%s", slug(first), slug(last), cfg.Seed, i, ds.Organizations[orgIndex].DomainNames[0]),
+				ExternalID: fmt.Sprintf("%s-%d-user-%d", cfg.MarkerTag, cfg.Seed, i),
+				Role:       "end-user",
+				Verified:   true,
+				Tags:       []string{cfg.MarkerTag},
+			},
+			OrganizationIndex: orgIndex,
+		})
+	}
+
+	if len(ds.Users) == 0 {
+		return ds
+	}
+
+	start := cfg.Until.Add(-cfg.Period)
+	for i := 0; i < cfg.Tickets; i++ {
+		tp := topics[r.Intn(len(topics))]
+		createdAt := start.Add(time.Duration(r.Int63n(int64(cfg.Period)))).Truncate(time.Second)
+
+		t := DatasetTicket{RequesterIndex: r.Intn(len(ds.Users))}
+		t.Subject = pick(r, tp.subjects)
+		t.Status = pick(r, statuses)
+		t.Priority = pick(r, priorities)
+		t.Type = pick(r, types)
+		t.Tags = []string{cfg.MarkerTag, tp.tag}
+		t.CustomFields = generateCustomFields(r, fields)
+
+		at := createdAt
+		public := true
+		comments := 1 + r.Intn(cfg.MaxComments)
+		for c := 0; c < comments; c++ {
+			body := pick(r, tp.questions)
+			if c > 0 {
+				at = at.Add(time.Duration(1+r.Intn(48)) * time.Hour)
+				if c%2 == 1 {
+					body = pick(r, tp.replies)
+					t.AgentComments = append(t.AgentComments, c)
+				} else {
+					body = pick(r, followUps)
+				}
+			}
+			t.Comments = append(t.Comments, zendesk.TicketComment{
+				Body:      body,
+				Public:    &public,
+				CreatedAt: at,
+			})
+		}
+
+		t.CreatedAt = &createdAt
+		updatedAt := at
+		t.UpdatedAt = &updatedAt
+		if t.Status == "solved" || t.Status == "closed" {
+			t.SolvedAt = &updatedAt
+		}
+
+		ds.Tickets = append(ds.Tickets, t)
+	}
+
+	return ds
+}
+
+// system ticket fields which are set directly on the ticket
+var systemFieldTypes = map[string]bool{
+	"subject":       true,
+	"description":   true,
+	"status":        true,
+	"custom_status": true,
+	"tickettype":    true,
+	"priority":      true,
+	"group":         true,
+	"assignee":      true,
+}
+
+// generateCustomFields generates values for the active custom ticket fields.
+// Required fields always get a value and the others get one most of the time.
+func generateCustomFields(r *rand.Rand, fields []zendesk.TicketField) []zendesk.CustomField {
+	var values []zendesk.CustomField
+
+	for _, f := range fields {
+		if !f.Active || systemFieldTypes[f.Type] {
+			continue
+		}
+		if !f.Required && r.Intn(10) < 3 {
+			continue
+		}
+
+		var value interface{}
+		switch f.Type {
+		case "text":
+			value = pick(r, fieldWords) + " " + pick(r, fieldWords)
+		case "textarea":
+			value = pick(r, followUps)
+		case "checkbox":
+			value = r.Intn(2) == 0
+		case "date":
+			value = time.Date(2024, time.January, 1+r.Intn(365), 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+		case "integer":
+			value = strconv.Itoa(r.Intn(1000))
+		case "decimal":
+			value = strconv.FormatFloat(float64(r.Intn(100000))/100, 'f', 2, 64)
+		case "tagger":
+			if len(f.CustomFieldOptions) == 0 {
+				continue
+			}
+			value = f.CustomFieldOptions[r.Intn(len(f.CustomFieldOptions))].Value
+		case "multiselect":
+			if len(f.CustomFieldOptions) == 0 {
+				continue
+			}
+			var selected []string
+			for _, i := range r.Perm(len(f.CustomFieldOptions))[:1+r.Intn(len(f.CustomFieldOptions))] {
+				selected = append(selected, f.CustomFieldOptions[i].Value)
+			}
+			value = selected
+		default:
+			// regexp, partialcreditcard, lookup and unknown types cannot be generated safely
+			continue
+		}
+
+		values = append(values, zendesk.CustomField{ID: f.ID, Value: value})
+	}
+	return values
+}
+
+func pick(r *rand.Rand, list []string) string {
+	return list[r.Intn(len(list))]
+}
+
+func slug(s string) string {
+	var b strings.Builder
+	for _, c := range strings.ToLower(s) {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+			b.WriteRune(c)
+		case c == ' ' || c == '-':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,334 @@
+// Package seeder fills a sandbox account with realistic synthetic organizations,
+// users and tickets with comment threads, tags and custom field values generated
+// from the account's ticket field definitions. The data is generated
+// deterministically from a seed, created with bulk endpoints and tagged with a
+// marker tag, so it can be removed again by Cleanup.
+package seeder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+// DefaultMarkerTag is the tag added to every created resource when Config.MarkerTag is empty
+const DefaultMarkerTag = "synthetic_seed"
+
+// maxBatchSize is the maximum number of items of a bulk request
+const maxBatchSize = 100
+
+// Config is configuration of the Seeder
+type Config struct {
+	// Seed makes the generated data deterministic
+	Seed int64
+
+	// Organizations is the number of organizations to create
+	Organizations int
+
+	// UsersPerOrganization is the number of end users to create in each organization
+	UsersPerOrganization int
+
+	// Tickets is the number of tickets to create
+	Tickets int
+
+	// MaxComments is the maximum number of comments of a ticket. Defaults to 4.
+	MaxComments int
+
+	// MarkerTag is added to every created resource. Defaults to DefaultMarkerTag.
+	MarkerTag string
+
+	// Domain is the domain of the organizations and the user emails. Defaults to example.com.
+	Domain string
+
+	// Until is the end of the period in which tickets are created. Defaults to now.
+	Until time.Time
+
+	// Period is the length of the period in which tickets are created. Defaults to 90 days.
+	Period time.Duration
+
+	// BatchSize is the number of items of a bulk request. Defaults to and is capped at 100.
+	// Use a smaller size together with zendesk.Client.SetScheduler to spread the load.
+	BatchSize int
+
+	// PollInterval is the interval to poll the job statuses of bulk requests. Defaults to 1 second.
+	PollInterval time.Duration
+
+	// AgentIDs are the authors of agent replies. Defaults to the agents and admins of the account.
+	AgentIDs []int64
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxComments <= 0 {
+		c.MaxComments = 4
+	}
+	if c.MarkerTag == "" {
+		c.MarkerTag = DefaultMarkerTag
+	}
+	if c.Domain == "" {
+		c.Domain = "example.com"
+	}
+	if c.Until.IsZero() {
+		c.Until = time.Now()
+	}
+	if c.Period <= 0 {
+		c.Period = 90 * 24 * time.Hour
+	}
+	if c.BatchSize <= 0 || c.BatchSize > maxBatchSize {
+		c.BatchSize = maxBatchSize
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	return c
+}
+
+// Result is the IDs of the resources created or deleted by the Seeder
+type Result struct {
+	OrganizationIDs []int64
+	UserIDs         []int64
+	TicketIDs       []int64
+
+	// Errors are the items which failed in the bulk jobs
+	Errors []error
+}
+
+// Seeder creates synthetic data in an account
+type Seeder struct {
+	api zendesk.API
+	cfg Config
+}
+
+// New returns a Seeder
+func New(api zendesk.API, cfg Config) *Seeder {
+	return &Seeder{api: api, cfg: cfg.withDefaults()}
+}
+
+// Seed generates the dataset and creates it in the account. Organizations and
+// users are created with the create many endpoints and tickets are imported with
+// their comment threads and timestamps, so triggers and notifications do not run.
+func (s *Seeder) Seed(ctx context.Context) (Result, error) {
+	var result Result
+
+	var fields []zendesk.TicketField
+	if s.cfg.Tickets > 0 {
+		it := s.api.GetTicketFieldsIterator(ctx, zendesk.NewPaginationOptions())
+		for it.HasMore() {
+			page, err := it.GetNext()
+			if err != nil {
+				return result, err
+			}
+			fields = append(fields, page...)
+		}
+	}
+
+	agentIDs := s.cfg.AgentIDs
+	if s.cfg.Tickets > 0 && len(agentIDs) == 0 {
+		opts := zendesk.NewPaginationOptions()
+		opts.Roles = []string{"agent", "admin"}
+		it := s.api.GetUsersIterator(ctx, opts)
+		for it.HasMore() {
+			agents, err := it.GetNext()
+			if err != nil {
+				return result, err
+			}
+			for _, a := range agents {
+				agentIDs = append(agentIDs, a.ID)
+			}
+		}
+	}
+
+	ds := Generate(s.cfg, fields)
+
+	orgIDs, err := s.createInBatches(ctx, len(ds.Organizations), func(from, to int) (zendesk.JobStatus, error) {
+		return s.api.CreateManyOrganizations(ctx, ds.Organizations[from:to])
+	}, &result)
+	if err != nil {
+		return result, err
+	}
+	result.OrganizationIDs = compact(orgIDs)
+
+	users := make([]zendesk.User, len(ds.Users))
+	for i, u := range ds.Users {
+		users[i] = u.User
+		users[i].OrganizationID = orgIDs[u.OrganizationIndex]
+	}
+	userIDs, err := s.createInBatches(ctx, len(users), func(from, to int) (zendesk.JobStatus, error) {
+		return s.api.CreateManyUsers(ctx, users[from:to])
+	}, &result)
+	if err != nil {
+		return result, err
+	}
+	result.UserIDs = compact(userIDs)
+
+	var tickets []zendesk.TicketImport
+	for i, t := range ds.Tickets {
+		requesterID := userIDs[t.RequesterIndex]
+		if requesterID == 0 {
+			result.Errors = append(result.Errors, fmt.Errorf("ticket %d: requester was not created", i))
+			continue
+		}
+
+		ticket := t.TicketImport
+		ticket.RequesterID = requesterID
+		ticket.OrganizationID = orgIDs[ds.Users[t.RequesterIndex].OrganizationIndex]
+		ticket.Comments = append([]zendesk.TicketComment(nil), t.Comments...)
+		for c := range ticket.Comments {
+			ticket.Comments[c].AuthorID = requesterID
+		}
+		if len(agentIDs) > 0 {
+			agentID := agentIDs[i%len(agentIDs)]
+			ticket.AssigneeID = agentID
+			for _, c := range t.AgentComments {
+				ticket.Comments[c].AuthorID = agentID
+			}
+		}
+		tickets = append(tickets, ticket)
+	}
+	ticketIDs, err := s.createInBatches(ctx, len(tickets), func(from, to int) (zendesk.JobStatus, error) {
+		return s.api.ImportManyTickets(ctx, tickets[from:to], nil)
+	}, &result)
+	if err != nil {
+		return result, err
+	}
+	result.TicketIDs = compact(ticketIDs)
+
+	return result, nil
+}
+
+// createInBatches calls create for each batch of n items and waits for its job.
+// It returns the created IDs by the index of the items, which is 0 for failed items.
+func (s *Seeder) createInBatches(
+	ctx context.Context, n int, create func(from, to int) (zendesk.JobStatus, error), result *Result,
+) ([]int64, error) {
+	ids := make([]int64, n)
+
+	for from := 0; from < n; from += s.cfg.BatchSize {
+		to := from + s.cfg.BatchSize
+		if to > n {
+			to = n
+		}
+
+		job, err := create(from, to)
+		if err != nil {
+			return ids, err
+		}
+		job, err = zendesk.WaitJobStatus(ctx, s.api, job, s.cfg.PollInterval)
+		if err != nil {
+			return ids, err
+		}
+		if job.Status != zendesk.JobStatusCompleted {
+			return ids, fmt.Errorf("seeder: job %s is %s: %s", job.ID, job.Status, job.Message)
+		}
+
+		for k, r := range job.Results {
+			// results of create_many are in the order of the items
+			i := k
+			if r.Index != nil {
+				i = *r.Index
+			}
+			if i < 0 || from+i >= to {
+				continue
+			}
+			if r.Error != "" {
+				result.Errors = append(result.Errors, fmt.Errorf("item %d: %s %s", from+i, r.Error, r.Details))
+				continue
+			}
+			ids[from+i] = r.ID
+		}
+	}
+	return ids, nil
+}
+
+// Cleanup deletes the tickets, users and organizations which have the marker tag.
+// Search returns at most 1000 results, so Cleanup may need to be called again
+// until the returned Result is empty.
+func (s *Seeder) Cleanup(ctx context.Context) (Result, error) {
+	var result Result
+
+	steps := []struct {
+		resultType string
+		ids        *[]int64
+		delete     func(ctx context.Context, ids []int64) (zendesk.JobStatus, error)
+	}{
+		{"ticket", &result.TicketIDs, s.api.DeleteManyTickets},
+		{"user", &result.UserIDs, s.api.DeleteManyUsers},
+		{"organization", &result.OrganizationIDs, s.api.DeleteManyOrganizations},
+	}
+
+	for _, step := range steps {
+		ids, err := s.searchMarked(ctx, step.resultType)
+		if err != nil {
+			return result, err
+		}
+
+		for from := 0; from < len(ids); from += s.cfg.BatchSize {
+			to := from + s.cfg.BatchSize
+			if to > len(ids) {
+				to = len(ids)
+			}
+
+			job, err := step.delete(ctx, ids[from:to])
+			if err != nil {
+				return result, err
+			}
+			job, err = zendesk.WaitJobStatus(ctx, s.api, job, s.cfg.PollInterval)
+			if err != nil {
+				return result, err
+			}
+			if job.Status != zendesk.JobStatusCompleted {
+				return result, fmt.Errorf("seeder: job %s is %s: %s", job.ID, job.Status, job.Message)
+			}
+			*step.ids = append(*step.ids, ids[from:to]...)
+		}
+	}
+
+	return result, nil
+}
+
+// searchMarked returns the IDs of the resources of the type which have the marker tag
+func (s *Seeder) searchMarked(ctx context.Context, resultType string) ([]int64, error) {
+	var ids []int64
+	opts := &zendesk.SearchOptions{
+		PageOptions: zendesk.PageOptions{PerPage: 100, Page: 1},
+		Query:       fmt.Sprintf("type:%s tags:%s", resultType, s.cfg.MarkerTag),
+	}
+
+	for {
+		results, page, err := s.api.Search(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range results.List() {
+			switch v := r.(type) {
+			case zendesk.Ticket:
+				ids = append(ids, v.ID)
+			case zendesk.User:
+				ids = append(ids, v.ID)
+			case zendesk.Organization:
+				ids = append(ids, v.ID)
+			default:
+				return nil, errors.New("seeder: unexpected search result")
+			}
+		}
+
+		if !page.HasNext() || len(results.List()) == 0 {
+			return ids, nil
+		}
+		opts.Page++
+	}
+}
+
+// compact returns the non-zero IDs
+func compact(ids []int64) []int64 {
+	var out []int64
+	for _, id := range ids {
+		if id != 0 {
+			out = append(out, id)
+		}
+	}
+	return out
+}
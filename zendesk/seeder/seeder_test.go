@@ -0,0 +1,216 @@
+package seeder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+var testFields = []zendesk.TicketField{
+	{ID: 1, Type: "subject", Active: true},
+	{ID: 2, Type: "tagger", Active: true, Required: true, CustomFieldOptions: []zendesk.CustomFieldOption{{Value: "plan_free"}, {Value: "plan_pro"}}},
+	{ID: 3, Type: "checkbox", Active: true, Required: true},
+	{ID: 4, Type: "regexp", Active: true, Required: true},
+	{ID: 5, Type: "text", Active: false},
+}
+
+func testConfig() Config {
+	return Config{
+		Seed:                 42,
+		Organizations:        3,
+		UsersPerOrganization: 2,
+		Tickets:              5,
+		Until:                time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		BatchSize:            2,
+		PollInterval:         time.Millisecond,
+	}
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	a := Generate(testConfig(), testFields)
+	b := Generate(testConfig(), testFields)
+	if !reflect.DeepEqual(a, b) {
+		t.Fatal("The same seed should generate the same dataset")
+	}
+
+	cfg := testConfig()
+	cfg.Seed = 43
+	if reflect.DeepEqual(a, Generate(cfg, testFields)) {
+		t.Fatal("Different seeds should generate different datasets")
+	}
+
+	if len(a.Organizations) != 3 || len(a.Users) != 6 || len(a.Tickets) != 5 {
+		t.Fatalf("Unexpected volume %d/%d/%d", len(a.Organizations), len(a.Users), len(a.Tickets))
+	}
+	if a.Users[5].OrganizationIndex != 2 || !strings.Contains(a.Users[5].Email, a.Organizations[2].DomainNames[0]) {
+		t.Fatalf("Users should belong to organizations in order: %+v", a.Users[5])
+	}
+
+	for _, ticket := range a.Tickets {
+		if ticket.Tags[0] != DefaultMarkerTag {
+			t.Fatalf("Tickets should have the marker tag: %v", ticket.Tags)
+		}
+		if len(ticket.Comments) == 0 || len(ticket.Comments) > 4 {
+			t.Fatalf("Unexpected number of comments %d", len(ticket.Comments))
+		}
+		if ticket.CreatedAt.After(testConfig().Until) || ticket.CreatedAt.Before(testConfig().Until.Add(-90*24*time.Hour)) {
+			t.Fatalf("Ticket is created out of the period: %s", ticket.CreatedAt)
+		}
+
+		values := map[int64]interface{}{}
+		for _, cf := range ticket.CustomFields {
+			values[cf.ID] = cf.Value
+		}
+		if v, ok := values[2].(string); !ok || !strings.HasPrefix(v, "plan_") {
+			t.Fatalf("Dropdown should have one of its options: %v", values)
+		}
+		if _, ok := values[3].(bool); !ok {
+			t.Fatalf("Checkbox should have a bool value: %v", values)
+		}
+		for _, id := range []int64{1, 4, 5} {
+			if _, ok := values[id]; ok {
+				t.Fatalf("Field %d should not have a value: %v", id, values)
+			}
+		}
+	}
+}
+
+// newTestServer is a fake account whose bulk jobs complete immediately
+func newTestServer(t *testing.T, requests map[string]int, imported *[]zendesk.TicketImport) *httptest.Server {
+	t.Helper()
+
+	var mu sync.Mutex
+	nextID := 1000
+	completed := func(w http.ResponseWriter, n int) {
+		results := make([]map[string]interface{}, n)
+		for i := range results {
+			nextID++
+			results[i] = map[string]interface{}{"id": nextID, "index": i, "success": true}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"job_status": map[string]interface{}{"id": "job", "status": "completed", "results": results},
+		})
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests[r.Method+" "+r.URL.Path]++
+
+		var data map[string][]json.RawMessage
+		if r.Method == http.MethodPost {
+			json.NewDecoder(r.Body).Decode(&data)
+		}
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /ticket_fields.json":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ticket_fields": testFields})
+		case "GET /users.json":
+			if roles := r.URL.Query()["role[]"]; len(roles) != 2 {
+				t.Errorf("Agents should be listed by role, but got %v", roles)
+			}
+			// the agents span two pages
+			if r.URL.Query().Get("page[after]") == "" {
+				w.Write([]byte(`{"users":[{"id":1,"role":"agent"}],"meta":{"has_more":true,"after_cursor":"next"}}`))
+			} else {
+				w.Write([]byte(`{"users":[{"id":2,"role":"admin"}],"meta":{"has_more":false}}`))
+			}
+		case "POST /organizations/create_many.json":
+			completed(w, len(data["organizations"]))
+		case "POST /users/create_many.json":
+			completed(w, len(data["users"]))
+		case "POST /imports/tickets/create_many.json":
+			for _, raw := range data["tickets"] {
+				var ticket zendesk.TicketImport
+				json.Unmarshal(raw, &ticket)
+				*imported = append(*imported, ticket)
+			}
+			completed(w, len(data["tickets"]))
+		case "GET /search.json":
+			query := r.URL.Query().Get("query")
+			if !strings.HasSuffix(query, "tags:"+DefaultMarkerTag) {
+				t.Errorf("Unexpected search query %s", query)
+			}
+			resultType := strings.TrimPrefix(strings.Fields(query)[0], "type:")
+			fmt.Fprintf(w, `{"results":[{"id":1,"result_type":%q},{"id":2,"result_type":%q},{"id":3,"result_type":%q}],"next_page":null}`,
+				resultType, resultType, resultType)
+		case "DELETE /tickets/destroy_many.json", "DELETE /users/destroy_many.json", "DELETE /organizations/destroy_many.json":
+			w.Write([]byte(`{"job_status":{"id":"job","status":"queued"}}`))
+		case "GET /job_statuses/job.json":
+			w.Write([]byte(`{"job_status":{"id":"job","status":"completed"}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestSeed(t *testing.T) {
+	requests := map[string]int{}
+	var imported []zendesk.TicketImport
+	s := newTestServer(t, requests, &imported)
+
+	client, _ := zendesk.NewClient(nil)
+	client.SetEndpointURL(s.URL)
+
+	result, err := New(client, testConfig()).Seed(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to seed: %s", err)
+	}
+
+	if len(result.OrganizationIDs) != 3 || len(result.UserIDs) != 6 || len(result.TicketIDs) != 5 || len(result.Errors) != 0 {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+	if requests["POST /organizations/create_many.json"] != 2 || requests["POST /users/create_many.json"] != 3 ||
+		requests["POST /imports/tickets/create_many.json"] != 3 {
+		t.Fatalf("Resources should be created in batches of 2: %v", requests)
+	}
+	if requests["GET /users.json"] != 2 {
+		t.Fatalf("Every page of agents should be read: %v", requests)
+	}
+
+	for _, ticket := range imported {
+		if ticket.RequesterID == 0 || ticket.OrganizationID == 0 || ticket.AssigneeID == 0 {
+			t.Fatalf("Ticket should refer to the created resources: %+v", ticket.Ticket)
+		}
+		for i, c := range ticket.Comments {
+			if i == 1 && c.AuthorID != ticket.AssigneeID {
+				t.Fatalf("Replies should be written by the agent: %+v", c)
+			}
+			if i != 1 && c.AuthorID != ticket.RequesterID && c.AuthorID != ticket.AssigneeID {
+				t.Fatalf("Unexpected comment author %d", c.AuthorID)
+			}
+		}
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	requests := map[string]int{}
+	s := newTestServer(t, requests, nil)
+
+	client, _ := zendesk.NewClient(nil)
+	client.SetEndpointURL(s.URL)
+
+	result, err := New(client, testConfig()).Cleanup(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to clean up: %s", err)
+	}
+
+	if len(result.TicketIDs) != 3 || len(result.UserIDs) != 3 || len(result.OrganizationIDs) != 3 {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+	if requests["DELETE /tickets/destroy_many.json"] != 2 || requests["GET /job_statuses/job.json"] != 6 {
+		t.Fatalf("Resources should be deleted in batches of 2: %v", requests)
+	}
+}
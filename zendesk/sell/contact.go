@@ -0,0 +1,101 @@
+package sell
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Contact is a person or an organization of the sales pipeline
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/contacts/
+type Contact struct {
+	ID                   int64                  `json:"id,omitempty"`
+	CreatorID            int64                  `json:"creator_id,omitempty"`
+	OwnerID              int64                  `json:"owner_id,omitempty"`
+	IsOrganization       bool                   `json:"is_organization,omitempty"`
+	ContactID            int64                  `json:"contact_id,omitempty"`
+	ParentOrganizationID int64                  `json:"parent_organization_id,omitempty"`
+	Name                 string                 `json:"name,omitempty"`
+	FirstName            string                 `json:"first_name,omitempty"`
+	LastName             string                 `json:"last_name,omitempty"`
+	CustomerStatus       string                 `json:"customer_status,omitempty"`
+	ProspectStatus       string                 `json:"prospect_status,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Industry             string                 `json:"industry,omitempty"`
+	Website              string                 `json:"website,omitempty"`
+	Email                string                 `json:"email,omitempty"`
+	Phone                string                 `json:"phone,omitempty"`
+	Mobile               string                 `json:"mobile,omitempty"`
+	Fax                  string                 `json:"fax,omitempty"`
+	Twitter              string                 `json:"twitter,omitempty"`
+	Facebook             string                 `json:"facebook,omitempty"`
+	Linkedin             string                 `json:"linkedin,omitempty"`
+	Skype                string                 `json:"skype,omitempty"`
+	Address              *Address               `json:"address,omitempty"`
+	Tags                 []string               `json:"tags,omitempty"`
+	CustomFields         map[string]interface{} `json:"custom_fields,omitempty"`
+	CreatedAt            *time.Time             `json:"created_at,omitempty"`
+	UpdatedAt            *time.Time             `json:"updated_at,omitempty"`
+}
+
+// ContactListOptions is options of GetContacts
+type ContactListOptions struct {
+	ListOptions
+	OwnerID        int64  `url:"owner_id,omitempty"`
+	IsOrganization *bool  `url:"is_organization,omitempty"`
+	ContactID      int64  `url:"contact_id,omitempty"`
+	Name           string `url:"name,omitempty"`
+	Email          string `url:"email,omitempty"`
+	CustomerStatus string `url:"customer_status,omitempty"`
+	ProspectStatus string `url:"prospect_status,omitempty"`
+}
+
+// ContactAPI is an interface containing contact related methods
+type ContactAPI interface {
+	GetContacts(ctx context.Context, opts *ContactListOptions) ([]Contact, Meta, error)
+	GetContact(ctx context.Context, id int64) (Contact, error)
+	CreateContact(ctx context.Context, contact Contact) (Contact, error)
+	UpdateContact(ctx context.Context, id int64, contact Contact) (Contact, error)
+	DeleteContact(ctx context.Context, id int64) error
+}
+
+// GetContacts lists contacts
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/contacts/#retrieve-all-contacts
+func (c *Client) GetContacts(ctx context.Context, opts *ContactListOptions) ([]Contact, Meta, error) {
+	tmp := opts
+	if tmp == nil {
+		tmp = &ContactListOptions{}
+	}
+	return list[Contact](ctx, c, "/v2/contacts", tmp)
+}
+
+// GetContact gets a contact
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/contacts/#retrieve-a-single-contact
+func (c *Client) GetContact(ctx context.Context, id int64) (Contact, error) {
+	return getOne[Contact](ctx, c, resourcePath("/v2/contacts", id))
+}
+
+// CreateContact creates a contact
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/contacts/#create-a-contact
+func (c *Client) CreateContact(ctx context.Context, contact Contact) (Contact, error) {
+	return sendOne[Contact](ctx, c, http.MethodPost, "/v2/contacts", contact)
+}
+
+// UpdateContact updates a contact
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/contacts/#update-a-contact
+func (c *Client) UpdateContact(ctx context.Context, id int64, contact Contact) (Contact, error) {
+	return sendOne[Contact](ctx, c, http.MethodPut, resourcePath("/v2/contacts", id), contact)
+}
+
+// DeleteContact deletes a contact
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/contacts/#delete-a-contact
+func (c *Client) DeleteContact(ctx context.Context, id int64) error {
+	return deleteOne(ctx, c, resourcePath("/v2/contacts", id))
+}
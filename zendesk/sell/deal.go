@@ -0,0 +1,94 @@
+package sell
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Deal is a sales opportunity with a contact
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/deals/
+type Deal struct {
+	ID                      int64                  `json:"id,omitempty"`
+	CreatorID               int64                  `json:"creator_id,omitempty"`
+	OwnerID                 int64                  `json:"owner_id,omitempty"`
+	Name                    string                 `json:"name,omitempty"`
+	Value                   json.Number            `json:"value,omitempty"`
+	Currency                string                 `json:"currency,omitempty"`
+	Hot                     bool                   `json:"hot,omitempty"`
+	StageID                 int64                  `json:"stage_id,omitempty"`
+	LastStageChangeAt       *time.Time             `json:"last_stage_change_at,omitempty"`
+	SourceID                int64                  `json:"source_id,omitempty"`
+	LossReasonID            int64                  `json:"loss_reason_id,omitempty"`
+	UnqualifiedReasonID     int64                  `json:"unqualified_reason_id,omitempty"`
+	DropboxEmail            string                 `json:"dropbox_email,omitempty"`
+	ContactID               int64                  `json:"contact_id,omitempty"`
+	OrganizationID          int64                  `json:"organization_id,omitempty"`
+	EstimatedCloseDate      string                 `json:"estimated_close_date,omitempty"`
+	CustomizedWinLikelihood int                    `json:"customized_win_likelihood,omitempty"`
+	Tags                    []string               `json:"tags,omitempty"`
+	CustomFields            map[string]interface{} `json:"custom_fields,omitempty"`
+	CreatedAt               *time.Time             `json:"created_at,omitempty"`
+	UpdatedAt               *time.Time             `json:"updated_at,omitempty"`
+}
+
+// DealListOptions is options of GetDeals
+type DealListOptions struct {
+	ListOptions
+	OwnerID        int64  `url:"owner_id,omitempty"`
+	ContactID      int64  `url:"contact_id,omitempty"`
+	OrganizationID int64  `url:"organization_id,omitempty"`
+	StageID        int64  `url:"stage_id,omitempty"`
+	Hot            *bool  `url:"hot,omitempty"`
+	Name           string `url:"name,omitempty"`
+}
+
+// DealAPI is an interface containing deal related methods
+type DealAPI interface {
+	GetDeals(ctx context.Context, opts *DealListOptions) ([]Deal, Meta, error)
+	GetDeal(ctx context.Context, id int64) (Deal, error)
+	CreateDeal(ctx context.Context, deal Deal) (Deal, error)
+	UpdateDeal(ctx context.Context, id int64, deal Deal) (Deal, error)
+	DeleteDeal(ctx context.Context, id int64) error
+}
+
+// GetDeals lists deals
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/deals/#retrieve-all-deals
+func (c *Client) GetDeals(ctx context.Context, opts *DealListOptions) ([]Deal, Meta, error) {
+	tmp := opts
+	if tmp == nil {
+		tmp = &DealListOptions{}
+	}
+	return list[Deal](ctx, c, "/v2/deals", tmp)
+}
+
+// GetDeal gets a deal
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/deals/#retrieve-a-single-deal
+func (c *Client) GetDeal(ctx context.Context, id int64) (Deal, error) {
+	return getOne[Deal](ctx, c, resourcePath("/v2/deals", id))
+}
+
+// CreateDeal creates a deal
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/deals/#create-a-deal
+func (c *Client) CreateDeal(ctx context.Context, deal Deal) (Deal, error) {
+	return sendOne[Deal](ctx, c, http.MethodPost, "/v2/deals", deal)
+}
+
+// UpdateDeal updates a deal
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/deals/#update-a-deal
+func (c *Client) UpdateDeal(ctx context.Context, id int64, deal Deal) (Deal, error) {
+	return sendOne[Deal](ctx, c, http.MethodPut, resourcePath("/v2/deals", id), deal)
+}
+
+// DeleteDeal deletes a deal
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/deals/#delete-a-deal
+func (c *Client) DeleteDeal(ctx context.Context, id int64) error {
+	return deleteOne(ctx, c, resourcePath("/v2/deals", id))
+}
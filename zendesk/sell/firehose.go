@@ -0,0 +1,138 @@
+package sell
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Resources which have a firehose stream
+const (
+	StreamLeads    = "leads"
+	StreamContacts = "contacts"
+	StreamDeals    = "deals"
+	StreamNotes    = "notes"
+	StreamTasks    = "tasks"
+)
+
+// StreamPositionTail is the position of the oldest event kept in a stream
+const StreamPositionTail = "tail"
+
+// StreamEvent is a change of a resource in a firehose stream
+type StreamEvent struct {
+	Type      string
+	EventType string
+	EventID   string
+	EventTime time.Time
+	Data      json.RawMessage
+	Previous  json.RawMessage
+}
+
+// Decode decodes the resource of the event into v
+func (e StreamEvent) Decode(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+// StreamPage is a page of firehose events
+type StreamPage struct {
+	Events []StreamEvent
+
+	// Position is the position to read the next page from
+	Position string
+
+	// Top reports whether the page reached the latest event of the stream
+	Top bool
+}
+
+// FirehoseAPI is an interface containing the firehose methods
+type FirehoseAPI interface {
+	GetStream(ctx context.Context, resource, position string) (StreamPage, error)
+}
+
+// GetStream reads the events of a resource stream from position. Pass
+// StreamPositionTail to read from the oldest event, and the Position of the
+// returned page to continue reading.
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/firehose/introduction/
+func (c *Client) GetStream(ctx context.Context, resource, position string) (StreamPage, error) {
+	var result struct {
+		Items []struct {
+			Data json.RawMessage `json:"data"`
+			Meta struct {
+				Type      string          `json:"type"`
+				EventType string          `json:"event_type"`
+				EventID   string          `json:"event_id"`
+				EventTime time.Time       `json:"event_time"`
+				Previous  json.RawMessage `json:"previous"`
+			} `json:"meta"`
+		} `json:"items"`
+		Meta struct {
+			Position string `json:"position"`
+			Top      bool   `json:"top"`
+		} `json:"meta"`
+	}
+
+	var opts struct {
+		Position string `url:"position,omitempty"`
+	}
+	opts.Position = position
+
+	u, err := addOptions(fmt.Sprintf("/v3/%s/stream", resource), opts)
+	if err != nil {
+		return StreamPage{}, err
+	}
+
+	body, _, err := c.do(ctx, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return StreamPage{}, err
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return StreamPage{}, err
+	}
+
+	page := StreamPage{
+		Events:   make([]StreamEvent, len(result.Items)),
+		Position: result.Meta.Position,
+		Top:      result.Meta.Top,
+	}
+	for i, item := range result.Items {
+		page.Events[i] = StreamEvent{
+			Type:      item.Meta.Type,
+			EventType: item.Meta.EventType,
+			EventID:   item.Meta.EventID,
+			EventTime: item.Meta.EventTime,
+			Data:      item.Data,
+			Previous:  item.Meta.Previous,
+		}
+	}
+	return page, nil
+}
+
+// ReadStream reads the events of a resource stream from position until it
+// reaches the latest event, and calls fn with every event. It returns the
+// position to continue from, which is the position of the last page fn
+// handled completely.
+func ReadStream(ctx context.Context, api FirehoseAPI, resource, position string, fn func(StreamEvent) error) (string, error) {
+	for {
+		page, err := api.GetStream(ctx, resource, position)
+		if err != nil {
+			return position, err
+		}
+
+		for _, e := range page.Events {
+			if err := fn(e); err != nil {
+				return position, err
+			}
+		}
+
+		if page.Position != "" {
+			position = page.Position
+		}
+		if page.Top || len(page.Events) == 0 {
+			return position, nil
+		}
+	}
+}
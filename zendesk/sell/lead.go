@@ -0,0 +1,96 @@
+package sell
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Lead is an individual or organization which expressed interest in the products
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/leads/
+type Lead struct {
+	ID               int64                  `json:"id,omitempty"`
+	CreatorID        int64                  `json:"creator_id,omitempty"`
+	OwnerID          int64                  `json:"owner_id,omitempty"`
+	FirstName        string                 `json:"first_name,omitempty"`
+	LastName         string                 `json:"last_name,omitempty"`
+	OrganizationName string                 `json:"organization_name,omitempty"`
+	Status           string                 `json:"status,omitempty"`
+	SourceID         int64                  `json:"source_id,omitempty"`
+	Title            string                 `json:"title,omitempty"`
+	Description      string                 `json:"description,omitempty"`
+	Industry         string                 `json:"industry,omitempty"`
+	Website          string                 `json:"website,omitempty"`
+	Email            string                 `json:"email,omitempty"`
+	Phone            string                 `json:"phone,omitempty"`
+	Mobile           string                 `json:"mobile,omitempty"`
+	Fax              string                 `json:"fax,omitempty"`
+	Twitter          string                 `json:"twitter,omitempty"`
+	Facebook         string                 `json:"facebook,omitempty"`
+	Linkedin         string                 `json:"linkedin,omitempty"`
+	Skype            string                 `json:"skype,omitempty"`
+	Address          *Address               `json:"address,omitempty"`
+	Tags             []string               `json:"tags,omitempty"`
+	CustomFields     map[string]interface{} `json:"custom_fields,omitempty"`
+	CreatedAt        *time.Time             `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time             `json:"updated_at,omitempty"`
+}
+
+// LeadListOptions is options of GetLeads
+type LeadListOptions struct {
+	ListOptions
+	OwnerID          int64  `url:"owner_id,omitempty"`
+	SourceID         int64  `url:"source_id,omitempty"`
+	Status           string `url:"status,omitempty"`
+	Email            string `url:"email,omitempty"`
+	OrganizationName string `url:"organization_name,omitempty"`
+}
+
+// LeadAPI is an interface containing lead related methods
+type LeadAPI interface {
+	GetLeads(ctx context.Context, opts *LeadListOptions) ([]Lead, Meta, error)
+	GetLead(ctx context.Context, id int64) (Lead, error)
+	CreateLead(ctx context.Context, lead Lead) (Lead, error)
+	UpdateLead(ctx context.Context, id int64, lead Lead) (Lead, error)
+	DeleteLead(ctx context.Context, id int64) error
+}
+
+// GetLeads lists leads
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/leads/#retrieve-all-leads
+func (c *Client) GetLeads(ctx context.Context, opts *LeadListOptions) ([]Lead, Meta, error) {
+	tmp := opts
+	if tmp == nil {
+		tmp = &LeadListOptions{}
+	}
+	return list[Lead](ctx, c, "/v2/leads", tmp)
+}
+
+// GetLead gets a lead
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/leads/#retrieve-a-single-lead
+func (c *Client) GetLead(ctx context.Context, id int64) (Lead, error) {
+	return getOne[Lead](ctx, c, resourcePath("/v2/leads", id))
+}
+
+// CreateLead creates a lead
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/leads/#create-a-lead
+func (c *Client) CreateLead(ctx context.Context, lead Lead) (Lead, error) {
+	return sendOne[Lead](ctx, c, http.MethodPost, "/v2/leads", lead)
+}
+
+// UpdateLead updates a lead
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/leads/#update-a-lead
+func (c *Client) UpdateLead(ctx context.Context, id int64, lead Lead) (Lead, error) {
+	return sendOne[Lead](ctx, c, http.MethodPut, resourcePath("/v2/leads", id), lead)
+}
+
+// DeleteLead deletes a lead
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/leads/#delete-a-lead
+func (c *Client) DeleteLead(ctx context.Context, id int64) error {
+	return deleteOne(ctx, c, resourcePath("/v2/leads", id))
+}
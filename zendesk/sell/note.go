@@ -0,0 +1,87 @@
+package sell
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Resource types which notes and tasks are attached to
+const (
+	ResourceTypeLead    = "lead"
+	ResourceTypeContact = "contact"
+	ResourceTypeDeal    = "deal"
+)
+
+// Note is a note attached to a lead, contact or deal
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/notes/
+type Note struct {
+	ID           int64      `json:"id,omitempty"`
+	CreatorID    int64      `json:"creator_id,omitempty"`
+	ResourceType string     `json:"resource_type,omitempty"`
+	ResourceID   int64      `json:"resource_id,omitempty"`
+	Content      string     `json:"content,omitempty"`
+	IsImportant  bool       `json:"is_important,omitempty"`
+	Tags         []string   `json:"tags,omitempty"`
+	Type         string     `json:"type,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+// NoteListOptions is options of GetNotes
+type NoteListOptions struct {
+	ListOptions
+	CreatorID    int64  `url:"creator_id,omitempty"`
+	ResourceType string `url:"resource_type,omitempty"`
+	ResourceID   int64  `url:"resource_id,omitempty"`
+	Query        string `url:"q,omitempty"`
+}
+
+// NoteAPI is an interface containing note related methods
+type NoteAPI interface {
+	GetNotes(ctx context.Context, opts *NoteListOptions) ([]Note, Meta, error)
+	GetNote(ctx context.Context, id int64) (Note, error)
+	CreateNote(ctx context.Context, note Note) (Note, error)
+	UpdateNote(ctx context.Context, id int64, note Note) (Note, error)
+	DeleteNote(ctx context.Context, id int64) error
+}
+
+// GetNotes lists notes
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/notes/#retrieve-all-notes
+func (c *Client) GetNotes(ctx context.Context, opts *NoteListOptions) ([]Note, Meta, error) {
+	tmp := opts
+	if tmp == nil {
+		tmp = &NoteListOptions{}
+	}
+	return list[Note](ctx, c, "/v2/notes", tmp)
+}
+
+// GetNote gets a note
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/notes/#retrieve-a-single-note
+func (c *Client) GetNote(ctx context.Context, id int64) (Note, error) {
+	return getOne[Note](ctx, c, resourcePath("/v2/notes", id))
+}
+
+// CreateNote creates a note
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/notes/#create-a-note
+func (c *Client) CreateNote(ctx context.Context, note Note) (Note, error) {
+	return sendOne[Note](ctx, c, http.MethodPost, "/v2/notes", note)
+}
+
+// UpdateNote updates a note
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/notes/#update-a-note
+func (c *Client) UpdateNote(ctx context.Context, id int64, note Note) (Note, error) {
+	return sendOne[Note](ctx, c, http.MethodPut, resourcePath("/v2/notes", id), note)
+}
+
+// DeleteNote deletes a note
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/notes/#delete-a-note
+func (c *Client) DeleteNote(ctx context.Context, id int64) error {
+	return deleteOne(ctx, c, resourcePath("/v2/notes", id))
+}
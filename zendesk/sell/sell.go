@@ -0,0 +1,254 @@
+// Package sell provides a client of the Zendesk Sell API, which is used to link
+// the leads, contacts and deals of a sales team to Support tickets.
+//
+// Sell is served from its own host, authenticates with OAuth bearer tokens only,
+// and wraps every resource in a {"data": ..., "meta": ...} envelope. The client
+// shares the http.Client of the Support client and reports failed requests as
+// zendesk.Error.
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/introduction/
+package sell
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/google/go-querystring/query"
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+const defaultBaseURL = "https://api.getbase.com"
+
+// API is an interface containing all of the Sell methods
+type API interface {
+	LeadAPI
+	ContactAPI
+	DealAPI
+	NoteAPI
+	TaskAPI
+	SyncAPI
+	FirehoseAPI
+}
+
+var _ API = (*Client)(nil)
+
+// Client of Zendesk Sell API
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	credential *zendesk.BearerTokenCredential
+	headers    map[string]string
+}
+
+// Meta is the meta object of a Sell envelope
+type Meta struct {
+	Type  string `json:"type"`
+	Count int    `json:"count,omitempty"`
+	Links struct {
+		Self     string `json:"self,omitempty"`
+		NextPage string `json:"next_page,omitempty"`
+		PrevPage string `json:"prev_page,omitempty"`
+	} `json:"links"`
+}
+
+// HasNext checks whether a collection has the next page
+func (m Meta) HasNext() bool {
+	return m.Links.NextPage != ""
+}
+
+// ListOptions are the pagination and sort options shared by collections
+type ListOptions struct {
+	// Page is the page number starting from 1
+	Page int `url:"page,omitempty"`
+
+	// PerPage is the number of records per page. Up to 100.
+	PerPage int `url:"per_page,omitempty"`
+
+	// SortBy is an attribute to sort by, with an optional ":desc" suffix
+	SortBy string `url:"sort_by,omitempty"`
+
+	// IDs is a comma separated list of IDs to return
+	IDs string `url:"ids,omitempty"`
+}
+
+// Address is a postal address of a lead or contact
+type Address struct {
+	Line1      string `json:"line1,omitempty"`
+	City       string `json:"city,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	State      string `json:"state,omitempty"`
+	Country    string `json:"country,omitempty"`
+}
+
+// NewClient creates new Zendesk Sell API client. httpClient can be the one
+// given to zendesk.NewClient so that both share the transport.
+func NewClient(httpClient *http.Client) (*Client, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	baseURL, err := url.Parse(defaultBaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		headers: map[string]string{
+			"User-Agent":   "nukosuke/go-zendesk/0.18.0",
+			"Content-Type": "application/json",
+			"Accept":       "application/json",
+		},
+	}, nil
+}
+
+// SetHeader saves HTTP header in client. It will be included all API request
+func (c *Client) SetHeader(key string, value string) {
+	c.headers[key] = value
+}
+
+// SetEndpointURL replaces the URL of the Sell host.
+// This is mainly used for testing to point to mock API server.
+func (c *Client) SetEndpointURL(newURL string) error {
+	baseURL, err := url.Parse(newURL)
+	if err != nil {
+		return err
+	}
+
+	c.baseURL = baseURL
+	return nil
+}
+
+// SetCredential saves the OAuth access token of Sell in client
+func (c *Client) SetCredential(cred *zendesk.BearerTokenCredential) {
+	c.credential = cred
+}
+
+// do sends a request with data wrapped in the envelope and returns the response
+// body and status. Responses other than 2xx are returned as zendesk.Error.
+func (c *Client) do(ctx context.Context, method, path string, data interface{}, header http.Header) ([]byte, int, error) {
+	var body io.Reader
+	if data != nil {
+		b, err := json.Marshal(struct {
+			Data interface{} `json:"data"`
+		}{data})
+		if err != nil {
+			return nil, 0, err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL.String()+path, body)
+	if err != nil {
+		return nil, 0, err
+	}
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if c.credential != nil {
+		req.Header.Set("Authorization", "Bearer "+c.credential.Secret())
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, resp.StatusCode, zendesk.NewError(b, resp)
+	}
+	return b, resp.StatusCode, nil
+}
+
+// addOptions build query string
+func addOptions(path string, opts interface{}) (string, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return path, err
+	}
+
+	qs, err := query.Values(opts)
+	if err != nil {
+		return path, err
+	}
+
+	u.RawQuery = qs.Encode()
+	return u.String(), nil
+}
+
+// getOne gets a resource and unwraps its envelope
+func getOne[T any](ctx context.Context, c *Client, path string) (T, error) {
+	return sendOne[T](ctx, c, http.MethodGet, path, nil)
+}
+
+// sendOne sends data to a resource and unwraps the returned envelope
+func sendOne[T any](ctx context.Context, c *Client, method, path string, data interface{}) (T, error) {
+	var result struct {
+		Data T `json:"data"`
+	}
+
+	body, _, err := c.do(ctx, method, path, data, nil)
+	if err != nil {
+		return result.Data, err
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return result.Data, err
+	}
+	return result.Data, nil
+}
+
+// list gets a collection and unwraps the envelopes of its items
+func list[T any](ctx context.Context, c *Client, path string, opts interface{}) ([]T, Meta, error) {
+	var result struct {
+		Items []struct {
+			Data T `json:"data"`
+		} `json:"items"`
+		Meta Meta `json:"meta"`
+	}
+
+	u, err := addOptions(path, opts)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	body, _, err := c.do(ctx, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, Meta{}, err
+	}
+
+	items := make([]T, len(result.Items))
+	for i, item := range result.Items {
+		items[i] = item.Data
+	}
+	return items, result.Meta, nil
+}
+
+// deleteOne deletes a resource
+func deleteOne(ctx context.Context, c *Client, path string) error {
+	_, _, err := c.do(ctx, http.MethodDelete, path, nil, nil)
+	return err
+}
+
+func resourcePath(collection string, id int64) string {
+	return fmt.Sprintf("%s/%d", collection, id)
+}
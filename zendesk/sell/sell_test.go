@@ -0,0 +1,144 @@
+package sell
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nukosuke/go-zendesk/zendesk"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	client, err := NewClient(nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err)
+	}
+	if err := client.SetEndpointURL(server.URL); err != nil {
+		t.Fatalf("Failed to set endpoint: %s", err)
+	}
+	client.SetCredential(zendesk.NewBearerTokenCredential("sell-token"))
+	return client, server.Close
+}
+
+func TestGetLeads(t *testing.T) {
+	client, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v2/leads" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer sell-token" {
+			t.Errorf("Unexpected authorization %s", auth)
+		}
+		if q := r.URL.Query(); q.Get("status") != "New" || q.Get("per_page") != "2" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{
+			"items": [
+				{"data": {"id": 1, "first_name": "Mark", "last_name": "Johnson", "status": "New", "custom_fields": {"ticket_id": "42"}}, "meta": {"type": "lead"}},
+				{"data": {"id": 2, "organization_name": "Design Services Company", "status": "New"}, "meta": {"type": "lead"}}
+			],
+			"meta": {"type": "collection", "count": 2, "links": {"self": "http://example/v2/leads?page=1", "next_page": "http://example/v2/leads?page=2"}}
+		}`))
+	})
+	defer done()
+
+	leads, meta, err := client.GetLeads(context.Background(), &LeadListOptions{
+		ListOptions: ListOptions{PerPage: 2},
+		Status:      "New",
+	})
+	if err != nil {
+		t.Fatalf("Failed to get leads: %s", err)
+	}
+
+	if len(leads) != 2 || leads[0].FirstName != "Mark" || leads[1].OrganizationName != "Design Services Company" {
+		t.Fatalf("Unexpected leads %v", leads)
+	}
+	if leads[0].CustomFields["ticket_id"] != "42" {
+		t.Fatalf("Unexpected custom fields %v", leads[0].CustomFields)
+	}
+	if meta.Count != 2 || !meta.HasNext() {
+		t.Fatalf("Unexpected meta %v", meta)
+	}
+}
+
+func TestCreateDeal(t *testing.T) {
+	client, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/deals" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+
+		var data struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &data); err != nil {
+			t.Errorf("Failed to decode request: %s", err)
+		}
+		if data.Data["name"] != "Website Redesign" || data.Data["contact_id"] != float64(7) {
+			t.Errorf("Deal should be sent in the data envelope: %s", body)
+		}
+		if _, ok := data.Data["id"]; ok {
+			t.Errorf("Empty ID should not be sent: %s", body)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": {"id": 3, "name": "Website Redesign", "value": "1000.00", "currency": "USD", "contact_id": 7}, "meta": {"type": "deal"}}`))
+	})
+	defer done()
+
+	deal, err := client.CreateDeal(context.Background(), Deal{Name: "Website Redesign", ContactID: 7, Value: "1000"})
+	if err != nil {
+		t.Fatalf("Failed to create deal: %s", err)
+	}
+	if deal.ID != 3 || deal.Value != "1000.00" || deal.Currency != "USD" {
+		t.Fatalf("Unexpected deal %v", deal)
+	}
+}
+
+func TestUpdateAndDeleteNote(t *testing.T) {
+	client, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/v2/notes/5":
+			w.Write([]byte(`{"data": {"id": 5, "resource_type": "deal", "resource_id": 3, "content": "Linked to ticket #42"}, "meta": {"type": "note"}}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v2/notes/5":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+	defer done()
+
+	note, err := client.UpdateNote(context.Background(), 5, Note{Content: "Linked to ticket #42"})
+	if err != nil {
+		t.Fatalf("Failed to update note: %s", err)
+	}
+	if note.ResourceType != ResourceTypeDeal || note.ResourceID != 3 {
+		t.Fatalf("Unexpected note %v", note)
+	}
+
+	if err := client.DeleteNote(context.Background(), 5); err != nil {
+		t.Fatalf("Failed to delete note: %s", err)
+	}
+}
+
+func TestError(t *testing.T) {
+	client, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"errors": [{"error": {"code": "blank", "message": "can't be blank"}, "meta": {"type": "error"}}], "meta": {"type": "errors", "http_status": "422 Unprocessable Entity"}}`))
+	})
+	defer done()
+
+	_, err := client.CreateTask(context.Background(), Task{})
+	zerr, ok := err.(zendesk.Error)
+	if !ok {
+		t.Fatalf("Expected zendesk.Error, but got %v", err)
+	}
+	if zerr.Status() != http.StatusUnprocessableEntity {
+		t.Fatalf("Unexpected status %d", zerr.Status())
+	}
+}
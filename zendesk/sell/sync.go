@@ -0,0 +1,173 @@
+package sell
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// deviceUUIDHeader identifies the device which keeps the sync state of a client
+const deviceUUIDHeader = "X-Basecrm-Device-UUID"
+
+// SyncQueueMain is the queue of a sync session which contains all changes
+const SyncQueueMain = "main"
+
+// Event types of sync items
+const (
+	SyncEventCreated = "created"
+	SyncEventUpdated = "updated"
+	SyncEventDeleted = "deleted"
+)
+
+// SyncSession is a sync session started by StartSync
+type SyncSession struct {
+	ID     string `json:"id"`
+	Queues []struct {
+		Data SyncQueue `json:"data"`
+	} `json:"queues"`
+}
+
+// SyncQueue is a queue of a sync session
+type SyncQueue struct {
+	Name       string `json:"name"`
+	Pages      int    `json:"pages"`
+	TotalCount int    `json:"total_count"`
+}
+
+// SyncItem is a change of a resource in a sync queue. Data is the resource,
+// which can be decoded into the type given by Type such as Lead or Deal.
+type SyncItem struct {
+	Type      string
+	EventType string
+	AckKey    string
+	Revision  int64
+	Data      json.RawMessage
+}
+
+// Decode decodes the resource of the item into v
+func (i SyncItem) Decode(v interface{}) error {
+	return json.Unmarshal(i.Data, v)
+}
+
+// SyncAPI is an interface containing the sync methods
+type SyncAPI interface {
+	StartSync(ctx context.Context, deviceUUID string) (*SyncSession, error)
+	GetSyncQueue(ctx context.Context, deviceUUID, sessionID, queue string) ([]SyncItem, error)
+	AckSync(ctx context.Context, deviceUUID string, ackKeys []string) error
+}
+
+// StartSync starts a sync session of the device. It returns nil when there
+// is nothing to synchronize.
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/sync/reference/#start-synchronization-session
+func (c *Client) StartSync(ctx context.Context, deviceUUID string) (*SyncSession, error) {
+	body, status, err := c.do(ctx, http.MethodPost, "/v2/sync/start", nil, syncHeader(deviceUUID))
+	if err != nil || status == http.StatusNoContent {
+		return nil, err
+	}
+
+	var result struct {
+		Data SyncSession `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &result.Data, nil
+}
+
+// GetSyncQueue gets the next items of a queue in the sync session. It returns
+// no items when the queue is drained.
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/sync/reference/#get-data-from-queue
+func (c *Client) GetSyncQueue(ctx context.Context, deviceUUID, sessionID, queue string) ([]SyncItem, error) {
+	path := fmt.Sprintf("/v2/sync/%s/queues/%s", sessionID, queue)
+	body, status, err := c.do(ctx, http.MethodGet, path, nil, syncHeader(deviceUUID))
+	if err != nil || status == http.StatusNoContent {
+		return nil, err
+	}
+
+	var result struct {
+		Items []struct {
+			Data json.RawMessage `json:"data"`
+			Meta struct {
+				Type string `json:"type"`
+				Sync struct {
+					EventType string `json:"event_type"`
+					AckKey    string `json:"ack_key"`
+					Revision  int64  `json:"revision"`
+				} `json:"sync"`
+			} `json:"meta"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	items := make([]SyncItem, len(result.Items))
+	for i, item := range result.Items {
+		items[i] = SyncItem{
+			Type:      item.Meta.Type,
+			EventType: item.Meta.Sync.EventType,
+			AckKey:    item.Meta.Sync.AckKey,
+			Revision:  item.Meta.Sync.Revision,
+			Data:      item.Data,
+		}
+	}
+	return items, nil
+}
+
+// AckSync acknowledges the items received by the device, so that they are
+// not returned by the next sync session
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/sync/reference/#acknowledge-received-data
+func (c *Client) AckSync(ctx context.Context, deviceUUID string, ackKeys []string) error {
+	var data struct {
+		AckKeys []string `json:"ack_keys"`
+	}
+	data.AckKeys = ackKeys
+
+	_, _, err := c.do(ctx, http.MethodPost, "/v2/sync/ack", data, syncHeader(deviceUUID))
+	return err
+}
+
+func syncHeader(deviceUUID string) http.Header {
+	return http.Header{deviceUUIDHeader: []string{deviceUUID}}
+}
+
+// Sync runs a sync session of the device and calls fn with every changed
+// item of the main queue. Each batch is acknowledged after fn returns nil for
+// all of its items, so items which failed are received again by the next sync.
+func Sync(ctx context.Context, api SyncAPI, deviceUUID string, fn func(SyncItem) error) error {
+	session, err := api.StartSync(ctx, deviceUUID)
+	if err != nil || session == nil {
+		return err
+	}
+
+	for {
+		items, err := api.GetSyncQueue(ctx, deviceUUID, session.ID, SyncQueueMain)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+
+		ackKeys := make([]string, 0, len(items))
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				if len(ackKeys) > 0 {
+					if ackErr := api.AckSync(ctx, deviceUUID, ackKeys); ackErr != nil {
+						return ackErr
+					}
+				}
+				return err
+			}
+			ackKeys = append(ackKeys, item.AckKey)
+		}
+
+		if err := api.AckSync(ctx, deviceUUID, ackKeys); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package sell
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSync(t *testing.T) {
+	fetched := 0
+	var acked [][]string
+	client, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if uuid := r.Header.Get(deviceUUIDHeader); uuid != "device-1" {
+			t.Errorf("Unexpected device UUID %s", uuid)
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/sync/start":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data": {"id": "session-1", "queues": [{"data": {"name": "main", "pages": 1, "total_count": 3}, "meta": {"type": "sync_queue"}}]}, "meta": {"type": "sync_session"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/sync/session-1/queues/main":
+			fetched++
+			if fetched > 1 {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.Write([]byte(`{"items": [
+				{"data": {"id": 1, "first_name": "Mark"}, "meta": {"type": "lead", "sync": {"event_type": "created", "ack_key": "Lead-1-1", "revision": 1}}},
+				{"data": {"id": 3, "name": "Website Redesign"}, "meta": {"type": "deal", "sync": {"event_type": "updated", "ack_key": "Deal-3-2", "revision": 2}}}
+			], "meta": {"type": "collection", "count": 2}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/sync/ack":
+			var data struct {
+				Data struct {
+					AckKeys []string `json:"ack_keys"`
+				} `json:"data"`
+			}
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &data); err != nil {
+				t.Errorf("Failed to decode ack: %s", err)
+			}
+			acked = append(acked, data.Data.AckKeys)
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+	defer done()
+
+	var leads []Lead
+	var deals []Deal
+	err := Sync(context.Background(), client, "device-1", func(item SyncItem) error {
+		switch item.Type {
+		case "lead":
+			var lead Lead
+			if err := item.Decode(&lead); err != nil {
+				return err
+			}
+			leads = append(leads, lead)
+		case "deal":
+			var deal Deal
+			if err := item.Decode(&deal); err != nil {
+				return err
+			}
+			deals = append(deals, deal)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to sync: %s", err)
+	}
+
+	if len(leads) != 1 || leads[0].FirstName != "Mark" || len(deals) != 1 || deals[0].Name != "Website Redesign" {
+		t.Fatalf("Unexpected synced resources %v %v", leads, deals)
+	}
+	if !reflect.DeepEqual(acked, [][]string{{"Lead-1-1", "Deal-3-2"}}) {
+		t.Fatalf("Unexpected acknowledged keys %v", acked)
+	}
+
+	fetched = 0
+	acked = nil
+	err = Sync(context.Background(), client, "device-1", func(item SyncItem) error {
+		if item.Type == "deal" {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	if err == nil {
+		t.Fatal("Sync should return the error of fn")
+	}
+	if !reflect.DeepEqual(acked, [][]string{{"Lead-1-1"}}) {
+		t.Fatalf("Only handled items should be acknowledged, but got %v", acked)
+	}
+}
+
+func TestReadStream(t *testing.T) {
+	client, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v3/deals/stream" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		switch r.URL.Query().Get("position") {
+		case StreamPositionTail:
+			w.Write([]byte(`{"items": [
+				{"data": {"id": 3, "name": "Website Redesign"}, "meta": {"type": "deal", "event_type": "created", "event_id": "e1", "event_time": "2024-05-01T10:00:00Z"}}
+			], "meta": {"position": "p1", "top": false}}`))
+		case "p1":
+			w.Write([]byte(`{"items": [
+				{"data": {"id": 3, "name": "Website Redesign", "hot": true}, "meta": {"type": "deal", "event_type": "updated", "event_id": "e2", "event_time": "2024-05-01T11:00:00Z", "previous": {"hot": false}}}
+			], "meta": {"position": "p2", "top": true}}`))
+		default:
+			t.Errorf("Unexpected position %s", r.URL.Query().Get("position"))
+		}
+	})
+	defer done()
+
+	var events []StreamEvent
+	position, err := ReadStream(context.Background(), client, StreamDeals, StreamPositionTail, func(e StreamEvent) error {
+		events = append(events, e)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to read stream: %s", err)
+	}
+
+	if position != "p2" || len(events) != 2 {
+		t.Fatalf("Unexpected position %s and events %v", position, events)
+	}
+	var deal Deal
+	if err := events[1].Decode(&deal); err != nil || !deal.Hot || events[1].EventType != "updated" {
+		t.Fatalf("Unexpected event %v", events[1])
+	}
+	if string(events[1].Previous) != `{"hot": false}` {
+		t.Fatalf("Unexpected previous values %s", events[1].Previous)
+	}
+}
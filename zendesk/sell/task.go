@@ -0,0 +1,85 @@
+package sell
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Task is a to-do of a user, optionally attached to a lead, contact or deal
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/tasks/
+type Task struct {
+	ID           int64      `json:"id,omitempty"`
+	CreatorID    int64      `json:"creator_id,omitempty"`
+	OwnerID      int64      `json:"owner_id,omitempty"`
+	ResourceType string     `json:"resource_type,omitempty"`
+	ResourceID   int64      `json:"resource_id,omitempty"`
+	Completed    bool       `json:"completed,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	DueDate      *time.Time `json:"due_date,omitempty"`
+	Overdue      bool       `json:"overdue,omitempty"`
+	RemindAt     *time.Time `json:"remind_at,omitempty"`
+	Content      string     `json:"content,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+// TaskListOptions is options of GetTasks
+type TaskListOptions struct {
+	ListOptions
+	OwnerID      int64  `url:"owner_id,omitempty"`
+	ResourceType string `url:"resource_type,omitempty"`
+	ResourceID   int64  `url:"resource_id,omitempty"`
+	Completed    *bool  `url:"completed,omitempty"`
+	Overdue      *bool  `url:"overdue,omitempty"`
+	Query        string `url:"q,omitempty"`
+}
+
+// TaskAPI is an interface containing task related methods
+type TaskAPI interface {
+	GetTasks(ctx context.Context, opts *TaskListOptions) ([]Task, Meta, error)
+	GetTask(ctx context.Context, id int64) (Task, error)
+	CreateTask(ctx context.Context, task Task) (Task, error)
+	UpdateTask(ctx context.Context, id int64, task Task) (Task, error)
+	DeleteTask(ctx context.Context, id int64) error
+}
+
+// GetTasks lists tasks
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/tasks/#retrieve-all-tasks
+func (c *Client) GetTasks(ctx context.Context, opts *TaskListOptions) ([]Task, Meta, error) {
+	tmp := opts
+	if tmp == nil {
+		tmp = &TaskListOptions{}
+	}
+	return list[Task](ctx, c, "/v2/tasks", tmp)
+}
+
+// GetTask gets a task
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/tasks/#retrieve-a-single-task
+func (c *Client) GetTask(ctx context.Context, id int64) (Task, error) {
+	return getOne[Task](ctx, c, resourcePath("/v2/tasks", id))
+}
+
+// CreateTask creates a task
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/tasks/#create-a-task
+func (c *Client) CreateTask(ctx context.Context, task Task) (Task, error) {
+	return sendOne[Task](ctx, c, http.MethodPost, "/v2/tasks", task)
+}
+
+// UpdateTask updates a task
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/tasks/#update-a-task
+func (c *Client) UpdateTask(ctx context.Context, id int64, task Task) (Task, error) {
+	return sendOne[Task](ctx, c, http.MethodPut, resourcePath("/v2/tasks", id), task)
+}
+
+// DeleteTask deletes a task
+//
+// ref: https://developer.zendesk.com/api-reference/sales-crm/resources/tasks/#delete-a-task
+func (c *Client) DeleteTask(ctx context.Context, id int64) error {
+	return deleteOne(ctx, c, resourcePath("/v2/tasks", id))
+}
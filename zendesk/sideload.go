@@ -0,0 +1,41 @@
+package zendesk
+
+// Sideloads which can be passed to the include option of listings
+//
+// ref: https://developer.zendesk.com/documentation/ticketing/using-the-zendesk-api/side_loading/
+const (
+	SideloadUsers         = "users"
+	SideloadGroups        = "groups"
+	SideloadOrganizations = "organizations"
+)
+
+// sideloads are the related records returned next to a listing
+type sideloads struct {
+	Users         []User         `json:"users"`
+	Groups        []Group        `json:"groups"`
+	Organizations []Organization `json:"organizations"`
+}
+
+func (s *sideloads) usersByID() map[int64]*User {
+	m := make(map[int64]*User, len(s.Users))
+	for i := range s.Users {
+		m[s.Users[i].ID] = &s.Users[i]
+	}
+	return m
+}
+
+func (s *sideloads) groupsByID() map[int64]*Group {
+	m := make(map[int64]*Group, len(s.Groups))
+	for i := range s.Groups {
+		m[s.Groups[i].ID] = &s.Groups[i]
+	}
+	return m
+}
+
+func (s *sideloads) organizationsByID() map[int64]*Organization {
+	m := make(map[int64]*Organization, len(s.Organizations))
+	for i := range s.Organizations {
+		m[s.Organizations[i].ID] = &s.Organizations[i]
+	}
+	return m
+}
@@ -0,0 +1,282 @@
+package zendesk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Via channels of social channel tickets
+const (
+	ViaChannelTwitter  = "twitter"
+	ViaChannelFacebook = "facebook"
+)
+
+// MonitoredTwitterHandle is struct for monitored X (Twitter) handle payload
+// https://developer.zendesk.com/api-reference/ticketing/account-configuration/twitter_channel/
+type MonitoredTwitterHandle struct {
+	ID            int64     `json:"id"`
+	ScreenName    string    `json:"screen_name"`
+	TwitterUserID int64     `json:"twitter_user_id"`
+	BrandID       int64     `json:"brand_id,omitempty"`
+	AllowReply    bool      `json:"allow_reply"`
+	CanReply      bool      `json:"can_reply"`
+	Avatar        string    `json:"avatar,omitempty"`
+	Name          string    `json:"name,omitempty"`
+	CreatedAt     time.Time `json:"created_at,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at,omitempty"`
+}
+
+// TwitterStatus is the status of the tweet which a ticket comment was created from
+// https://developer.zendesk.com/api-reference/ticketing/account-configuration/twitter_channel/#list-ticket-statuses
+type TwitterStatus struct {
+	ID           int64 `json:"id"`
+	Favorited    bool  `json:"favorited"`
+	Retweeted    bool  `json:"retweeted"`
+	UserFollowed bool  `json:"user_followed"`
+}
+
+// FacebookPage is struct for Facebook page payload
+// https://developer.zendesk.com/api-reference/ticketing/account-configuration/facebook_pages/
+type FacebookPage struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url,omitempty"`
+	Name      string    `json:"name"`
+	PageID    string    `json:"page_id,omitempty"`
+	Category  string    `json:"category,omitempty"`
+	BrandID   int64     `json:"brand_id,omitempty"`
+	State     string    `json:"state,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// ViaTwitterParty is the sender or recipient in the source of a twitter Via
+type ViaTwitterParty struct {
+	Name       string      `json:"name,omitempty"`
+	Username   string      `json:"username,omitempty"`
+	ProfileURL string      `json:"profile_url,omitempty"`
+	TwitterID  json.Number `json:"twitter_id,omitempty"`
+}
+
+// ViaTwitterSource is the typed source of a Via whose channel is twitter.
+// Rel is "mention", "direct_message" or "favorite".
+type ViaTwitterSource struct {
+	From ViaTwitterParty
+	To   ViaTwitterParty
+	Rel  string
+}
+
+// ViaFacebookParty is the sender or recipient in the source of a facebook Via
+type ViaFacebookParty struct {
+	Name       string      `json:"name,omitempty"`
+	ProfileURL string      `json:"profile_url,omitempty"`
+	FacebookID json.Number `json:"facebook_id,omitempty"`
+}
+
+// ViaFacebookSource is the typed source of a Via whose channel is facebook.
+// Rel is "post" or "message".
+type ViaFacebookSource struct {
+	From ViaFacebookParty
+	To   ViaFacebookParty
+	Rel  string
+}
+
+// TwitterSource returns the typed source of a twitter Via.
+// It returns false if the channel of Via is not twitter.
+func (v *Via) TwitterSource() (ViaTwitterSource, bool) {
+	var source ViaTwitterSource
+	if v == nil || v.Channel != ViaChannelTwitter {
+		return source, false
+	}
+
+	if decodeViaParty(v.Source.From, &source.From) != nil || decodeViaParty(v.Source.To, &source.To) != nil {
+		return source, false
+	}
+	source.Rel = v.Source.Rel
+	return source, true
+}
+
+// FacebookSource returns the typed source of a facebook Via.
+// It returns false if the channel of Via is not facebook.
+func (v *Via) FacebookSource() (ViaFacebookSource, bool) {
+	var source ViaFacebookSource
+	if v == nil || v.Channel != ViaChannelFacebook {
+		return source, false
+	}
+
+	if decodeViaParty(v.Source.From, &source.From) != nil || decodeViaParty(v.Source.To, &source.To) != nil {
+		return source, false
+	}
+	source.Rel = v.Source.Rel
+	return source, true
+}
+
+// decodeViaParty converts the untyped from or to of Via source into party
+func decodeViaParty(m map[string]interface{}, party interface{}) error {
+	if m == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, party)
+}
+
+// SocialChannelAPI an interface containing all X (Twitter) and Facebook channel related methods
+type SocialChannelAPI interface {
+	GetMonitoredTwitterHandles(ctx context.Context) ([]MonitoredTwitterHandle, error)
+	GetMonitoredTwitterHandle(ctx context.Context, id int64) (MonitoredTwitterHandle, error)
+	CreateTicketFromTweet(ctx context.Context, tweetID int64, monitoredTwitterHandleID int64) (Ticket, error)
+	GetTwitterStatuses(ctx context.Context, ticketID int64, commentIDs []int64) ([]TwitterStatus, error)
+	GetFacebookPages(ctx context.Context) ([]FacebookPage, error)
+	GetFacebookPage(ctx context.Context, id int64) (FacebookPage, error)
+}
+
+// GetMonitoredTwitterHandles lists the X (Twitter) handles monitored by the account
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/account-configuration/twitter_channel/#list-monitored-x-handles
+func (z *Client) GetMonitoredTwitterHandles(ctx context.Context) ([]MonitoredTwitterHandle, error) {
+	var data struct {
+		MonitoredTwitterHandles []MonitoredTwitterHandle `json:"monitored_twitter_handles"`
+	}
+
+	body, err := z.get(ctx, "/channels/twitter/monitored_twitter_handles.json")
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, err
+	}
+	return data.MonitoredTwitterHandles, nil
+}
+
+// GetMonitoredTwitterHandle gets a specified monitored X (Twitter) handle
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/account-configuration/twitter_channel/#show-monitored-x-handle
+func (z *Client) GetMonitoredTwitterHandle(ctx context.Context, id int64) (MonitoredTwitterHandle, error) {
+	var result struct {
+		MonitoredTwitterHandle MonitoredTwitterHandle `json:"monitored_twitter_handle"`
+	}
+
+	body, err := z.get(ctx, fmt.Sprintf("/channels/twitter/monitored_twitter_handles/%d.json", id))
+	if err != nil {
+		return MonitoredTwitterHandle{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return MonitoredTwitterHandle{}, err
+	}
+	return result.MonitoredTwitterHandle, nil
+}
+
+// CreateTicketFromTweet creates a ticket from the tweet which mentions the monitored handle
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/account-configuration/twitter_channel/#create-ticket-from-tweet
+func (z *Client) CreateTicketFromTweet(ctx context.Context, tweetID int64, monitoredTwitterHandleID int64) (Ticket, error) {
+	var data struct {
+		Ticket struct {
+			TwitterStatusMessageID   int64 `json:"twitter_status_message_id"`
+			MonitoredTwitterHandleID int64 `json:"monitored_twitter_handle_id"`
+		} `json:"ticket"`
+	}
+	data.Ticket.TwitterStatusMessageID = tweetID
+	data.Ticket.MonitoredTwitterHandleID = monitoredTwitterHandleID
+
+	var result struct {
+		Ticket Ticket `json:"ticket"`
+	}
+
+	body, err := z.post(ctx, "/channels/twitter/tickets.json", data)
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return Ticket{}, err
+	}
+	return result.Ticket, nil
+}
+
+// GetTwitterStatuses gets the statuses of the tweets which the ticket comments were created from.
+// All comments of the ticket are included if commentIDs is empty.
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/account-configuration/twitter_channel/#list-ticket-statuses
+func (z *Client) GetTwitterStatuses(ctx context.Context, ticketID int64, commentIDs []int64) ([]TwitterStatus, error) {
+	var data struct {
+		Statuses []TwitterStatus `json:"statuses"`
+	}
+
+	var opts struct {
+		CommentIDs string `url:"comment_ids,omitempty"`
+	}
+	ids := make([]string, len(commentIDs))
+	for i, id := range commentIDs {
+		ids[i] = strconv.FormatInt(id, 10)
+	}
+	opts.CommentIDs = strings.Join(ids, ",")
+
+	u, err := addOptions(fmt.Sprintf("/channels/twitter/tickets/%d/statuses.json", ticketID), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := z.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, err
+	}
+	return data.Statuses, nil
+}
+
+// GetFacebookPages lists the Facebook pages connected to the account
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/account-configuration/facebook_pages/#list-facebook-pages
+func (z *Client) GetFacebookPages(ctx context.Context) ([]FacebookPage, error) {
+	var data struct {
+		FacebookPages []FacebookPage `json:"facebook_pages"`
+	}
+
+	body, err := z.get(ctx, "/channels/facebook/pages.json")
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, err
+	}
+	return data.FacebookPages, nil
+}
+
+// GetFacebookPage gets a specified Facebook page
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/account-configuration/facebook_pages/#show-facebook-page
+func (z *Client) GetFacebookPage(ctx context.Context, id int64) (FacebookPage, error) {
+	var result struct {
+		FacebookPage FacebookPage `json:"facebook_page"`
+	}
+
+	body, err := z.get(ctx, fmt.Sprintf("/channels/facebook/pages/%d.json", id))
+	if err != nil {
+		return FacebookPage{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return FacebookPage{}, err
+	}
+	return result.FacebookPage, nil
+}
@@ -0,0 +1,170 @@
+package zendesk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetMonitoredTwitterHandles(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "monitored_twitter_handles.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	handles, err := client.GetMonitoredTwitterHandles(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get monitored twitter handles: %s", err)
+	}
+
+	if len(handles) != 2 {
+		t.Fatalf("expected length of monitored twitter handles is 2, but got %d", len(handles))
+	}
+}
+
+func TestGetMonitoredTwitterHandle(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "monitored_twitter_handle.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	handle, err := client.GetMonitoredTwitterHandle(ctx, 211)
+	if err != nil {
+		t.Fatalf("Failed to get monitored twitter handle: %s", err)
+	}
+
+	if handle.ID != 211 || handle.ScreenName != "@zendesk" {
+		t.Fatalf("Returned handle is not the expected one: %v", handle)
+	}
+}
+
+func TestCreateTicketFromTweet(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data map[string]map[string]int64
+		json.NewDecoder(r.Body).Decode(&data)
+		if r.URL.Path != "/channels/twitter/tickets.json" ||
+			data["ticket"]["twitter_status_message_id"] != 8605426295771136 ||
+			data["ticket"]["monitored_twitter_handle_id"] != 45 {
+			t.Errorf("Unexpected request to %s: %v", r.URL.Path, data)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write(readFixture(filepath.Join(http.MethodPost, "ticket.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	ticket, err := client.CreateTicketFromTweet(ctx, 8605426295771136, 45)
+	if err != nil {
+		t.Fatalf("Failed to create ticket from tweet: %s", err)
+	}
+
+	if ticket.ID == 0 {
+		t.Fatal("Created ticket should have an ID")
+	}
+}
+
+func TestGetTwitterStatuses(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ids := r.URL.Query().Get("comment_ids"); ids != "1,2" {
+			t.Errorf("Unexpected comment_ids %s", ids)
+		}
+		w.Write(readFixture(filepath.Join(http.MethodGet, "twitter_statuses.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	statuses, err := client.GetTwitterStatuses(ctx, 2, []int64{1, 2})
+	if err != nil {
+		t.Fatalf("Failed to get twitter statuses: %s", err)
+	}
+
+	if len(statuses) != 1 || !statuses[0].Retweeted {
+		t.Fatalf("Returned statuses are not the expected ones: %v", statuses)
+	}
+}
+
+func TestGetFacebookPages(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "facebook_pages.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	pages, err := client.GetFacebookPages(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get facebook pages: %s", err)
+	}
+
+	if len(pages) != 1 {
+		t.Fatalf("expected length of facebook pages is 1, but got %d", len(pages))
+	}
+}
+
+func TestGetFacebookPage(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "facebook_page.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	page, err := client.GetFacebookPage(ctx, 41)
+	if err != nil {
+		t.Fatalf("Failed to get facebook page: %s", err)
+	}
+
+	if page.PageID != "153212738023416" {
+		t.Fatalf("Returned page is not the expected one: %v", page)
+	}
+}
+
+func TestViaTwitterSource(t *testing.T) {
+	var via Via
+	err := json.Unmarshal([]byte(`{
+		"channel": "twitter",
+		"source": {
+			"from": {"name": "John", "username": "john", "profile_url": "https://twitter.com/john", "twitter_id": 123456},
+			"to": {"name": "Zendesk", "username": "zendesk", "profile_url": "https://twitter.com/zendesk", "twitter_id": "67656578"},
+			"rel": "mention"
+		}
+	}`), &via)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, ok := via.TwitterSource()
+	if !ok {
+		t.Fatal("Via should have twitter source")
+	}
+	if source.From.Username != "john" || source.From.TwitterID != "123456" || source.To.TwitterID != "67656578" || source.Rel != "mention" {
+		t.Fatalf("Unexpected twitter source %+v", source)
+	}
+
+	if _, ok := via.FacebookSource(); ok {
+		t.Fatal("Twitter via should not have facebook source")
+	}
+}
+
+func TestViaFacebookSource(t *testing.T) {
+	var via Via
+	err := json.Unmarshal([]byte(`{
+		"channel": "facebook",
+		"source": {
+			"from": {"name": "John", "profile_url": "https://facebook.com/john", "facebook_id": "10001"},
+			"to": {"name": "Example Support", "profile_url": "https://facebook.com/example", "facebook_id": "153212738023416"},
+			"rel": "post"
+		}
+	}`), &via)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, ok := via.FacebookSource()
+	if !ok {
+		t.Fatal("Via should have facebook source")
+	}
+	if source.From.FacebookID != "10001" || source.To.Name != "Example Support" || source.Rel != "post" {
+		t.Fatalf("Unexpected facebook source %+v", source)
+	}
+
+	var nilVia *Via
+	if _, ok := nilVia.TwitterSource(); ok {
+		t.Fatal("nil via should not have twitter source")
+	}
+}
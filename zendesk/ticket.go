@@ -142,6 +142,9 @@ type TicketListOptions struct {
 
 	// SortOrder can take "asc" or "desc"
 	SortOrder string `url:"sort_order,omitempty"`
+
+	// ExternalID lists the tickets which have the external ID
+	ExternalID string `url:"external_id,omitempty"`
 }
 
 // TicketListCBPResult struct represents the result of a ticket list operation in CBP. It includes an array of Ticket objects, and Meta that holds pagination metadata.
@@ -150,6 +153,15 @@ type TicketListCBPResult struct {
 	Meta    CursorPaginationMeta `json:"meta"`
 }
 
+// IncrementalExportMeta is the cursor of a page of a cursor based incremental export
+type IncrementalExportMeta struct {
+	Cursor
+
+	// EndOfStream is true on the last page. The after cursor of the last
+	// page continues the export with the resources changed later.
+	EndOfStream bool `json:"end_of_stream"`
+}
+
 // TicketAPI an interface containing all ticket related methods
 type TicketAPI interface {
 	GetTicketsIterator(ctx context.Context, opts *PaginationOptions) *Iterator[Ticket]
@@ -160,11 +172,30 @@ type TicketAPI interface {
 	GetOrganizationTicketsOBP(ctx context.Context, opts *OBPOptions) ([]Ticket, Page, error)
 	GetOrganizationTicketsCBP(ctx context.Context, opts *CBPOptions) ([]Ticket, CursorPaginationMeta, error)
 	GetOrganizationTicketsIterator(ctx context.Context, opts *PaginationOptions) *Iterator[Ticket]
+	GetUserRequestedTicketsOBP(ctx context.Context, opts *OBPOptions) ([]Ticket, Page, error)
+	GetUserRequestedTicketsCBP(ctx context.Context, opts *CBPOptions) ([]Ticket, CursorPaginationMeta, error)
+	GetUserRequestedTicketsIterator(ctx context.Context, opts *PaginationOptions) *Iterator[Ticket]
+	GetUserCCDTicketsOBP(ctx context.Context, opts *OBPOptions) ([]Ticket, Page, error)
+	GetUserCCDTicketsCBP(ctx context.Context, opts *CBPOptions) ([]Ticket, CursorPaginationMeta, error)
+	GetUserCCDTicketsIterator(ctx context.Context, opts *PaginationOptions) *Iterator[Ticket]
+	GetUserAssignedTicketsOBP(ctx context.Context, opts *OBPOptions) ([]Ticket, Page, error)
+	GetUserAssignedTicketsCBP(ctx context.Context, opts *CBPOptions) ([]Ticket, CursorPaginationMeta, error)
+	GetUserAssignedTicketsIterator(ctx context.Context, opts *PaginationOptions) *Iterator[Ticket]
+	GetUserFollowedTicketsOBP(ctx context.Context, opts *OBPOptions) ([]Ticket, Page, error)
+	GetUserFollowedTicketsCBP(ctx context.Context, opts *CBPOptions) ([]Ticket, CursorPaginationMeta, error)
+	GetUserFollowedTicketsIterator(ctx context.Context, opts *PaginationOptions) *Iterator[Ticket]
+	GetRecentTicketsOBP(ctx context.Context, opts *OBPOptions) ([]Ticket, Page, error)
+	GetRecentTicketsCBP(ctx context.Context, opts *CBPOptions) ([]Ticket, CursorPaginationMeta, error)
+	GetRecentTicketsIterator(ctx context.Context, opts *PaginationOptions) *Iterator[Ticket]
+	GetIncrementalTickets(ctx context.Context, opts *CursorOption) ([]Ticket, IncrementalExportMeta, error)
 	GetTicket(ctx context.Context, id int64) (Ticket, error)
 	GetMultipleTickets(ctx context.Context, ticketIDs []int64) ([]Ticket, error)
 	CreateTicket(ctx context.Context, ticket Ticket) (Ticket, error)
 	UpdateTicket(ctx context.Context, ticketID int64, ticket Ticket) (Ticket, error)
 	DeleteTicket(ctx context.Context, ticketID int64) error
+	CreateManyTickets(ctx context.Context, tickets []Ticket) (JobStatus, error)
+	DeleteManyTickets(ctx context.Context, ticketIDs []int64) (JobStatus, error)
+	MergeTickets(ctx context.Context, targetID int64, opts TicketMergeOptions) (JobStatus, error)
 }
 
 // GetTickets get ticket list with offset based pagination
@@ -198,6 +229,38 @@ func (z *Client) GetTickets(ctx context.Context, opts *TicketListOptions) ([]Tic
 	return data.Tickets, data.Page, nil
 }
 
+// GetIncrementalTickets exports the tickets changed since opts.StartTime,
+// or since the cursor of the previous page. Unlike the ticket list, the
+// export includes archived tickets and deleted ones with the "deleted" status.
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/ticket-management/incremental_exports/#incremental-ticket-export-cursor-based
+func (z *Client) GetIncrementalTickets(ctx context.Context, opts *CursorOption) ([]Ticket, IncrementalExportMeta, error) {
+	var data struct {
+		Tickets []Ticket `json:"tickets"`
+		IncrementalExportMeta
+	}
+
+	if opts == nil {
+		return nil, IncrementalExportMeta{}, &OptionsError{opts}
+	}
+
+	u, err := addOptions("/incremental/tickets/cursor.json", opts)
+	if err != nil {
+		return nil, IncrementalExportMeta{}, err
+	}
+
+	body, err := z.get(ctx, u)
+	if err != nil {
+		return nil, IncrementalExportMeta{}, err
+	}
+
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, IncrementalExportMeta{}, err
+	}
+	return data.Tickets, data.IncrementalExportMeta, nil
+}
+
 // GetOrganizationTickets get organization ticket list
 //
 // ref: https://developer.zendesk.com/rest_api/docs/support/tickets#list-tickets
@@ -336,3 +399,20 @@ func (z *Client) DeleteTicket(ctx context.Context, ticketID int64) error {
 
 	return nil
 }
+
+// CreateManyTickets creates up to 100 tickets in a background job
+// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#create-many-tickets
+func (z *Client) CreateManyTickets(ctx context.Context, tickets []Ticket) (JobStatus, error) {
+	var data struct {
+		Tickets []Ticket `json:"tickets"`
+	}
+	data.Tickets = tickets
+
+	return z.postJob(ctx, "/tickets/create_many.json", data)
+}
+
+// DeleteManyTickets deletes up to 100 tickets in a background job
+// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#bulk-delete-tickets
+func (z *Client) DeleteManyTickets(ctx context.Context, ticketIDs []int64) (JobStatus, error) {
+	return z.deleteJob(ctx, "/tickets/destroy_many.json", ticketIDs)
+}
@@ -0,0 +1,230 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// defaults of DuplicateTicketOptions
+const (
+	defaultDuplicateMinScore = 0.5
+	defaultDuplicateWindow   = 7 * 24 * time.Hour
+)
+
+// weights of the scores of DuplicateTicketSuggestion
+const (
+	duplicateSimilarityWeight = 0.8
+	duplicateProximityWeight  = 0.2
+)
+
+// openTicketStatuses are the statuses of the tickets which can be merged
+var openTicketStatuses = map[string]bool{
+	"new":     true,
+	"open":    true,
+	"pending": true,
+	"hold":    true,
+}
+
+// DuplicateTicketOptions is options of the duplicate ticket detection
+type DuplicateTicketOptions struct {
+	// MinScore is the minimum score of a suggestion. Defaults to 0.5.
+	MinScore float64
+
+	// Window is the maximum time between the creation of two duplicate tickets. Defaults to 7 days.
+	Window time.Duration
+}
+
+func (o *DuplicateTicketOptions) withDefaults() DuplicateTicketOptions {
+	var opts DuplicateTicketOptions
+	if o != nil {
+		opts = *o
+	}
+	if opts.MinScore <= 0 {
+		opts.MinScore = defaultDuplicateMinScore
+	}
+	if opts.Window <= 0 {
+		opts.Window = defaultDuplicateWindow
+	}
+	return opts
+}
+
+// DuplicateTicketSuggestion is a proposal to merge Source into Target.
+// Target is the older ticket of the two.
+// Score is the weighted sum of Similarity, the TF-IDF cosine similarity of the subjects
+// and descriptions, and Proximity, which decreases linearly from 1 to 0 over the window.
+type DuplicateTicketSuggestion struct {
+	Target           Ticket  `json:"target"`
+	Source           Ticket  `json:"source"`
+	Score            float64 `json:"score"`
+	Similarity       float64 `json:"similarity"`
+	Proximity        float64 `json:"proximity"`
+	SameRequester    bool    `json:"same_requester"`
+	SameOrganization bool    `json:"same_organization"`
+}
+
+// FindDuplicateTickets compares the open tickets which have the same requester or organization
+// and returns the suggested merges ordered by score. Each ticket is suggested as a source
+// at most once, with its best target.
+func FindDuplicateTickets(tickets []Ticket, opts *DuplicateTicketOptions) []DuplicateTicketSuggestion {
+	best := map[int64]DuplicateTicketSuggestion{}
+	for _, s := range scoreDuplicateTickets(tickets, opts.withDefaults()) {
+		if b, ok := best[s.Source.ID]; !ok || s.Score > b.Score {
+			best[s.Source.ID] = s
+		}
+	}
+
+	suggestions := make([]DuplicateTicketSuggestion, 0, len(best))
+	for _, s := range best {
+		suggestions = append(suggestions, s)
+	}
+	sortDuplicateTickets(suggestions)
+	return suggestions
+}
+
+// scoreDuplicateTickets returns all pairs of the open tickets which score at least opts.MinScore
+func scoreDuplicateTickets(tickets []Ticket, opts DuplicateTicketOptions) []DuplicateTicketSuggestion {
+	var open []Ticket
+	seen := map[int64]bool{}
+	for _, t := range tickets {
+		if !openTicketStatuses[t.Status] || seen[t.ID] {
+			continue
+		}
+		seen[t.ID] = true
+		open = append(open, t)
+	}
+
+	// TF-IDF over the compared tickets, so words common to all of them weigh less
+	counts := make([]map[string]float64, len(open))
+	df := map[string]int{}
+	for i, t := range open {
+		counts[i] = termCounts(t.Subject + " " + t.Description)
+		for term := range counts[i] {
+			df[term]++
+		}
+	}
+	vectors := make([]map[string]float64, len(open))
+	for i, c := range counts {
+		vectors[i] = make(map[string]float64, len(c))
+		for term, n := range c {
+			idf := math.Log(float64(len(open)+1)/float64(df[term]+1)) + 1
+			vectors[i][term] = (1 + math.Log(n)) * idf
+		}
+	}
+
+	var suggestions []DuplicateTicketSuggestion
+	for i := range open {
+		for j := i + 1; j < len(open); j++ {
+			a, b := open[i], open[j]
+			s := DuplicateTicketSuggestion{
+				SameRequester:    a.RequesterID != 0 && a.RequesterID == b.RequesterID,
+				SameOrganization: a.OrganizationID != 0 && a.OrganizationID == b.OrganizationID,
+			}
+			if !s.SameRequester && !s.SameOrganization {
+				continue
+			}
+
+			if a.CreatedAt != nil && b.CreatedAt != nil {
+				d := a.CreatedAt.Sub(*b.CreatedAt)
+				if d < 0 {
+					d = -d
+				}
+				if d > opts.Window {
+					continue
+				}
+				s.Proximity = 1 - float64(d)/float64(opts.Window)
+			}
+
+			s.Similarity = cosine(vectors[i], vectors[j])
+			if s.Similarity == 0 {
+				continue
+			}
+			s.Score = duplicateSimilarityWeight*s.Similarity + duplicateProximityWeight*s.Proximity
+			if s.Score < opts.MinScore {
+				continue
+			}
+
+			if createdBefore(b, a) {
+				a, b = b, a
+			}
+			s.Target, s.Source = a, b
+			suggestions = append(suggestions, s)
+		}
+	}
+	return suggestions
+}
+
+// createdBefore reports whether a was created before b. IDs are compared when the times are unknown.
+func createdBefore(a, b Ticket) bool {
+	if a.CreatedAt != nil && b.CreatedAt != nil && !a.CreatedAt.Equal(*b.CreatedAt) {
+		return a.CreatedAt.Before(*b.CreatedAt)
+	}
+	return a.ID < b.ID
+}
+
+func sortDuplicateTickets(suggestions []DuplicateTicketSuggestion) {
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Source.ID < suggestions[j].Source.ID
+	})
+}
+
+// FindTicketDuplicates fetches the tickets of the requester and the organization of the ticket,
+// and returns the suggested merges which involve the ticket ordered by score
+func FindTicketDuplicates(ctx context.Context, api API, ticketID int64, opts *DuplicateTicketOptions) ([]DuplicateTicketSuggestion, error) {
+	ticket, err := api.GetTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	tickets := []Ticket{ticket}
+	listings := []struct {
+		id       int64
+		iterator func(ctx context.Context, opts *PaginationOptions) *Iterator[Ticket]
+	}{
+		{ticket.RequesterID, api.GetUserRequestedTicketsIterator},
+		{ticket.OrganizationID, api.GetOrganizationTicketsIterator},
+	}
+	for _, l := range listings {
+		if l.id == 0 {
+			continue
+		}
+		pageOpts := NewPaginationOptions()
+		pageOpts.Id = l.id
+		it := l.iterator(ctx, pageOpts)
+		for it.HasMore() {
+			page, err := it.GetNext()
+			if err != nil {
+				return nil, err
+			}
+			tickets = append(tickets, page...)
+		}
+	}
+
+	var suggestions []DuplicateTicketSuggestion
+	for _, s := range scoreDuplicateTickets(tickets, opts.withDefaults()) {
+		if s.Target.ID == ticketID || s.Source.ID == ticketID {
+			suggestions = append(suggestions, s)
+		}
+	}
+	sortDuplicateTickets(suggestions)
+	return suggestions, nil
+}
+
+// MergeDuplicateTicket merges the source ticket of the accepted suggestion into its target.
+// The closing comment is added to the source ticket, and a comment referring to the source
+// ticket is added to the target. A default closing comment is used when it is empty.
+func MergeDuplicateTicket(ctx context.Context, api API, s DuplicateTicketSuggestion, closingComment string) (JobStatus, error) {
+	if closingComment == "" {
+		closingComment = fmt.Sprintf("This request was closed and merged into request #%d as a duplicate.", s.Target.ID)
+	}
+	return api.MergeTickets(ctx, s.Target.ID, TicketMergeOptions{
+		IDs:           []int64{s.Source.ID},
+		TargetComment: fmt.Sprintf("Request #%d was closed and merged into this request as a duplicate.", s.Source.ID),
+		SourceComment: closingComment,
+	})
+}
@@ -0,0 +1,123 @@
+package zendesk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func duplicateTestTickets() []Ticket {
+	at := func(hours int) *time.Time {
+		t := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(hours) * time.Hour)
+		return &t
+	}
+	return []Ticket{
+		{ID: 1, Status: "open", RequesterID: 10, OrganizationID: 100, CreatedAt: at(0),
+			Subject: "Charged twice for subscription", Description: "My card was charged twice for the subscription this month."},
+		{ID: 2, Status: "new", RequesterID: 10, OrganizationID: 100, CreatedAt: at(2),
+			Subject: "Double charge on subscription", Description: "The subscription was charged twice on my card."},
+		// same organization, other requester
+		{ID: 3, Status: "new", RequesterID: 11, OrganizationID: 100, CreatedAt: at(3),
+			Subject: "Subscription charged twice", Description: "Our card was charged twice for the subscription."},
+		// unrelated requester
+		{ID: 4, Status: "new", RequesterID: 12, CreatedAt: at(1),
+			Subject: "Charged twice for subscription", Description: "My card was charged twice for the subscription this month."},
+		// solved
+		{ID: 5, Status: "solved", RequesterID: 10, CreatedAt: at(1),
+			Subject: "Charged twice for subscription", Description: "My card was charged twice for the subscription this month."},
+		// out of the window
+		{ID: 6, Status: "open", RequesterID: 10, CreatedAt: at(24 * 30),
+			Subject: "Charged twice for subscription", Description: "My card was charged twice for the subscription this month."},
+		// different topic
+		{ID: 7, Status: "open", RequesterID: 10, CreatedAt: at(4),
+			Subject: "Cannot log in", Description: "The password reset email never arrives."},
+	}
+}
+
+func TestFindDuplicateTickets(t *testing.T) {
+	suggestions := FindDuplicateTickets(duplicateTestTickets(), nil)
+	if len(suggestions) != 2 {
+		t.Fatalf("Expected 2 suggestions, but got %+v", suggestions)
+	}
+
+	sources := map[int64]DuplicateTicketSuggestion{}
+	for _, s := range suggestions {
+		if s.Target.ID != 1 {
+			t.Fatalf("Tickets should be merged into the oldest ticket: %+v", s)
+		}
+		if s.Score < defaultDuplicateMinScore || s.Similarity <= 0 || s.Proximity <= 0 {
+			t.Fatalf("Unexpected scores %+v", s)
+		}
+		sources[s.Source.ID] = s
+	}
+	if !sources[2].SameRequester || sources[3].SameRequester || !sources[3].SameOrganization {
+		t.Fatalf("Unexpected relations %+v", sources)
+	}
+	if suggestions[0].Score < suggestions[1].Score {
+		t.Fatal("Suggestions should be ordered by score")
+	}
+
+	if s := FindDuplicateTickets(duplicateTestTickets(), &DuplicateTicketOptions{MinScore: 0.99}); len(s) != 0 {
+		t.Fatalf("High minimum score should filter suggestions: %+v", s)
+	}
+}
+
+func TestFindTicketDuplicates(t *testing.T) {
+	tickets := duplicateTestTickets()
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tickets/2.json":
+			json.NewEncoder(w).Encode(map[string]interface{}{"ticket": tickets[1]})
+		case "/users/10/tickets/requested.json":
+			json.NewEncoder(w).Encode(map[string]interface{}{"tickets": []Ticket{tickets[0], tickets[1], tickets[6]}})
+		case "/organizations/100/tickets.json":
+			json.NewEncoder(w).Encode(map[string]interface{}{"tickets": tickets[:3]})
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	suggestions, err := FindTicketDuplicates(ctx, client, 2, nil)
+	if err != nil {
+		t.Fatalf("Failed to find ticket duplicates: %s", err)
+	}
+
+	if len(suggestions) != 2 {
+		t.Fatalf("Expected 2 suggestions, but got %+v", suggestions)
+	}
+	for _, s := range suggestions {
+		if s.Target.ID != 2 && s.Source.ID != 2 {
+			t.Fatalf("Suggestions should involve the ticket: %+v", s)
+		}
+	}
+}
+
+func TestMergeDuplicateTicket(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tickets/1/merge.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		var opts TicketMergeOptions
+		json.NewDecoder(r.Body).Decode(&opts)
+		if len(opts.IDs) != 1 || opts.IDs[0] != 2 || !strings.Contains(opts.SourceComment, "#1") ||
+			!strings.Contains(opts.TargetComment, "#2") {
+			t.Errorf("Unexpected merge options %+v", opts)
+		}
+		w.Write(readFixture(filepath.Join(http.MethodPost, "job_status.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	tickets := duplicateTestTickets()
+	_, err := MergeDuplicateTicket(ctx, client, DuplicateTicketSuggestion{Target: tickets[0], Source: tickets[1]}, "")
+	if err != nil {
+		t.Fatalf("Failed to merge duplicate ticket: %s", err)
+	}
+}
@@ -46,9 +46,17 @@ type TicketField struct {
 	AgentDescription    string                         `json:"agent_description,omitempty"`
 }
 
+// TicketFieldListOptions is options for GetLocalizedTicketFields
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_fields/#list-ticket-fields
+type TicketFieldListOptions struct {
+	Locale string `url:"locale,omitempty"`
+}
+
 // TicketFieldAPI an interface containing all of the ticket field related zendesk methods
 type TicketFieldAPI interface {
 	GetTicketFields(ctx context.Context) ([]TicketField, Page, error)
+	GetLocalizedTicketFields(ctx context.Context, locale string) ([]TicketField, Page, error)
 	CreateTicketField(ctx context.Context, ticketField TicketField) (TicketField, error)
 	GetTicketField(ctx context.Context, ticketID int64) (TicketField, error)
 	UpdateTicketField(ctx context.Context, ticketID int64, field TicketField) (TicketField, error)
@@ -78,6 +86,32 @@ func (z *Client) GetTicketFields(ctx context.Context) ([]TicketField, Page, erro
 	return data.TicketFields, data.Page, nil
 }
 
+// GetLocalizedTicketFields fetches ticket field list in the specified locale.
+// Titles and custom field option names which use dynamic content are rendered
+// with the variant of the locale.
+// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_fields/#list-ticket-fields
+func (z *Client) GetLocalizedTicketFields(ctx context.Context, locale string) ([]TicketField, Page, error) {
+	var data struct {
+		TicketFields []TicketField `json:"ticket_fields"`
+		Page
+	}
+
+	u, err := addOptions("/ticket_fields.json", TicketFieldListOptions{Locale: locale})
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	body, err := z.get(WithLocale(ctx, locale), u)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, Page{}, err
+	}
+	return data.TicketFields, data.Page, nil
+}
+
 // CreateTicketField creates new ticket field
 // ref: https://developer.zendesk.com/rest_api/docs/core/ticket_fields#create-ticket-field
 func (z *Client) CreateTicketField(ctx context.Context, ticketField TicketField) (TicketField, error) {
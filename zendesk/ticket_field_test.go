@@ -3,6 +3,7 @@ package zendesk
 import (
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"testing"
 )
 
@@ -21,6 +22,32 @@ func TestGetTicketFields(t *testing.T) {
 	}
 }
 
+func TestGetLocalizedTicketFields(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/ticket_fields.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		if locale := r.URL.Query().Get("locale"); locale != "ja" {
+			t.Errorf("Expected locale param ja, but got %s", locale)
+		}
+		if lang := r.Header.Get("Accept-Language"); lang != "ja" {
+			t.Errorf("Expected Accept-Language ja, but got %s", lang)
+		}
+		w.Write(readFixture(filepath.Join(http.MethodGet, "ticket_fields.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	ticketFields, _, err := client.GetLocalizedTicketFields(ctx, "ja")
+	if err != nil {
+		t.Fatalf("Failed to get ticket fields: %s", err)
+	}
+
+	if len(ticketFields) != 15 {
+		t.Fatalf("expected length of ticket fields is 15, but got %d", len(ticketFields))
+	}
+}
+
 func TestGetTicketField(t *testing.T) {
 	mockAPI := newMockAPI(http.MethodGet, "ticket_field.json")
 	client := newTestClient(mockAPI)
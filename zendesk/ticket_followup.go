@@ -0,0 +1,111 @@
+package zendesk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Satisfaction rating scores of a ticket
+const (
+	SatisfactionScoreUnoffered = "unoffered"
+	SatisfactionScoreOffered   = "offered"
+	SatisfactionScoreGood      = "good"
+	SatisfactionScoreBad       = "bad"
+)
+
+// SatisfactionFollowupOptions is options of CreateSatisfactionFollowup
+type SatisfactionFollowupOptions struct {
+	// GroupID is the group the follow-up ticket is assigned to
+	GroupID int64
+
+	// CustomFieldIDs are the custom fields copied to the follow-up ticket.
+	// All custom fields which have a value are copied when it is empty.
+	CustomFieldIDs []int64
+
+	// Subject of the follow-up ticket. Defaults to "Follow-up: " and the subject of the rated ticket.
+	Subject string
+
+	// Tags are added to the follow-up ticket in addition to the tags of the rated ticket
+	Tags []string
+
+	// Priority of the follow-up ticket
+	Priority string
+}
+
+// CreateSatisfactionFollowup creates a follow-up of a ticket which received a satisfaction
+// rating. The follow-up is linked to the rated ticket with via_followup_source_id, has
+// the same requester, organization, tags and custom fields, and is assigned to
+// opts.GroupID. The rating and its comment are added as a private comment.
+//
+// Zendesk only accepts follow-ups of closed tickets.
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#creating-follow-up-tickets
+func CreateSatisfactionFollowup(ctx context.Context, api API, ticketID int64, opts SatisfactionFollowupOptions) (Ticket, error) {
+	source, err := api.GetTicket(ctx, ticketID)
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	rating := source.SatisfactionRating
+	if rating == nil || rating.Score == "" || rating.Score == SatisfactionScoreUnoffered || rating.Score == SatisfactionScoreOffered {
+		return Ticket{}, fmt.Errorf("ticket %d has no satisfaction rating", ticketID)
+	}
+
+	return api.CreateTicket(ctx, satisfactionFollowup(source, opts))
+}
+
+// satisfactionFollowup builds the follow-up ticket of a rated ticket
+func satisfactionFollowup(source Ticket, opts SatisfactionFollowupOptions) Ticket {
+	subject := opts.Subject
+	if subject == "" {
+		subject = "Follow-up: " + source.Subject
+	}
+
+	tags := make([]string, 0, len(source.Tags)+len(opts.Tags))
+	for _, list := range [][]string{source.Tags, opts.Tags} {
+		for _, tag := range list {
+			if !containsTag(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	var fields []CustomField
+	for _, f := range source.CustomFields {
+		if f.Value == nil || f.Value == "" {
+			continue
+		}
+		if len(opts.CustomFieldIDs) > 0 && !containsID(opts.CustomFieldIDs, f.ID) {
+			continue
+		}
+		fields = append(fields, f)
+	}
+
+	public := false
+	rating := source.SatisfactionRating
+	body := fmt.Sprintf("Satisfaction rating of #%d: %s", source.ID, rating.Score)
+	if comment := strings.TrimSpace(rating.Comment); comment != "" {
+		body += "\n\n" + comment
+	}
+
+	followup := Ticket{
+		Subject:             subject,
+		Priority:            opts.Priority,
+		RequesterID:         source.RequesterID,
+		OrganizationID:      source.OrganizationID,
+		Tags:                tags,
+		CustomFields:        fields,
+		ViaFollowupSourceID: source.ID,
+		Comment: &TicketComment{
+			Body:   body,
+			Public: &public,
+		},
+	}
+	if opts.GroupID != 0 {
+		followup.GroupID = json.Number(strconv.FormatInt(opts.GroupID, 10))
+	}
+	return followup
+}
@@ -0,0 +1,91 @@
+package zendesk
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newFollowupMockAPI(t *testing.T, created *map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/tickets/1.json":
+			w.Write([]byte(`{"ticket": {"id": 1, "subject": "Printer is broken", "status": "closed",
+				"requester_id": 100, "organization_id": 200, "group_id": 10, "tags": ["printer", "vip"],
+				"custom_fields": [{"id": 11, "value": "hardware"}, {"id": 12, "value": "tokyo"}, {"id": 13, "value": null}],
+				"satisfaction_rating": {"id": 5, "score": "bad", "comment": "Nobody answered for a week"}}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/tickets/2.json":
+			w.Write([]byte(`{"ticket": {"id": 2, "subject": "Unrated", "status": "closed",
+				"satisfaction_rating": {"score": "offered"}}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/tickets.json":
+			var data struct {
+				Ticket map[string]interface{} `json:"ticket"`
+			}
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &data); err != nil {
+				t.Errorf("Failed to decode ticket: %s", err)
+			}
+			*created = data.Ticket
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"ticket": {"id": 3, "via_followup_source_id": 1}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestCreateSatisfactionFollowup(t *testing.T) {
+	var created map[string]interface{}
+	mockAPI := newFollowupMockAPI(t, &created)
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	ticket, err := CreateSatisfactionFollowup(ctx, client, 1, SatisfactionFollowupOptions{
+		GroupID:        30,
+		CustomFieldIDs: []int64{11, 13},
+		Tags:           []string{"csat_followup", "vip"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create satisfaction follow-up: %s", err)
+	}
+	if ticket.ID != 3 || ticket.ViaFollowupSourceID != 1 {
+		t.Fatalf("Unexpected follow-up ticket %v", ticket)
+	}
+
+	if created["via_followup_source_id"] != float64(1) || created["subject"] != "Follow-up: Printer is broken" {
+		t.Fatalf("Follow-up should be linked to the rated ticket: %v", created)
+	}
+	if created["requester_id"] != float64(100) || created["organization_id"] != float64(200) || created["group_id"] != float64(30) {
+		t.Fatalf("Unexpected requester, organization or group of follow-up: %v", created)
+	}
+	if tags, _ := json.Marshal(created["tags"]); string(tags) != `["printer","vip","csat_followup"]` {
+		t.Fatalf("Unexpected tags of follow-up: %s", tags)
+	}
+	if fields, _ := json.Marshal(created["custom_fields"]); string(fields) != `[{"id":11,"value":"hardware"}]` {
+		t.Fatalf("Unexpected custom fields of follow-up: %s", fields)
+	}
+
+	comment, _ := created["comment"].(map[string]interface{})
+	body, _ := comment["body"].(string)
+	if comment["public"] != false || !strings.Contains(body, "bad") || !strings.Contains(body, "Nobody answered for a week") {
+		t.Fatalf("Rating comment should be added as a private comment: %v", comment)
+	}
+}
+
+func TestCreateSatisfactionFollowupWithoutRating(t *testing.T) {
+	var created map[string]interface{}
+	mockAPI := newFollowupMockAPI(t, &created)
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	if _, err := CreateSatisfactionFollowup(ctx, client, 2, SatisfactionFollowupOptions{}); err == nil {
+		t.Fatal("Follow-up of a ticket without rating should not be created")
+	}
+	if created != nil {
+		t.Fatalf("Ticket should not be created: %v", created)
+	}
+}
@@ -0,0 +1,88 @@
+package zendesk
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// TicketImport is a ticket with its comment history to be imported.
+// The comments keep their author and created_at, and the first one is the description.
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_import/
+type TicketImport struct {
+	Ticket
+	Comments []TicketComment `json:"comments,omitempty"`
+	SolvedAt *time.Time      `json:"solved_at,omitempty"`
+}
+
+// TicketImportOptions is options for ImportTicket
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_import/#ticket-import
+type TicketImportOptions struct {
+	// ArchiveImmediately archives closed tickets right after they are imported
+	ArchiveImmediately bool `url:"archive_immediately,omitempty"`
+}
+
+// TicketImportAPI an interface containing ticket import related methods
+type TicketImportAPI interface {
+	ImportTicket(ctx context.Context, ticket TicketImport, opts *TicketImportOptions) (Ticket, error)
+	ImportManyTickets(ctx context.Context, tickets []TicketImport, opts *TicketImportOptions) (JobStatus, error)
+}
+
+// ImportTicket imports a ticket with its comments without sending notifications or running triggers
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_import/#ticket-import
+func (z *Client) ImportTicket(ctx context.Context, ticket TicketImport, opts *TicketImportOptions) (Ticket, error) {
+	var data struct {
+		Ticket TicketImport `json:"ticket"`
+	}
+	data.Ticket = ticket
+
+	var result struct {
+		Ticket Ticket `json:"ticket"`
+	}
+
+	tmp := opts
+	if tmp == nil {
+		tmp = &TicketImportOptions{}
+	}
+
+	u, err := addOptions("/imports/tickets.json", tmp)
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	body, err := z.post(ctx, u, data)
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return Ticket{}, err
+	}
+	return result.Ticket, nil
+}
+
+// ImportManyTickets imports up to 100 tickets with their comments in a background job
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_import/#ticket-bulk-import
+func (z *Client) ImportManyTickets(ctx context.Context, tickets []TicketImport, opts *TicketImportOptions) (JobStatus, error) {
+	var data struct {
+		Tickets []TicketImport `json:"tickets"`
+	}
+	data.Tickets = tickets
+
+	tmp := opts
+	if tmp == nil {
+		tmp = &TicketImportOptions{}
+	}
+
+	u, err := addOptions("/imports/tickets/create_many.json", tmp)
+	if err != nil {
+		return JobStatus{}, err
+	}
+
+	return z.postJob(ctx, u, data)
+}
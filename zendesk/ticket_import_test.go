@@ -0,0 +1,69 @@
+package zendesk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestImportTicket(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data struct {
+			Ticket map[string]interface{} `json:"ticket"`
+		}
+		json.NewDecoder(r.Body).Decode(&data)
+
+		if r.URL.Path != "/imports/tickets.json" || r.URL.Query().Get("archive_immediately") != "true" {
+			t.Errorf("Unexpected request to %s", r.URL)
+		}
+		comments, _ := data.Ticket["comments"].([]interface{})
+		if data.Ticket["subject"] != "Imported" || len(comments) != 2 || data.Ticket["solved_at"] == nil {
+			t.Errorf("Unexpected ticket import payload %v", data.Ticket)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write(readFixture(filepath.Join(http.MethodPost, "ticket.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	solvedAt := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	ticket, err := client.ImportTicket(ctx, TicketImport{
+		Ticket: Ticket{Subject: "Imported", Status: "closed", RequesterID: 1},
+		Comments: []TicketComment{
+			NewPublicTicketComment("Help", 1),
+			NewPrivateTicketComment("Solved", 2),
+		},
+		SolvedAt: &solvedAt,
+	}, &TicketImportOptions{ArchiveImmediately: true})
+	if err != nil {
+		t.Fatalf("Failed to import ticket: %s", err)
+	}
+
+	if ticket.ID == 0 {
+		t.Fatal("Imported ticket should have an ID")
+	}
+}
+
+func TestImportManyTickets(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/imports/tickets/create_many.json" {
+			t.Errorf("Unexpected request to %s", r.URL)
+		}
+		w.Write(readFixture(filepath.Join(http.MethodPost, "job_status.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	job, err := client.ImportManyTickets(ctx, []TicketImport{{Ticket: Ticket{Subject: "a"}}}, nil)
+	if err != nil {
+		t.Fatalf("Failed to import many tickets: %s", err)
+	}
+
+	if job.Status != JobStatusQueued {
+		t.Fatalf("Returned job status is not the expected one: %+v", job)
+	}
+}
@@ -0,0 +1,27 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+)
+
+// TicketMergeOptions is the request of MergeTickets.
+// The comments are added to the target and source tickets; Zendesk adds default
+// comments when they are empty.
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#merge-tickets-into-target-ticket
+type TicketMergeOptions struct {
+	IDs                   []int64 `json:"ids"`
+	TargetComment         string  `json:"target_comment,omitempty"`
+	SourceComment         string  `json:"source_comment,omitempty"`
+	TargetCommentIsPublic *bool   `json:"target_comment_is_public,omitempty"`
+	SourceCommentIsPublic *bool   `json:"source_comment_is_public,omitempty"`
+}
+
+// MergeTickets merges the tickets of opts.IDs into the target ticket in a background job.
+// The source tickets are closed.
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#merge-tickets-into-target-ticket
+func (z *Client) MergeTickets(ctx context.Context, targetID int64, opts TicketMergeOptions) (JobStatus, error) {
+	return z.postJob(ctx, fmt.Sprintf("/tickets/%d/merge.json", targetID), opts)
+}
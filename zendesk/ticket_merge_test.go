@@ -0,0 +1,34 @@
+package zendesk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestMergeTickets(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tickets/1/merge.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		var opts TicketMergeOptions
+		json.NewDecoder(r.Body).Decode(&opts)
+		if len(opts.IDs) != 2 || opts.SourceComment != "Closing" {
+			t.Errorf("Unexpected merge options %+v", opts)
+		}
+		w.Write(readFixture(filepath.Join(http.MethodPost, "job_status.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	job, err := client.MergeTickets(ctx, 1, TicketMergeOptions{IDs: []int64{2, 3}, SourceComment: "Closing"})
+	if err != nil {
+		t.Fatalf("Failed to merge tickets: %s", err)
+	}
+
+	if job.ID == "" {
+		t.Fatal("Job status should have an ID")
+	}
+}
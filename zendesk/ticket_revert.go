@@ -0,0 +1,320 @@
+package zendesk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ticket fields which can be reverted by RevertTicket
+const (
+	TicketRevertFieldStatus      = "status"
+	TicketRevertFieldAssigneeID  = "assignee_id"
+	TicketRevertFieldGroupID     = "group_id"
+	TicketRevertFieldTags        = "tags"
+	TicketRevertFieldCustomField = "custom_fields"
+)
+
+// TicketRevertOptions specifies the point in a ticket's history to revert to.
+// Either Before or BeforeAuditID is required.
+type TicketRevertOptions struct {
+	// Before reverts the changes made at or after this time
+	Before time.Time
+
+	// BeforeAuditID reverts the changes made by this audit and the later ones
+	BeforeAuditID int64
+
+	// DryRun only computes the changes without updating the ticket
+	DryRun bool
+
+	// Comment is added to the ticket as a private comment when it is reverted
+	Comment string
+}
+
+// TicketRevertChange is a change of a ticket field needed to revert it.
+// From and To are nil when the field has no value.
+type TicketRevertChange struct {
+	Field         string      `json:"field"`
+	CustomFieldID int64       `json:"custom_field_id,omitempty"`
+	From          interface{} `json:"from"`
+	To            interface{} `json:"to"`
+}
+
+// TicketRevertResult is the result of reverting a ticket
+type TicketRevertResult struct {
+	TicketID int64                `json:"ticket_id"`
+	Changes  []TicketRevertChange `json:"changes"`
+
+	// Ticket is the updated ticket. It is empty on dry-run or when nothing was changed.
+	Ticket Ticket `json:"ticket"`
+
+	// Err is the error which occurred while reverting the ticket in RevertTickets
+	Err error `json:"-"`
+}
+
+// auditEvent is a field change event of a ticket audit
+type auditEvent struct {
+	Type          string      `json:"type"`
+	FieldName     string      `json:"field_name"`
+	Value         interface{} `json:"value"`
+	PreviousValue interface{} `json:"previous_value"`
+}
+
+// RevertTicket computes the field values of the ticket as of the point given by opts
+// from its audits, and applies the inverse changes of status, assignee, group, tags
+// and custom fields with safe update. The update fails if the ticket is updated
+// concurrently.
+//
+// ref: https://developer.zendesk.com/documentation/ticketing/managing-tickets/creating-and-updating-tickets/#protecting-against-ticket-update-collisions
+func RevertTicket(ctx context.Context, api API, ticketID int64, opts TicketRevertOptions) (TicketRevertResult, error) {
+	result := TicketRevertResult{TicketID: ticketID}
+
+	ticket, err := api.GetTicket(ctx, ticketID)
+	if err != nil {
+		return result, err
+	}
+
+	var audits []TicketAudit
+	pageOpts := NewPaginationOptions()
+	pageOpts.Id = ticketID
+	it := api.GetTicketAuditsIterator(ctx, pageOpts)
+	for it.HasMore() {
+		page, err := it.GetNext()
+		if err != nil {
+			return result, err
+		}
+		audits = append(audits, page...)
+	}
+
+	result.Changes, err = ComputeTicketRevert(ticket, audits, opts)
+	if err != nil {
+		return result, err
+	}
+	if opts.DryRun || len(result.Changes) == 0 {
+		return result, nil
+	}
+
+	fields := map[string]interface{}{
+		"safe_update":   true,
+		"updated_stamp": ticket.UpdatedAt,
+	}
+	var customFields []map[string]interface{}
+	for _, c := range result.Changes {
+		if c.Field == TicketRevertFieldCustomField {
+			customFields = append(customFields, map[string]interface{}{"id": c.CustomFieldID, "value": c.To})
+			continue
+		}
+		fields[c.Field] = c.To
+	}
+	if customFields != nil {
+		fields["custom_fields"] = customFields
+	}
+	if opts.Comment != "" {
+		fields["comment"] = NewPrivateTicketComment(opts.Comment, 0)
+	}
+
+	body, err := api.Put(ctx, fmt.Sprintf("/tickets/%d.json", ticketID), map[string]interface{}{"ticket": fields})
+	if err != nil {
+		return result, err
+	}
+
+	var data struct {
+		Ticket Ticket `json:"ticket"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return result, err
+	}
+	result.Ticket = data.Ticket
+
+	return result, nil
+}
+
+// RevertTickets reverts each ticket with RevertTicket. It continues with the
+// remaining tickets when one fails, and reports the error in its result.
+func RevertTickets(ctx context.Context, api API, ticketIDs []int64, opts TicketRevertOptions) []TicketRevertResult {
+	results := make([]TicketRevertResult, 0, len(ticketIDs))
+	for _, id := range ticketIDs {
+		result, err := RevertTicket(ctx, api, id, opts)
+		result.Err = err
+		results = append(results, result)
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return results
+}
+
+// ComputeTicketRevert returns the changes which revert the ticket to the point given by opts.
+// audits must be all audits of the ticket in chronological order.
+func ComputeTicketRevert(ticket Ticket, audits []TicketAudit, opts TicketRevertOptions) ([]TicketRevertChange, error) {
+	cut, err := revertCutIndex(audits, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// the value of a field before the cut is the previous value of
+	// its first change at or after the cut
+	before := map[string]interface{}{}
+	for _, audit := range audits[cut:] {
+		for _, raw := range audit.Events {
+			event, ok := decodeAuditEvent(raw)
+			if !ok || event.Type != "Change" {
+				continue
+			}
+			if _, seen := before[event.FieldName]; seen {
+				continue
+			}
+			before[event.FieldName] = event.PreviousValue
+		}
+	}
+
+	var changes []TicketRevertChange
+	add := func(field string, customFieldID int64, from, to interface{}) {
+		if normalizeRevertValue(from) == normalizeRevertValue(to) {
+			return
+		}
+		changes = append(changes, TicketRevertChange{
+			Field:         field,
+			CustomFieldID: customFieldID,
+			From:          from,
+			To:            to,
+		})
+	}
+
+	if v, ok := before[TicketRevertFieldStatus]; ok {
+		add(TicketRevertFieldStatus, 0, ticket.Status, v)
+	}
+	if v, ok := before[TicketRevertFieldAssigneeID]; ok {
+		var from interface{}
+		if ticket.AssigneeID != 0 {
+			from = ticket.AssigneeID
+		}
+		add(TicketRevertFieldAssigneeID, 0, from, auditIDValue(v))
+	}
+	if v, ok := before[TicketRevertFieldGroupID]; ok {
+		var from interface{}
+		if id, err := ticket.GroupID.Int64(); err == nil && id != 0 {
+			from = id
+		}
+		add(TicketRevertFieldGroupID, 0, from, auditIDValue(v))
+	}
+	if v, ok := before[TicketRevertFieldTags]; ok {
+		add(TicketRevertFieldTags, 0, ticket.Tags, auditTagsValue(v))
+	}
+
+	var customFieldIDs []int64
+	for name := range before {
+		if id, err := strconv.ParseInt(name, 10, 64); err == nil {
+			customFieldIDs = append(customFieldIDs, id)
+		}
+	}
+	sort.Slice(customFieldIDs, func(i, j int) bool { return customFieldIDs[i] < customFieldIDs[j] })
+	for _, id := range customFieldIDs {
+		var from interface{}
+		for _, cf := range ticket.CustomFields {
+			if cf.ID == id {
+				from = cf.Value
+			}
+		}
+		add(TicketRevertFieldCustomField, id, from, before[strconv.FormatInt(id, 10)])
+	}
+
+	return changes, nil
+}
+
+// revertCutIndex returns the index of the first audit to revert
+func revertCutIndex(audits []TicketAudit, opts TicketRevertOptions) (int, error) {
+	if opts.BeforeAuditID != 0 {
+		for i, audit := range audits {
+			if audit.ID == opts.BeforeAuditID {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("audit %d is not found in the ticket audits", opts.BeforeAuditID)
+	}
+
+	if opts.Before.IsZero() {
+		return 0, errors.New("either Before or BeforeAuditID is required to revert a ticket")
+	}
+
+	for i, audit := range audits {
+		if audit.CreatedAt != nil && !audit.CreatedAt.Before(opts.Before) {
+			return i, nil
+		}
+	}
+	return len(audits), nil
+}
+
+func decodeAuditEvent(raw interface{}) (auditEvent, bool) {
+	var event auditEvent
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return event, false
+	}
+	if err := json.Unmarshal(b, &event); err != nil {
+		return event, false
+	}
+	return event, event.FieldName != ""
+}
+
+// auditIDValue converts an ID in an audit event, which is a string, to int64
+func auditIDValue(v interface{}) interface{} {
+	switch id := v.(type) {
+	case string:
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil || n == 0 {
+			return nil
+		}
+		return n
+	case float64:
+		if id == 0 {
+			return nil
+		}
+		return int64(id)
+	}
+	return nil
+}
+
+// auditTagsValue converts tags in an audit event to []string.
+// Tags may be a list or a space separated string.
+func auditTagsValue(v interface{}) []string {
+	tags := []string{}
+	switch t := v.(type) {
+	case []interface{}:
+		for _, tag := range t {
+			if s, ok := tag.(string); ok {
+				tags = append(tags, s)
+			}
+		}
+	case string:
+		tags = append(tags, strings.Fields(t)...)
+	}
+	return tags
+}
+
+// normalizeRevertValue returns a comparable representation of a field value
+func normalizeRevertValue(v interface{}) string {
+	var list []string
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case []string:
+		list = append(list, t...)
+	case []interface{}:
+		for _, e := range t {
+			list = append(list, fmt.Sprint(e))
+		}
+	default:
+		return fmt.Sprint(v)
+	}
+
+	sort.Strings(list)
+	return strings.Join(list, " ")
+}
@@ -0,0 +1,166 @@
+package zendesk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func revertTestAudits() []TicketAudit {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	t3 := t2.Add(time.Hour)
+
+	return []TicketAudit{
+		{
+			ID:        1,
+			CreatedAt: &t1,
+			Events: []interface{}{
+				map[string]interface{}{"type": "Create", "field_name": "status", "value": "new"},
+			},
+		},
+		{
+			ID:        2,
+			CreatedAt: &t2,
+			Events: []interface{}{
+				map[string]interface{}{"type": "Comment", "body": "mangled by trigger"},
+				map[string]interface{}{"type": "Change", "field_name": "status", "value": "pending", "previous_value": "open"},
+				map[string]interface{}{"type": "Change", "field_name": "assignee_id", "value": "200", "previous_value": nil},
+				map[string]interface{}{"type": "Change", "field_name": "group_id", "value": "20", "previous_value": "10"},
+				map[string]interface{}{"type": "Change", "field_name": "tags", "value": []interface{}{"a", "b", "bad"}, "previous_value": []interface{}{"b", "a"}},
+				map[string]interface{}{"type": "Change", "field_name": "360001", "value": "x", "previous_value": "y"},
+			},
+		},
+		{
+			ID:        3,
+			CreatedAt: &t3,
+			Events: []interface{}{
+				map[string]interface{}{"type": "Change", "field_name": "status", "value": "solved", "previous_value": "pending"},
+			},
+		},
+	}
+}
+
+func TestComputeTicketRevert(t *testing.T) {
+	ticket := Ticket{
+		Status:       "solved",
+		AssigneeID:   200,
+		GroupID:      "20",
+		Tags:         []string{"a", "b", "bad"},
+		CustomFields: []CustomField{{ID: 360001, Value: "x"}},
+	}
+
+	changes, err := ComputeTicketRevert(ticket, revertTestAudits(), TicketRevertOptions{BeforeAuditID: 2})
+	if err != nil {
+		t.Fatalf("Failed to compute revert: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		TicketRevertFieldStatus:      "open",
+		TicketRevertFieldAssigneeID:  nil,
+		TicketRevertFieldGroupID:     int64(10),
+		TicketRevertFieldTags:        "a b",
+		TicketRevertFieldCustomField: "y",
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, but got %v", len(expected), changes)
+	}
+	for _, c := range changes {
+		to := c.To
+		if _, ok := to.([]string); ok {
+			to = normalizeRevertValue(to)
+		}
+		if to != expected[c.Field] {
+			t.Fatalf("Field %s should be reverted to %v, but got %v", c.Field, expected[c.Field], c.To)
+		}
+	}
+
+	cut := revertTestAudits()[2].CreatedAt
+	changes, err = ComputeTicketRevert(ticket, revertTestAudits(), TicketRevertOptions{Before: *cut})
+	if err != nil {
+		t.Fatalf("Failed to compute revert: %s", err)
+	}
+	if len(changes) != 1 || changes[0].To != "pending" {
+		t.Fatalf("Only status should be reverted to pending, but got %v", changes)
+	}
+}
+
+func TestComputeTicketRevertRequiresPoint(t *testing.T) {
+	if _, err := ComputeTicketRevert(Ticket{}, revertTestAudits(), TicketRevertOptions{}); err == nil {
+		t.Fatal("Revert without a point in history should fail")
+	}
+	if _, err := ComputeTicketRevert(Ticket{}, revertTestAudits(), TicketRevertOptions{BeforeAuditID: 99}); err == nil {
+		t.Fatal("Revert to unknown audit should fail")
+	}
+}
+
+func newRevertMockAPI(updated *map[string]interface{}) *httptest.Server {
+	audits, _ := json.Marshal(map[string]interface{}{"audits": revertTestAudits(), "meta": map[string]interface{}{"has_more": false}})
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/tickets/1/audits.json":
+			w.Write(audits)
+		case r.Method == http.MethodGet && r.URL.Path == "/tickets/1.json":
+			w.Write([]byte(`{"ticket":{"id":1,"status":"solved","assignee_id":200,"group_id":20,"tags":["a","b","bad"],"updated_at":"2024-01-01T03:00:00Z"}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/tickets/1.json":
+			var data map[string]map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&data)
+			*updated = data["ticket"]
+			w.Write([]byte(`{"ticket":{"id":1,"status":"open"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRevertTicket(t *testing.T) {
+	var updated map[string]interface{}
+	mockAPI := newRevertMockAPI(&updated)
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	result, err := RevertTicket(ctx, client, 1, TicketRevertOptions{BeforeAuditID: 2, Comment: "Reverted"})
+	if err != nil {
+		t.Fatalf("Failed to revert ticket: %s", err)
+	}
+	if result.Ticket.Status != "open" {
+		t.Fatalf("Unexpected updated ticket %v", result.Ticket)
+	}
+
+	if updated["safe_update"] != true || updated["updated_stamp"] != "2024-01-01T03:00:00Z" {
+		t.Fatalf("Revert should be a safe update: %v", updated)
+	}
+	if v, ok := updated["assignee_id"]; !ok || v != nil {
+		t.Fatalf("Assignee should be cleared: %v", updated)
+	}
+	if updated["status"] != "open" || updated["group_id"] != float64(10) {
+		t.Fatalf("Unexpected update %v", updated)
+	}
+	if _, ok := updated["comment"]; !ok {
+		t.Fatalf("Revert comment should be added: %v", updated)
+	}
+}
+
+func TestRevertTicketsDryRun(t *testing.T) {
+	var updated map[string]interface{}
+	mockAPI := newRevertMockAPI(&updated)
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	results := RevertTickets(ctx, client, []int64{1, 2}, TicketRevertOptions{BeforeAuditID: 2, DryRun: true})
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, but got %d", len(results))
+	}
+	if results[0].Err != nil || len(results[0].Changes) != 5 {
+		t.Fatalf("Unexpected result %+v", results[0])
+	}
+	if results[1].Err == nil {
+		t.Fatal("Revert of missing ticket should report an error")
+	}
+	if updated != nil {
+		t.Fatal("Dry-run should not update tickets")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"testing"
@@ -34,6 +35,28 @@ func TestGetTickets(t *testing.T) {
 	}
 }
 
+func TestGetIncrementalTickets(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "incremental_tickets.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	tickets, meta, err := client.GetIncrementalTickets(ctx, &CursorOption{StartTime: 1})
+	if err != nil {
+		t.Fatalf("Failed to export tickets: %s", err)
+	}
+
+	if len(tickets) != 2 || tickets[1].Status != "deleted" {
+		t.Fatalf("Unexpected tickets %v", tickets)
+	}
+	if !meta.EndOfStream || meta.AfterCursor != "MTU3NjYxMzUzOS4wfHw0Njd8" {
+		t.Fatalf("Unexpected cursor %+v", meta)
+	}
+
+	if _, _, err := client.GetIncrementalTickets(ctx, nil); err == nil {
+		t.Fatal("Export without options should fail")
+	}
+}
+
 func TestGetTicketsCBP(t *testing.T) {
 	mockAPI := newMockAPI(http.MethodGet, "tickets.json")
 	client := newTestClient(mockAPI)
@@ -209,6 +232,49 @@ func TestGetOrganizationTicketsIterator(t *testing.T) {
 	}
 }
 
+func TestGetUserTicketsIterators(t *testing.T) {
+	var path string
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write(readFixture(fmt.Sprintf("%s/%s", http.MethodGet, "tickets.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	cases := []struct {
+		expectedPath string
+		iterator     func(ctx context.Context, opts *PaginationOptions) *Iterator[Ticket]
+	}{
+		{"/users/123/tickets/requested.json", client.GetUserRequestedTicketsIterator},
+		{"/users/123/tickets/ccd.json", client.GetUserCCDTicketsIterator},
+		{"/users/123/tickets/assigned.json", client.GetUserAssignedTicketsIterator},
+		{"/users/123/tickets/followed.json", client.GetUserFollowedTicketsIterator},
+		{"/tickets/recent.json", client.GetRecentTicketsIterator},
+	}
+
+	for _, c := range cases {
+		for _, isCBP := range []bool{true, false} {
+			ops := NewPaginationOptions()
+			ops.IsCBP = isCBP
+			ops.Id = 123
+			it := c.iterator(ctx, ops)
+
+			tickets, err := it.GetNext()
+			if err != nil {
+				t.Fatalf("Failed to get tickets: %s", err)
+			}
+			if path != c.expectedPath {
+				t.Fatalf("Expected request to %s, but got %s", c.expectedPath, path)
+			}
+
+			expectedLength := 2
+			if len(tickets) != expectedLength {
+				t.Fatalf("Returned tickets does not have the expected length %d. Tickets length is %d", expectedLength, len(tickets))
+			}
+		}
+	}
+}
+
 func TestGetTicket(t *testing.T) {
 	mockAPI := newMockAPI(http.MethodGet, "ticket.json")
 	client := newTestClient(mockAPI)
@@ -423,3 +489,38 @@ func TestTicketMarshalling(t *testing.T) {
 	}
 
 }
+
+func TestCreateManyTickets(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodPost, "job_status.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	job, err := client.CreateManyTickets(ctx, []Ticket{{Subject: "a"}, {Subject: "b"}})
+	if err != nil {
+		t.Fatalf("Failed to create many tickets: %s", err)
+	}
+
+	if job.Status != JobStatusQueued || job.Total != 2 {
+		t.Fatalf("Returned job status is not the expected one: %+v", job)
+	}
+}
+
+func TestDeleteManyTickets(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/tickets/destroy_many.json" || r.URL.Query().Get("ids") != "1,2" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write(readFixture(filepath.Join(http.MethodPost, "job_status.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	job, err := client.DeleteManyTickets(ctx, []int64{1, 2})
+	if err != nil {
+		t.Fatalf("Failed to delete many tickets: %s", err)
+	}
+
+	if job.ID == "" {
+		t.Fatal("Job status should have an ID")
+	}
+}
@@ -0,0 +1,325 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+)
+
+// ticket restrictions of users and ticket access of custom roles
+// ref: https://developer.zendesk.com/api-reference/ticketing/users/users/#json-format
+// ref: https://developer.zendesk.com/api-reference/ticketing/account-configuration/custom_roles/#configuration
+const (
+	TicketRestrictionOrganization = "organization"
+	TicketRestrictionGroups       = "groups"
+	TicketRestrictionAssigned     = "assigned"
+	TicketRestrictionRequested    = "requested"
+
+	TicketAccessAll                         = "all"
+	TicketAccessWithinGroups                = "within-groups"
+	TicketAccessWithinGroupsAndPublicGroups = "within-groups-and-public-groups"
+	TicketAccessWithinOrganization          = "within-organization"
+	TicketAccessAssignedOnly                = "assigned-only"
+)
+
+// TicketViewer is a user and the account configuration which decides the tickets the user can access
+type TicketViewer struct {
+	User User
+
+	// CustomRole is the custom role of the agent. It is ignored for end users and admins.
+	CustomRole *CustomRole
+
+	// Organizations are the organizations the user belongs to
+	Organizations []Organization
+
+	// GroupMemberships are the group memberships of the agent
+	GroupMemberships []GroupMembership
+
+	// TicketGroup is the group of the ticket. Agents with access to public
+	// groups need it to decide whether the group is public.
+	TicketGroup *Group
+}
+
+// TicketVisibility explains whether a user can see or edit a ticket
+type TicketVisibility struct {
+	CanView bool     `json:"can_view"`
+	CanEdit bool     `json:"can_edit"`
+	Reasons []string `json:"reasons"`
+
+	// Undetermined is true when the viewer lacks data needed to decide the
+	// access. CanView and CanEdit are then only what could be decided.
+	Undetermined bool `json:"undetermined,omitempty"`
+}
+
+// ExplainTicketVisibility decides locally whether the viewer can see or edit the ticket
+// from the user role, ticket restriction, custom role configuration, organization sharing,
+// group memberships and CC/follower data, and explains why.
+func ExplainTicketVisibility(ticket Ticket, viewer TicketViewer) TicketVisibility {
+	var v TicketVisibility
+	user := viewer.User
+
+	if user.Suspended {
+		v.because("user %d is suspended", user.ID)
+		return v
+	}
+
+	switch user.Role {
+	case userRoleText[UserRoleAdmin]:
+		v.CanView, v.CanEdit = true, true
+		v.because("user %d is an admin and can access all tickets", user.ID)
+	case userRoleText[UserRoleAgent]:
+		explainAgentVisibility(&v, ticket, viewer)
+	default:
+		explainEndUserVisibility(&v, ticket, viewer)
+	}
+
+	return v
+}
+
+func explainEndUserVisibility(v *TicketVisibility, ticket Ticket, viewer TicketViewer) {
+	user := viewer.User
+
+	switch {
+	case ticket.RequesterID == user.ID:
+		v.CanView, v.CanEdit = true, true
+		v.because("end user %d is the requester", user.ID)
+		return
+	case ticket.SubmitterID == user.ID:
+		v.CanView, v.CanEdit = true, true
+		v.because("end user %d is the submitter", user.ID)
+		return
+	case containsID(ticket.CollaboratorIDs, user.ID) || containsID(ticket.EmailCCIDs, user.ID):
+		v.CanView, v.CanEdit = true, true
+		v.because("end user %d is CC'd on the ticket", user.ID)
+		return
+	}
+
+	if ticket.OrganizationID == 0 {
+		v.because("end user %d is not the requester, submitter or a CC and the ticket has no organization", user.ID)
+		return
+	}
+
+	org, member, known := viewerOrganization(viewer, ticket.OrganizationID)
+	if !member {
+		v.because("end user %d is not a member of the ticket organization %d", user.ID, ticket.OrganizationID)
+		return
+	}
+
+	switch {
+	case known && org.SharedTickets:
+		v.CanView = true
+		v.because("organization %d shares tickets between its members", org.ID)
+	case user.TicketRestriction == TicketRestrictionOrganization:
+		v.CanView = true
+		v.because("end user %d can view tickets from the user's organization", user.ID)
+	case !known:
+		v.Undetermined = true
+		v.because("organization %d of end user %d was not given, so it is unknown whether it shares tickets", org.ID, user.ID)
+		return
+	default:
+		v.because("organization %d does not share tickets and end user %d can only view own tickets", org.ID, user.ID)
+		return
+	}
+
+	if !known {
+		v.Undetermined = true
+		v.because("organization %d was not given, so it is unknown whether its members can comment on shared tickets", org.ID)
+	} else if org.SharedComments {
+		v.CanEdit = true
+		v.because("organization %d allows its members to comment on shared tickets", org.ID)
+	} else {
+		v.because("organization %d does not allow its members to comment on shared tickets", org.ID)
+	}
+}
+
+func explainAgentVisibility(v *TicketVisibility, ticket Ticket, viewer TicketViewer) {
+	user := viewer.User
+	canEdit := true
+	access := agentTicketAccess(user)
+
+	if viewer.CustomRole != nil && user.CustomRoleID != 0 {
+		role := viewer.CustomRole
+		if a, ok := role.Configuration["ticket_access"].(string); ok {
+			access = a
+			v.because("custom role %q grants %q ticket access", role.Name, a)
+		}
+		if editing, ok := role.Configuration["ticket_editing"].(bool); ok && !editing {
+			canEdit = false
+			v.because("custom role %q does not allow ticket editing", role.Name)
+		}
+	}
+
+	groupID, _ := ticket.GroupID.Int64()
+
+	switch access {
+	case TicketAccessAll:
+		v.CanView = true
+		v.because("agent %d can access all tickets", user.ID)
+	case TicketAccessWithinGroups, TicketAccessWithinGroupsAndPublicGroups, TicketRestrictionGroups:
+		switch {
+		case ticket.AssigneeID == user.ID:
+			v.CanView = true
+			v.because("agent %d is the assignee", user.ID)
+		case groupID != 0 && viewerInGroup(viewer, groupID):
+			v.CanView = true
+			v.because("agent %d is a member of the ticket group %d", user.ID, groupID)
+		case access != TicketAccessWithinGroupsAndPublicGroups || groupID == 0:
+			v.because("agent %d can only access tickets in the agent's groups", user.ID)
+		case viewer.TicketGroup == nil || viewer.TicketGroup.ID != groupID || viewer.TicketGroup.IsPublic == nil:
+			v.Undetermined = true
+			v.because("agent %d can access tickets in public groups, but it is unknown whether group %d is public", user.ID, groupID)
+		case *viewer.TicketGroup.IsPublic:
+			v.CanView = true
+			v.because("agent %d can access tickets in public groups and group %d is public", user.ID, groupID)
+		default:
+			v.because("agent %d is not a member of the private group %d", user.ID, groupID)
+		}
+	case TicketAccessWithinOrganization, TicketRestrictionOrganization:
+		if _, member, _ := viewerOrganization(viewer, ticket.OrganizationID); ticket.OrganizationID != 0 && member {
+			v.CanView = true
+			v.because("agent %d is a member of the ticket organization %d", user.ID, ticket.OrganizationID)
+		} else {
+			v.because("agent %d can only access tickets in the agent's organizations", user.ID)
+		}
+	case TicketAccessAssignedOnly, TicketRestrictionAssigned:
+		if ticket.AssigneeID == user.ID {
+			v.CanView = true
+			v.because("agent %d is the assignee", user.ID)
+		} else {
+			v.because("agent %d can only access tickets assigned to the agent", user.ID)
+		}
+	case TicketRestrictionRequested:
+		if ticket.RequesterID == user.ID {
+			v.CanView = true
+			v.because("agent %d is the requester", user.ID)
+		} else {
+			v.because("agent %d can only access tickets requested by the agent", user.ID)
+		}
+	default:
+		v.because("agent %d has unknown ticket access %q", user.ID, access)
+	}
+
+	if !v.CanView && (containsID(ticket.FollowerIDs, user.ID) || containsID(ticket.CollaboratorIDs, user.ID)) {
+		v.because("agent %d follows the ticket, but following does not grant access beyond the agent's restriction", user.ID)
+	}
+
+	v.CanEdit = v.CanView && canEdit
+}
+
+// agentTicketAccess returns the ticket access of an agent without a custom role
+func agentTicketAccess(user User) string {
+	if user.TicketRestriction == "" {
+		return TicketAccessAll
+	}
+	return user.TicketRestriction
+}
+
+// viewerOrganization reports whether the viewer is a member of the organization,
+// and whether the organization itself is known from viewer.Organizations
+func viewerOrganization(viewer TicketViewer, orgID int64) (Organization, bool, bool) {
+	for _, org := range viewer.Organizations {
+		if org.ID == orgID {
+			return org, true, true
+		}
+	}
+	if viewer.User.OrganizationID == orgID && orgID != 0 {
+		return Organization{ID: orgID}, true, false
+	}
+	return Organization{}, false, false
+}
+
+func viewerInGroup(viewer TicketViewer, groupID int64) bool {
+	for _, m := range viewer.GroupMemberships {
+		if m.GroupID == groupID {
+			return true
+		}
+	}
+	return viewer.User.DefaultGroupID == groupID
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *TicketVisibility) because(format string, args ...interface{}) {
+	v.Reasons = append(v.Reasons, fmt.Sprintf(format, args...))
+}
+
+// GetTicketVisibility fetches the ticket, the user, the user's organizations, group memberships,
+// custom role and the ticket group if needed, and explains whether the user can see or edit the ticket.
+func GetTicketVisibility(ctx context.Context, api API, ticketID, userID int64) (TicketVisibility, error) {
+	ticket, err := api.GetTicket(ctx, ticketID)
+	if err != nil {
+		return TicketVisibility{}, err
+	}
+
+	viewer := TicketViewer{}
+	viewer.User, err = api.GetUser(ctx, userID)
+	if err != nil {
+		return TicketVisibility{}, err
+	}
+
+	var orgIDs []int64
+	opts := NewPaginationOptions()
+	opts.UserID = userID
+	orgMemberships := api.GetOrganizationMembershipsIterator(ctx, opts)
+	for orgMemberships.HasMore() {
+		page, err := orgMemberships.GetNext()
+		if err != nil {
+			return TicketVisibility{}, err
+		}
+		for _, m := range page {
+			orgIDs = append(orgIDs, m.OrganizationID)
+		}
+	}
+	if id := viewer.User.OrganizationID; id != 0 && !containsID(orgIDs, id) {
+		orgIDs = append(orgIDs, id)
+	}
+	for _, id := range orgIDs {
+		org, err := api.GetOrganization(ctx, id)
+		if err != nil {
+			return TicketVisibility{}, err
+		}
+		viewer.Organizations = append(viewer.Organizations, org)
+	}
+
+	if viewer.User.Role == userRoleText[UserRoleAgent] {
+		opts := NewPaginationOptions()
+		opts.UserID = userID
+		groupMemberships := api.GetGroupMembershipsIterator(ctx, opts)
+		for groupMemberships.HasMore() {
+			page, err := groupMemberships.GetNext()
+			if err != nil {
+				return TicketVisibility{}, err
+			}
+			viewer.GroupMemberships = append(viewer.GroupMemberships, page...)
+		}
+
+		if viewer.User.CustomRoleID != 0 {
+			roles, err := api.GetCustomRoles(ctx)
+			if err != nil {
+				return TicketVisibility{}, err
+			}
+			for i := range roles {
+				if roles[i].ID == viewer.User.CustomRoleID {
+					viewer.CustomRole = &roles[i]
+				}
+			}
+		}
+
+		groupID, _ := ticket.GroupID.Int64()
+		if viewer.CustomRole != nil && viewer.CustomRole.Configuration["ticket_access"] == TicketAccessWithinGroupsAndPublicGroups && groupID != 0 {
+			group, err := api.GetGroup(ctx, groupID)
+			if err != nil {
+				return TicketVisibility{}, err
+			}
+			viewer.TicketGroup = &group
+		}
+	}
+
+	return ExplainTicketVisibility(ticket, viewer), nil
+}
@@ -0,0 +1,181 @@
+package zendesk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExplainTicketVisibility(t *testing.T) {
+	ticket := Ticket{
+		ID:              1,
+		RequesterID:     10,
+		AssigneeID:      20,
+		GroupID:         "300",
+		OrganizationID:  400,
+		CollaboratorIDs: []int64{11},
+		FollowerIDs:     []int64{22},
+	}
+	lightAgent := &CustomRole{
+		ID:   5,
+		Name: "Light agent",
+		Configuration: Configuration{
+			"ticket_access":  TicketAccessWithinGroups,
+			"ticket_editing": false,
+		},
+	}
+
+	publicGroups := &CustomRole{
+		ID:            6,
+		Name:          "Public groups agent",
+		Configuration: Configuration{"ticket_access": TicketAccessWithinGroupsAndPublicGroups},
+	}
+	public, private := true, false
+
+	cases := []struct {
+		name         string
+		viewer       TicketViewer
+		canView      bool
+		canEdit      bool
+		undetermined bool
+	}{
+		{"requester", TicketViewer{User: User{ID: 10, Role: "end-user"}}, true, true, false},
+		{"cc", TicketViewer{User: User{ID: 11, Role: "end-user"}}, true, true, false},
+		{"suspended requester", TicketViewer{User: User{ID: 10, Role: "end-user", Suspended: true}}, false, false, false},
+		{"stranger", TicketViewer{User: User{ID: 12, Role: "end-user"}}, false, false, false},
+		{
+			"org member without sharing",
+			TicketViewer{User: User{ID: 13, Role: "end-user"}, Organizations: []Organization{{ID: 400}}},
+			false, false, false,
+		},
+		{
+			"org member with shared tickets",
+			TicketViewer{User: User{ID: 13, Role: "end-user"}, Organizations: []Organization{{ID: 400, SharedTickets: true}}},
+			true, false, false,
+		},
+		{
+			"org member with shared comments",
+			TicketViewer{User: User{ID: 13, Role: "end-user"}, Organizations: []Organization{{ID: 400, SharedTickets: true, SharedComments: true}}},
+			true, true, false,
+		},
+		{
+			"end user with organization restriction",
+			TicketViewer{User: User{ID: 13, Role: "end-user", OrganizationID: 400, TicketRestriction: TicketRestrictionOrganization}},
+			true, false, true,
+		},
+		{"admin", TicketViewer{User: User{ID: 1, Role: "admin"}}, true, true, false},
+		{"unrestricted agent", TicketViewer{User: User{ID: 21, Role: "agent"}}, true, true, false},
+		{"assigned only agent", TicketViewer{User: User{ID: 21, Role: "agent", TicketRestriction: TicketRestrictionAssigned}}, false, false, false},
+		{"assignee", TicketViewer{User: User{ID: 20, Role: "agent", TicketRestriction: TicketRestrictionAssigned}}, true, true, false},
+		{
+			"group member",
+			TicketViewer{User: User{ID: 21, Role: "agent", TicketRestriction: TicketRestrictionGroups}, GroupMemberships: []GroupMembership{{GroupID: 300}}},
+			true, true, false,
+		},
+		{"follower outside group", TicketViewer{User: User{ID: 22, Role: "agent", TicketRestriction: TicketRestrictionGroups}}, false, false, false},
+		{
+			"light agent in group",
+			TicketViewer{User: User{ID: 23, Role: "agent", CustomRoleID: 5}, CustomRole: lightAgent, GroupMemberships: []GroupMembership{{GroupID: 300}}},
+			true, false, false,
+		},
+		{"light agent outside group", TicketViewer{User: User{ID: 23, Role: "agent", CustomRoleID: 5}, CustomRole: lightAgent}, false, false, false},
+		{
+			"agent outside public group",
+			TicketViewer{User: User{ID: 24, Role: "agent", CustomRoleID: 6}, CustomRole: publicGroups, TicketGroup: &Group{ID: 300, IsPublic: &public}},
+			true, true, false,
+		},
+		{
+			"agent outside private group",
+			TicketViewer{User: User{ID: 24, Role: "agent", CustomRoleID: 6}, CustomRole: publicGroups, TicketGroup: &Group{ID: 300, IsPublic: &private}},
+			false, false, false,
+		},
+		{
+			"agent outside unknown group",
+			TicketViewer{User: User{ID: 24, Role: "agent", CustomRoleID: 6}, CustomRole: publicGroups},
+			false, false, true,
+		},
+		{
+			"member of an organization which was not given",
+			TicketViewer{User: User{ID: 13, Role: "end-user", OrganizationID: 400}},
+			false, false, true,
+		},
+	}
+
+	for _, c := range cases {
+		v := ExplainTicketVisibility(ticket, c.viewer)
+		if v.CanView != c.canView || v.CanEdit != c.canEdit || v.Undetermined != c.undetermined {
+			t.Errorf("%s: expected view=%t edit=%t undetermined=%t, but got %+v", c.name, c.canView, c.canEdit, c.undetermined, v)
+		}
+		if len(v.Reasons) == 0 {
+			t.Errorf("%s: visibility should be explained", c.name)
+		}
+	}
+}
+
+func TestGetTicketVisibility(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tickets/1.json":
+			w.Write([]byte(`{"ticket":{"id":1,"requester_id":10,"group_id":300}}`))
+		case "/users/21.json":
+			w.Write([]byte(`{"user":{"id":21,"role":"agent","custom_role_id":5}}`))
+		case "/organization_memberships.json":
+			w.Write([]byte(`{"organization_memberships":[]}`))
+		case "/group_memberships.json":
+			w.Write([]byte(`{"group_memberships":[{"user_id":21,"group_id":300}]}`))
+		case "/custom_roles.json":
+			w.Write([]byte(`{"custom_roles":[{"id":5,"name":"Light agent","configuration":{"ticket_access":"within-groups","ticket_editing":false}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	v, err := GetTicketVisibility(ctx, client, 1, 21)
+	if err != nil {
+		t.Fatalf("Failed to get ticket visibility: %s", err)
+	}
+	if !v.CanView || v.CanEdit {
+		t.Fatalf("Light agent in the ticket group should only view the ticket: %+v", v)
+	}
+}
+
+func TestGetTicketVisibilityPublicGroup(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tickets/1.json":
+			w.Write([]byte(`{"ticket":{"id":1,"requester_id":10,"group_id":300}}`))
+		case "/users/24.json":
+			w.Write([]byte(`{"user":{"id":24,"role":"agent","custom_role_id":6,"organization_id":400}}`))
+		case "/organization_memberships.json":
+			w.Write([]byte(`{"organization_memberships":[],"meta":{"has_more":false}}`))
+		case "/organizations/400.json":
+			w.Write([]byte(`{"organization":{"id":400,"shared_tickets":true}}`))
+		case "/group_memberships.json":
+			// the memberships span two pages
+			if r.URL.Query().Get("page[after]") == "" {
+				w.Write([]byte(`{"group_memberships":[{"user_id":24,"group_id":100}],"meta":{"has_more":true,"after_cursor":"next"}}`))
+			} else {
+				w.Write([]byte(`{"group_memberships":[{"user_id":24,"group_id":200}],"meta":{"has_more":false}}`))
+			}
+		case "/custom_roles.json":
+			w.Write([]byte(`{"custom_roles":[{"id":6,"name":"Public groups agent","configuration":{"ticket_access":"within-groups-and-public-groups"}}]}`))
+		case "/groups/300.json":
+			w.Write([]byte(`{"group":{"id":300,"name":"Billing","is_public":true}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	v, err := GetTicketVisibility(ctx, client, 1, 24)
+	if err != nil {
+		t.Fatalf("Failed to get ticket visibility: %s", err)
+	}
+	if !v.CanView || v.Undetermined {
+		t.Fatalf("Agent should view the ticket in a public group: %+v", v)
+	}
+}
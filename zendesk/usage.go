@@ -0,0 +1,269 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type callerContextKey struct{}
+
+// WithCaller returns a copy of ctx which carries a caller label such as the
+// name of a job. Requests sent with the returned context are counted and
+// limited by the label when usage tracking is enabled.
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, caller)
+}
+
+// CallerFromContext returns the caller label carried by ctx, or "" if ctx has no label.
+func CallerFromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(callerContextKey{}).(string)
+	return caller
+}
+
+// UsageQuota limits the requests of a caller in a window of time
+type UsageQuota struct {
+	// Requests is the number of requests the caller may send in a window
+	Requests int
+
+	// Window is the length of the window. Defaults to a minute.
+	Window time.Duration
+
+	// Delay makes requests over the quota wait for the next window instead of
+	// failing with a QuotaExceededError
+	Delay bool
+}
+
+// UsageConfig is configuration of usage tracking
+type UsageConfig struct {
+	// Quotas are the quotas keyed by caller label. The quota of "" applies to
+	// requests without a label. Callers without a quota are only counted.
+	Quotas map[string]UsageQuota
+
+	// EndpointGroup returns the group of a request path relative to the API
+	// endpoint, such as "/tickets/1/comments.json". Defaults to the first
+	// segment of the path, e.g. "tickets".
+	EndpointGroup func(path string) string
+}
+
+// UsageCount is the number of requests of a caller to an endpoint group which
+// ended with a status. Status is 0 for requests which got no response.
+type UsageCount struct {
+	Caller   string
+	Endpoint string
+	Status   int
+	Requests int64
+}
+
+// UsageSnapshot is the usage counted since tracking was enabled or reset
+type UsageSnapshot struct {
+	// Counts are sorted by caller, endpoint group and status
+	Counts []UsageCount
+
+	// Rejected and Delayed are the requests over quota keyed by caller label
+	Rejected map[string]int64
+	Delayed  map[string]int64
+}
+
+// Requests returns the number of requests sent by a caller
+func (s UsageSnapshot) Requests(caller string) int64 {
+	var n int64
+	for _, c := range s.Counts {
+		if c.Caller == caller {
+			n += c.Requests
+		}
+	}
+	return n
+}
+
+// QuotaExceededError is returned for a request whose caller is over its quota
+type QuotaExceededError struct {
+	Caller string
+
+	// RetryAfter is the time until the next window of the quota
+	RetryAfter time.Duration
+}
+
+func (e QuotaExceededError) Error() string {
+	return fmt.Sprintf("quota of caller %q exceeded, retry after %s", e.Caller, e.RetryAfter)
+}
+
+// SetUsageTracking enables counting of requests by the caller label carried by
+// their context, endpoint group and response status. Callers with a quota are
+// rejected or delayed once they sent their share of requests in a window.
+func (z *Client) SetUsageTracking(cfg UsageConfig) {
+	z.usage = newUsageTracker(cfg)
+}
+
+// Usage returns a snapshot of the usage counters. It is empty when usage
+// tracking is not enabled.
+func (z *Client) Usage() UsageSnapshot {
+	if z.usage == nil {
+		return UsageSnapshot{Rejected: map[string]int64{}, Delayed: map[string]int64{}}
+	}
+	return z.usage.snapshot()
+}
+
+// ResetUsage clears the usage counters. Quota windows are kept.
+func (z *Client) ResetUsage() {
+	if z.usage != nil {
+		z.usage.reset()
+	}
+}
+
+type usageKey struct {
+	caller   string
+	endpoint string
+	status   int
+}
+
+type quotaWindow struct {
+	start time.Time
+	count int
+}
+
+// usageTracker counts requests and enforces quotas in fixed windows
+type usageTracker struct {
+	mu            sync.Mutex
+	quotas        map[string]UsageQuota
+	endpointGroup func(string) string
+	counts        map[usageKey]int64
+	rejected      map[string]int64
+	delayed       map[string]int64
+	windows       map[string]*quotaWindow
+	now           func() time.Time
+}
+
+func newUsageTracker(cfg UsageConfig) *usageTracker {
+	quotas := make(map[string]UsageQuota, len(cfg.Quotas))
+	for caller, q := range cfg.Quotas {
+		if q.Window <= 0 {
+			q.Window = time.Minute
+		}
+		quotas[caller] = q
+	}
+
+	endpointGroup := cfg.EndpointGroup
+	if endpointGroup == nil {
+		endpointGroup = defaultEndpointGroup
+	}
+
+	return &usageTracker{
+		quotas:        quotas,
+		endpointGroup: endpointGroup,
+		counts:        map[usageKey]int64{},
+		rejected:      map[string]int64{},
+		delayed:       map[string]int64{},
+		windows:       map[string]*quotaWindow{},
+		now:           time.Now,
+	}
+}
+
+// defaultEndpointGroup returns the first segment of the path without extension
+func defaultEndpointGroup(path string) string {
+	group := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]
+	return strings.TrimSuffix(group, ".json")
+}
+
+// admit blocks until the caller may send a request, or fails when the caller
+// is over a quota which does not delay
+func (u *usageTracker) admit(ctx context.Context, caller string) error {
+	delayed := false
+	for {
+		u.mu.Lock()
+		q, ok := u.quotas[caller]
+		if !ok {
+			u.mu.Unlock()
+			return nil
+		}
+
+		now := u.now()
+		w := u.windows[caller]
+		if w == nil || !now.Before(w.start.Add(q.Window)) {
+			w = &quotaWindow{start: now}
+			u.windows[caller] = w
+		}
+		if w.count < q.Requests {
+			w.count++
+			u.mu.Unlock()
+			return nil
+		}
+
+		wait := w.start.Add(q.Window).Sub(now)
+		if !q.Delay {
+			u.rejected[caller]++
+			u.mu.Unlock()
+			return QuotaExceededError{Caller: caller, RetryAfter: wait}
+		}
+		if !delayed {
+			delayed = true
+			u.delayed[caller]++
+		}
+		u.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// record counts a sent request. resp is nil when the request got no response.
+func (u *usageTracker) record(caller, path string, resp *http.Response) {
+	key := usageKey{caller: caller, endpoint: u.endpointGroup(path)}
+	if resp != nil {
+		key.status = resp.StatusCode
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.counts[key]++
+}
+
+func (u *usageTracker) snapshot() UsageSnapshot {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	s := UsageSnapshot{
+		Counts:   make([]UsageCount, 0, len(u.counts)),
+		Rejected: make(map[string]int64, len(u.rejected)),
+		Delayed:  make(map[string]int64, len(u.delayed)),
+	}
+	for k, n := range u.counts {
+		s.Counts = append(s.Counts, UsageCount{Caller: k.caller, Endpoint: k.endpoint, Status: k.status, Requests: n})
+	}
+	for caller, n := range u.rejected {
+		s.Rejected[caller] = n
+	}
+	for caller, n := range u.delayed {
+		s.Delayed[caller] = n
+	}
+
+	sort.Slice(s.Counts, func(i, j int) bool {
+		a, b := s.Counts[i], s.Counts[j]
+		if a.Caller != b.Caller {
+			return a.Caller < b.Caller
+		}
+		if a.Endpoint != b.Endpoint {
+			return a.Endpoint < b.Endpoint
+		}
+		return a.Status < b.Status
+	})
+	return s
+}
+
+func (u *usageTracker) reset() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.counts = map[usageKey]int64{}
+	u.rejected = map[string]int64{}
+	u.delayed = map[string]int64{}
+}
@@ -0,0 +1,135 @@
+package zendesk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCallerFromContext(t *testing.T) {
+	if caller := CallerFromContext(ctx); caller != "" {
+		t.Fatalf("Default caller should be empty, but got %s", caller)
+	}
+
+	if caller := CallerFromContext(WithCaller(ctx, "nightly-sync")); caller != "nightly-sync" {
+		t.Fatalf("Expected caller nightly-sync, but got %s", caller)
+	}
+}
+
+func TestUsageCounts(t *testing.T) {
+	// the endpoint has a path, which is not part of the endpoint group
+	mockAPI := httptest.NewServer(http.StripPrefix("/api/v2", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tickets/1.json":
+			w.Write(readFixture("GET/ticket.json"))
+		case "/users/1.json":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	})))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+	client.SetEndpointURL(mockAPI.URL + "/api/v2")
+
+	if s := client.Usage(); len(s.Counts) != 0 {
+		t.Fatalf("Usage should be empty before tracking is enabled %v", s)
+	}
+	client.SetUsageTracking(UsageConfig{})
+
+	job := WithCaller(ctx, "nightly-sync")
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTicket(job, 1); err != nil {
+			t.Fatalf("Failed to get ticket: %s", err)
+		}
+	}
+	if _, err := client.GetUser(job, 1); err == nil {
+		t.Fatal("Expected not found error")
+	}
+	if _, err := client.GetTicket(ctx, 1); err != nil {
+		t.Fatalf("Failed to get ticket: %s", err)
+	}
+
+	s := client.Usage()
+	expected := []UsageCount{
+		{Caller: "", Endpoint: "tickets", Status: http.StatusOK, Requests: 1},
+		{Caller: "nightly-sync", Endpoint: "tickets", Status: http.StatusOK, Requests: 2},
+		{Caller: "nightly-sync", Endpoint: "users", Status: http.StatusNotFound, Requests: 1},
+	}
+	if len(s.Counts) != len(expected) {
+		t.Fatalf("Expected counts %v, but got %v", expected, s.Counts)
+	}
+	for i, c := range expected {
+		if s.Counts[i] != c {
+			t.Fatalf("Expected counts %v, but got %v", expected, s.Counts)
+		}
+	}
+	if n := s.Requests("nightly-sync"); n != 3 {
+		t.Fatalf("Expected 3 requests of nightly-sync, but got %d", n)
+	}
+
+	client.ResetUsage()
+	if s := client.Usage(); len(s.Counts) != 0 {
+		t.Fatalf("Usage should be empty after reset %v", s)
+	}
+}
+
+func TestUsageQuotaRejects(t *testing.T) {
+	u := newUsageTracker(UsageConfig{Quotas: map[string]UsageQuota{"report": {Requests: 2}}})
+	now := time.Unix(0, 0)
+	u.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if err := u.admit(ctx, "report"); err != nil {
+			t.Fatalf("Request within quota should be admitted: %s", err)
+		}
+	}
+
+	now = now.Add(20 * time.Second)
+	var qerr QuotaExceededError
+	if err := u.admit(ctx, "report"); !errors.As(err, &qerr) {
+		t.Fatalf("Request over quota should be rejected, but got %v", err)
+	}
+	if qerr.Caller != "report" || qerr.RetryAfter != 40*time.Second {
+		t.Fatalf("Unexpected quota error %v", qerr)
+	}
+	if err := u.admit(ctx, "other"); err != nil {
+		t.Fatalf("Callers without quota should be admitted: %s", err)
+	}
+
+	now = now.Add(40 * time.Second)
+	if err := u.admit(ctx, "report"); err != nil {
+		t.Fatalf("Request in the next window should be admitted: %s", err)
+	}
+	if n := u.snapshot().Rejected["report"]; n != 1 {
+		t.Fatalf("Expected 1 rejected request, but got %d", n)
+	}
+}
+
+func TestUsageQuotaDelays(t *testing.T) {
+	u := newUsageTracker(UsageConfig{Quotas: map[string]UsageQuota{"": {Requests: 1, Window: 50 * time.Millisecond, Delay: true}}})
+
+	if err := u.admit(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if err := u.admit(ctx, ""); err != nil {
+		t.Fatalf("Request over quota should be delayed: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("Request should wait for the next window, but waited %s", elapsed)
+	}
+	if n := u.snapshot().Delayed[""]; n != 1 {
+		t.Fatalf("Expected 1 delayed request, but got %d", n)
+	}
+
+	canceled, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := u.admit(canceled, ""); err != context.DeadlineExceeded {
+		t.Fatalf("Delayed request should stop with its context, but got %v", err)
+	}
+}
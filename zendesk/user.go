@@ -121,7 +121,12 @@ type UserAPI interface {
 	CreateUser(ctx context.Context, user User) (User, error)
 	CreateOrUpdateUser(ctx context.Context, user User) (User, error)
 	UpdateUser(ctx context.Context, userID int64, user User) (User, error)
+	SetUserSuspended(ctx context.Context, userID int64, suspended bool) (User, error)
+	DeleteUser(ctx context.Context, userID int64) (User, error)
 	GetUserRelated(ctx context.Context, userID int64) (UserRelated, error)
+	CreateManyUsers(ctx context.Context, users []User) (JobStatus, error)
+	CreateOrUpdateManyUsers(ctx context.Context, users []User) (JobStatus, error)
+	DeleteManyUsers(ctx context.Context, userIDs []int64) (JobStatus, error)
 	GetUsersIterator(ctx context.Context, opts *PaginationOptions) *Iterator[User]
 	GetUsersOBP(ctx context.Context, opts *OBPOptions) ([]User, Page, error)
 	GetUsersCBP(ctx context.Context, opts *CBPOptions) ([]User, CursorPaginationMeta, error)
@@ -298,8 +303,6 @@ func (z *Client) CreateOrUpdateUser(ctx context.Context, user User) (User, error
 	return result.User, nil
 }
 
-// TODO: CreateOrUpdateManyUsers(users []User)
-
 // GetUser get an existing user
 // ref: https://developer.zendesk.com/rest_api/docs/support/users#show-user
 func (z *Client) GetUser(ctx context.Context, userID int64) (User, error) {
@@ -339,6 +342,51 @@ func (z *Client) UpdateUser(ctx context.Context, userID int64, user User) (User,
 	return result.User, nil
 }
 
+// SetUserSuspended suspends or unsuspends the user. UpdateUser can not
+// unsuspend a user because false is omitted from its payload.
+// ref: https://developer.zendesk.com/api-reference/ticketing/users/users/#suspending-a-user
+func (z *Client) SetUserSuspended(ctx context.Context, userID int64, suspended bool) (User, error) {
+	var data struct {
+		User struct {
+			Suspended bool `json:"suspended"`
+		} `json:"user"`
+	}
+	var result struct {
+		User User `json:"user"`
+	}
+	data.User.Suspended = suspended
+
+	body, err := z.put(ctx, fmt.Sprintf("/users/%d.json", userID), data)
+	if err != nil {
+		return User{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return User{}, err
+	}
+	return result.User, nil
+}
+
+// DeleteUser deletes the user. The deleted user is returned with active set to false.
+// ref: https://developer.zendesk.com/api-reference/ticketing/users/users/#delete-user
+func (z *Client) DeleteUser(ctx context.Context, userID int64) (User, error) {
+	var result struct {
+		User User `json:"user"`
+	}
+
+	body, err := z.deleteWithBody(ctx, fmt.Sprintf("/users/%d.json", userID))
+	if err != nil {
+		return User{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return User{}, err
+	}
+	return result.User, nil
+}
+
 // GetUserRelated retrieves user related user information
 // ref: https://developer.zendesk.com/api-reference/ticketing/users/users/#show-user-related-information
 func (z *Client) GetUserRelated(ctx context.Context, userID int64) (UserRelated, error) {
@@ -357,3 +405,31 @@ func (z *Client) GetUserRelated(ctx context.Context, userID int64) (UserRelated,
 
 	return data.UserRelated, nil
 }
+
+// CreateManyUsers creates up to 100 users in a background job
+// ref: https://developer.zendesk.com/api-reference/ticketing/users/users/#create-many-users
+func (z *Client) CreateManyUsers(ctx context.Context, users []User) (JobStatus, error) {
+	var data struct {
+		Users []User `json:"users"`
+	}
+	data.Users = users
+
+	return z.postJob(ctx, "/users/create_many.json", data)
+}
+
+// CreateOrUpdateManyUsers creates or updates up to 100 users matched by email or external ID in a background job
+// ref: https://developer.zendesk.com/api-reference/ticketing/users/users/#create-or-update-many-users
+func (z *Client) CreateOrUpdateManyUsers(ctx context.Context, users []User) (JobStatus, error) {
+	var data struct {
+		Users []User `json:"users"`
+	}
+	data.Users = users
+
+	return z.postJob(ctx, "/users/create_or_update_many.json", data)
+}
+
+// DeleteManyUsers deletes up to 100 users in a background job
+// ref: https://developer.zendesk.com/api-reference/ticketing/users/users/#bulk-delete-users
+func (z *Client) DeleteManyUsers(ctx context.Context, userIDs []int64) (JobStatus, error) {
+	return z.deleteJob(ctx, "/users/destroy_many.json", userIDs)
+}
@@ -0,0 +1,420 @@
+package zendesk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// User types of a user segment
+const (
+	UserSegmentSignedInUsers = "signed_in_users"
+	UserSegmentStaff         = "staff"
+)
+
+// UserSegment is a set of Help Center users defined by a user type and a
+// combination of tags, groups, organizations and individual users
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/user_segments/
+type UserSegment struct {
+	ID              int64     `json:"id,omitempty"`
+	Name            string    `json:"name"`
+	UserType        string    `json:"user_type"`
+	GroupIDs        []int64   `json:"group_ids,omitempty"`
+	OrganizationIDs []int64   `json:"organization_ids,omitempty"`
+	Tags            []string  `json:"tags,omitempty"`
+	OrTags          []string  `json:"or_tags,omitempty"`
+	AddedUserIDs    []int64   `json:"added_user_ids,omitempty"`
+	BuiltIn         bool      `json:"built_in,omitempty"`
+	CreatedAt       time.Time `json:"created_at,omitempty"`
+	UpdatedAt       time.Time `json:"updated_at,omitempty"`
+}
+
+// UserSegmentListOptions is options for GetUserSegments. BuiltIn filters by
+// whether the segments are built-in.
+type UserSegmentListOptions struct {
+	PageOptions
+	BuiltIn *bool `url:"built_in,omitempty"`
+}
+
+// UserSegmentAPI an interface containing all user segment related methods
+type UserSegmentAPI interface {
+	GetUserSegments(ctx context.Context, opts *UserSegmentListOptions) ([]UserSegment, Page, error)
+	GetApplicableUserSegments(ctx context.Context, opts *PageOptions) ([]UserSegment, Page, error)
+	GetUserSegmentsForUser(ctx context.Context, userID int64) ([]UserSegment, error)
+	GetUserSegment(ctx context.Context, id int64) (UserSegment, error)
+	CreateUserSegment(ctx context.Context, segment UserSegment) (UserSegment, error)
+	UpdateUserSegment(ctx context.Context, id int64, segment UserSegment) (UserSegment, error)
+	DeleteUserSegment(ctx context.Context, id int64) error
+	GetUserSegmentSections(ctx context.Context, id int64, opts *PageOptions) ([]Section, Page, error)
+	GetUserSegmentTopics(ctx context.Context, id int64, opts *PageOptions) ([]Topic, Page, error)
+}
+
+// GetUserSegments fetch user segment list
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/user_segments/#list-user-segments
+func (z *Client) GetUserSegments(ctx context.Context, opts *UserSegmentListOptions) ([]UserSegment, Page, error) {
+	tmp := opts
+	if tmp == nil {
+		tmp = &UserSegmentListOptions{}
+	}
+
+	u, err := addOptions("/help_center/user_segments.json", tmp)
+	if err != nil {
+		return nil, Page{}, err
+	}
+	return z.getUserSegments(ctx, u)
+}
+
+// GetApplicableUserSegments fetch the user segments which can be applied to Help Center content
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/user_segments/#list-applicable-user-segments
+func (z *Client) GetApplicableUserSegments(ctx context.Context, opts *PageOptions) ([]UserSegment, Page, error) {
+	tmp := opts
+	if tmp == nil {
+		tmp = &PageOptions{}
+	}
+
+	u, err := addOptions("/help_center/user_segments/applicable.json", tmp)
+	if err != nil {
+		return nil, Page{}, err
+	}
+	return z.getUserSegments(ctx, u)
+}
+
+// GetUserSegmentsForUser fetch the user segments the specified user belongs to
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/user_segments/#list-user-segments-for-a-user
+func (z *Client) GetUserSegmentsForUser(ctx context.Context, userID int64) ([]UserSegment, error) {
+	segments, _, err := z.getUserSegments(ctx, fmt.Sprintf("/help_center/users/%d/user_segments.json", userID))
+	return segments, err
+}
+
+func (z *Client) getUserSegments(ctx context.Context, path string) ([]UserSegment, Page, error) {
+	var data struct {
+		UserSegments []UserSegment `json:"user_segments"`
+		Page
+	}
+
+	body, err := z.get(ctx, path)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, Page{}, err
+	}
+	return data.UserSegments, data.Page, nil
+}
+
+// GetUserSegment gets a specified user segment
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/user_segments/#show-user-segment
+func (z *Client) GetUserSegment(ctx context.Context, id int64) (UserSegment, error) {
+	var result struct {
+		UserSegment UserSegment `json:"user_segment"`
+	}
+
+	body, err := z.get(ctx, fmt.Sprintf("/help_center/user_segments/%d.json", id))
+	if err != nil {
+		return UserSegment{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return UserSegment{}, err
+	}
+	return result.UserSegment, nil
+}
+
+// CreateUserSegment creates new user segment
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/user_segments/#create-user-segment
+func (z *Client) CreateUserSegment(ctx context.Context, segment UserSegment) (UserSegment, error) {
+	var data, result struct {
+		UserSegment UserSegment `json:"user_segment"`
+	}
+	data.UserSegment = segment
+
+	body, err := z.post(ctx, "/help_center/user_segments.json", data)
+	if err != nil {
+		return UserSegment{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return UserSegment{}, err
+	}
+	return result.UserSegment, nil
+}
+
+// UpdateUserSegment updates a user segment with the specified user segment
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/user_segments/#update-user-segment
+func (z *Client) UpdateUserSegment(ctx context.Context, id int64, segment UserSegment) (UserSegment, error) {
+	var data, result struct {
+		UserSegment UserSegment `json:"user_segment"`
+	}
+	data.UserSegment = segment
+
+	body, err := z.put(ctx, fmt.Sprintf("/help_center/user_segments/%d.json", id), data)
+	if err != nil {
+		return UserSegment{}, err
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return UserSegment{}, err
+	}
+	return result.UserSegment, nil
+}
+
+// DeleteUserSegment deletes the specified user segment
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/user_segments/#delete-user-segment
+func (z *Client) DeleteUserSegment(ctx context.Context, id int64) error {
+	return z.delete(ctx, fmt.Sprintf("/help_center/user_segments/%d.json", id))
+}
+
+// GetUserSegmentSections fetch the sections restricted to the specified user segment
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/user_segments/#list-sections-with-user-segment
+func (z *Client) GetUserSegmentSections(ctx context.Context, id int64, opts *PageOptions) ([]Section, Page, error) {
+	var data struct {
+		Sections []Section `json:"sections"`
+		Page
+	}
+
+	tmp := opts
+	if tmp == nil {
+		tmp = &PageOptions{}
+	}
+
+	u, err := addOptions(fmt.Sprintf("/help_center/user_segments/%d/sections.json", id), tmp)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	body, err := z.get(ctx, u)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, Page{}, err
+	}
+	return data.Sections, data.Page, nil
+}
+
+// GetUserSegmentTopics fetch the community topics restricted to the specified user segment
+//
+// ref: https://developer.zendesk.com/api-reference/help_center/help-center-api/user_segments/#list-topics-with-user-segment
+func (z *Client) GetUserSegmentTopics(ctx context.Context, id int64, opts *PageOptions) ([]Topic, Page, error) {
+	var data struct {
+		Topics []Topic `json:"topics"`
+		Page
+	}
+
+	tmp := opts
+	if tmp == nil {
+		tmp = &PageOptions{}
+	}
+
+	u, err := addOptions(fmt.Sprintf("/help_center/user_segments/%d/topics.json", id), tmp)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	body, err := z.get(ctx, u)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, Page{}, err
+	}
+	return data.Topics, data.Page, nil
+}
+
+// Matches reports whether the user belongs to the segment. organizationIDs
+// and groupIDs are the organizations and groups the user is a member of.
+// Users added to the segment explicitly always match.
+func (s UserSegment) Matches(user User, organizationIDs, groupIDs []int64) bool {
+	if containsID(s.AddedUserIDs, user.ID) {
+		return true
+	}
+	if s.UserType == UserSegmentStaff && user.Role != "agent" && user.Role != "admin" {
+		return false
+	}
+
+	for _, tag := range s.Tags {
+		if !containsTag(user.Tags, tag) {
+			return false
+		}
+	}
+	if len(s.OrTags) > 0 && !containsAnyTag(user.Tags, s.OrTags) {
+		return false
+	}
+	if len(s.GroupIDs) > 0 && !containsAnyID(groupIDs, s.GroupIDs) {
+		return false
+	}
+	if len(s.OrganizationIDs) > 0 && !containsAnyID(organizationIDs, s.OrganizationIDs) {
+		return false
+	}
+	return true
+}
+
+// GetUserSegmentUsers lists the users who belong to the segment. The API has
+// no such listing, so candidates are fetched from the organizations or groups
+// of the segment, or from all users when it has neither, and then evaluated
+// with UserSegment.Matches.
+func GetUserSegmentUsers(ctx context.Context, api API, segment UserSegment) ([]User, error) {
+	userOrgs := map[int64][]int64{}
+	userGroups := map[int64][]int64{}
+	seen := map[int64]bool{}
+	var candidates []User
+	add := func(users []User) {
+		for _, u := range users {
+			if !seen[u.ID] {
+				seen[u.ID] = true
+				candidates = append(candidates, u)
+			}
+		}
+	}
+
+	for _, groupID := range segment.GroupIDs {
+		opts := NewPaginationOptions()
+		opts.GroupID = groupID
+		it := api.GetGroupMembershipsIterator(ctx, opts)
+		for it.HasMore() {
+			page, err := it.GetNext()
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range page {
+				userGroups[m.UserID] = append(userGroups[m.UserID], m.GroupID)
+			}
+		}
+	}
+
+	switch {
+	case len(segment.OrganizationIDs) > 0:
+		for _, orgID := range segment.OrganizationIDs {
+			opts := NewPaginationOptions()
+			opts.Id = orgID
+			it := api.GetOrganizationUsersIterator(ctx, opts)
+			for it.HasMore() {
+				page, err := it.GetNext()
+				if err != nil {
+					return nil, err
+				}
+				for _, u := range page {
+					userOrgs[u.ID] = append(userOrgs[u.ID], orgID)
+				}
+				add(page)
+			}
+		}
+	case len(segment.GroupIDs) > 0:
+		ids := make([]int64, 0, len(userGroups))
+		for id := range userGroups {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		users, err := getUsersByIDs(ctx, api, ids)
+		if err != nil {
+			return nil, err
+		}
+		add(users)
+	default:
+		opts := NewPaginationOptions()
+		if segment.UserType == UserSegmentStaff {
+			opts.Roles = []string{"agent", "admin"}
+		}
+		it := api.GetUsersIterator(ctx, opts)
+		for it.HasMore() {
+			page, err := it.GetNext()
+			if err != nil {
+				return nil, err
+			}
+			add(page)
+		}
+	}
+
+	var added []int64
+	for _, id := range segment.AddedUserIDs {
+		if !seen[id] {
+			added = append(added, id)
+		}
+	}
+	users, err := getUsersByIDs(ctx, api, added)
+	if err != nil {
+		return nil, err
+	}
+	add(users)
+
+	var members []User
+	for _, u := range candidates {
+		if segment.Matches(u, userOrgs[u.ID], userGroups[u.ID]) {
+			members = append(members, u)
+		}
+	}
+	return members, nil
+}
+
+// getUsersByIDs fetch users in batches of the show many endpoint limit
+func getUsersByIDs(ctx context.Context, api API, ids []int64) ([]User, error) {
+	const batchSize = 100
+
+	var users []User
+	for start := 0; start < len(ids); start += batchSize {
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		s := make([]string, 0, end-start)
+		for _, id := range ids[start:end] {
+			s = append(s, strconv.FormatInt(id, 10))
+		}
+		page, _, err := api.GetManyUsers(ctx, &GetManyUsersOptions{IDs: strings.Join(s, ",")})
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, page...)
+	}
+	return users, nil
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAnyTag(tags, candidates []string) bool {
+	for _, c := range candidates {
+		if containsTag(tags, c) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAnyID(ids, candidates []int64) bool {
+	for _, c := range candidates {
+		if containsID(ids, c) {
+			return true
+		}
+	}
+	return false
+}
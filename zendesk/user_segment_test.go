@@ -0,0 +1,226 @@
+package zendesk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+)
+
+func TestGetUserSegments(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "user_segments.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	segments, _, err := client.GetUserSegments(ctx, nil)
+	if err != nil {
+		t.Fatalf("Failed to get user segments: %s", err)
+	}
+
+	if len(segments) != 2 {
+		t.Fatalf("expected length of user segments is 2, but got %d", len(segments))
+	}
+	if !segments[0].BuiltIn || segments[1].UserType != UserSegmentStaff {
+		t.Fatalf("Returned user segments are not the expected ones %+v", segments)
+	}
+}
+
+func TestGetUserSegmentsForUser(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/help_center/users/35436/user_segments.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write(readFixture("GET/user_segments.json"))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	segments, err := client.GetUserSegmentsForUser(ctx, 35436)
+	if err != nil {
+		t.Fatalf("Failed to get user segments for user: %s", err)
+	}
+
+	if len(segments) != 2 {
+		t.Fatalf("expected length of user segments is 2, but got %d", len(segments))
+	}
+}
+
+func TestGetUserSegment(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "user_segment.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	segment, err := client.GetUserSegment(ctx, 7284)
+	if err != nil {
+		t.Fatalf("Failed to get user segment: %s", err)
+	}
+
+	expectedID := int64(7284)
+	if segment.ID != expectedID {
+		t.Fatalf("Returned user segment does not have the expected ID %d. User segment id is %d", expectedID, segment.ID)
+	}
+}
+
+func TestCreateUserSegment(t *testing.T) {
+	mockAPI := newMockAPIWithStatus(http.MethodPost, "user_segment.json", http.StatusCreated)
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	segment, err := client.CreateUserSegment(ctx, UserSegment{
+		Name:     "VIP agents",
+		UserType: UserSegmentStaff,
+		GroupIDs: []int64{12},
+		Tags:     []string{"vip"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create user segment: %s", err)
+	}
+
+	if segment.ID == 0 {
+		t.Fatal("Created user segment should have an ID")
+	}
+}
+
+func TestUpdateUserSegment(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodPut, "user_segment.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	segment, err := client.UpdateUserSegment(ctx, 7284, UserSegment{Name: "VIP agents"})
+	if err != nil {
+		t.Fatalf("Failed to update user segment: %s", err)
+	}
+
+	if segment.Name != "VIP agents" {
+		t.Fatalf("Updated user segment does not have the expected name %s", segment.Name)
+	}
+}
+
+func TestDeleteUserSegment(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/help_center/user_segments/7284.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	err := client.DeleteUserSegment(ctx, 7284)
+	if err != nil {
+		t.Fatalf("Failed to delete user segment: %s", err)
+	}
+}
+
+func TestGetUserSegmentSections(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "user_segment_sections.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	sections, _, err := client.GetUserSegmentSections(ctx, 7284, nil)
+	if err != nil {
+		t.Fatalf("Failed to get user segment sections: %s", err)
+	}
+
+	if len(sections) != 1 || sections[0].CategoryID != 360000012345 {
+		t.Fatalf("Returned sections are not the expected ones %+v", sections)
+	}
+}
+
+func TestGetUserSegmentTopics(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodGet, "user_segment_topics.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	topics, _, err := client.GetUserSegmentTopics(ctx, 7284, nil)
+	if err != nil {
+		t.Fatalf("Failed to get user segment topics: %s", err)
+	}
+
+	if len(topics) != 1 || topics[0].UserSegmentID != 7284 {
+		t.Fatalf("Returned topics are not the expected ones %+v", topics)
+	}
+}
+
+func TestUserSegmentMatches(t *testing.T) {
+	segment := UserSegment{
+		UserType:     UserSegmentStaff,
+		GroupIDs:     []int64{12, 13},
+		Tags:         []string{"vip"},
+		OrTags:       []string{"gold", "platinum"},
+		AddedUserIDs: []int64{99},
+	}
+
+	cases := []struct {
+		name   string
+		user   User
+		groups []int64
+		want   bool
+	}{
+		{"member", User{ID: 1, Role: "agent", Tags: []string{"vip", "gold"}}, []int64{13}, true},
+		{"end user", User{ID: 2, Role: "end-user", Tags: []string{"vip", "gold"}}, []int64{13}, false},
+		{"missing tag", User{ID: 3, Role: "admin", Tags: []string{"gold"}}, []int64{12}, false},
+		{"missing or tag", User{ID: 4, Role: "admin", Tags: []string{"vip"}}, []int64{12}, false},
+		{"other group", User{ID: 5, Role: "agent", Tags: []string{"vip", "platinum"}}, []int64{14}, false},
+		{"added", User{ID: 99, Role: "end-user"}, nil, true},
+	}
+	for _, c := range cases {
+		if got := segment.Matches(c.user, nil, c.groups); got != c.want {
+			t.Fatalf("%s: expected %v, but got %v", c.name, c.want, got)
+		}
+	}
+
+	orgSegment := UserSegment{UserType: UserSegmentSignedInUsers, OrganizationIDs: []int64{100}}
+	if !orgSegment.Matches(User{ID: 1}, []int64{200, 100}, nil) || orgSegment.Matches(User{ID: 1}, []int64{200}, nil) {
+		t.Fatal("Organization membership is not evaluated")
+	}
+}
+
+func TestGetUserSegmentUsers(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/group_memberships.json":
+			if r.URL.Query().Get("group_id") != "12" {
+				t.Errorf("Unexpected group memberships query %s", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"group_memberships": []GroupMembership{
+				{UserID: 1, GroupID: 12},
+				{UserID: 2, GroupID: 12},
+			}})
+		case "/users/show_many.json":
+			users := map[string][]User{
+				"1,2": {
+					{ID: 1, Role: "agent", Tags: []string{"vip"}},
+					{ID: 2, Role: "agent"},
+				},
+				"99": {{ID: 99, Role: "end-user"}},
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"users": users[r.URL.Query().Get("ids")]})
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	users, err := GetUserSegmentUsers(ctx, client, UserSegment{
+		UserType:     UserSegmentStaff,
+		GroupIDs:     []int64{12},
+		Tags:         []string{"vip"},
+		AddedUserIDs: []int64{99},
+	})
+	if err != nil {
+		t.Fatalf("Failed to get user segment users: %s", err)
+	}
+
+	ids := make([]int, len(users))
+	for i, u := range users {
+		ids[i] = int(u.ID)
+	}
+	sort.Ints(ids)
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 99 {
+		t.Fatalf("Expected users 1 and 99, but got %v", ids)
+	}
+}
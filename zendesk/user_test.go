@@ -1,6 +1,7 @@
 package zendesk
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
@@ -218,6 +219,44 @@ func TestUpdateUserFailure(t *testing.T) {
 	}
 }
 
+func TestSetUserSuspended(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPut || string(body) != `{"user":{"suspended":false}}` {
+			t.Errorf("Unexpected request %s %s %s", r.Method, r.URL, body)
+		}
+		w.Write(readFixture(filepath.Join(http.MethodPut, "user.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	_, err := client.SetUserSuspended(ctx, 369531345753, false)
+	if err != nil {
+		t.Fatalf("Failed to unsuspend user: %s", err)
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/users/369531345753.json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Write(readFixture(filepath.Join(http.MethodGet, "user.json")))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	user, err := client.DeleteUser(ctx, 369531345753)
+	if err != nil {
+		t.Fatalf("Failed to delete user: %s", err)
+	}
+
+	expectedID := int64(369531345753)
+	if user.ID != expectedID {
+		t.Fatalf("Returned user does not have the expected ID %d. User id is %d", expectedID, user.ID)
+	}
+}
+
 func TestGetUserRelated(t *testing.T) {
 	mockAPI := newMockAPIWithStatus(http.MethodGet, "user_related.json", http.StatusOK)
 	client := newTestClient(mockAPI)
@@ -233,3 +272,49 @@ func TestGetUserRelated(t *testing.T) {
 		t.Fatalf("Returned user does not have the expected assigned tickets %d. It is %d", expectedAssignedTickets, userRelated.AssignedTickets)
 	}
 }
+
+func TestCreateManyUsers(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodPost, "job_status.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	job, err := client.CreateManyUsers(ctx, []User{{Name: "a"}, {Name: "b"}})
+	if err != nil {
+		t.Fatalf("Failed to create many users: %s", err)
+	}
+
+	if job.Status != JobStatusQueued {
+		t.Fatalf("Returned job status is not the expected one: %+v", job)
+	}
+}
+
+func TestCreateOrUpdateManyUsers(t *testing.T) {
+	mockAPI := newMockAPI(http.MethodPost, "job_status.json")
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	job, err := client.CreateOrUpdateManyUsers(ctx, []User{{Name: "a", Email: "a@example.com"}})
+	if err != nil {
+		t.Fatalf("Failed to create or update many users: %s", err)
+	}
+
+	if job.Status != JobStatusQueued {
+		t.Fatalf("Returned job status is not the expected one: %+v", job)
+	}
+}
+
+func TestDeleteManyUsers(t *testing.T) {
+	mockAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/destroy_many.json" || r.URL.Query().Get("ids") != "3" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"InvalidValue"}`))
+	}))
+	client := newTestClient(mockAPI)
+	defer mockAPI.Close()
+
+	if _, err := client.DeleteManyUsers(ctx, []int64{3}); err == nil {
+		t.Fatal("Bulk delete should fail on bad request")
+	}
+}
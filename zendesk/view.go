@@ -19,8 +19,11 @@ type (
 		Title       string    `json:"title"`
 		CreatedAt   time.Time `json:"created_at,omitempty"`
 		UpdatedAt   time.Time `json:"updated_at,omitempty"`
+		Conditions  struct {
+			All []TriggerCondition `json:"all"`
+			Any []TriggerCondition `json:"any"`
+		} `json:"conditions"`
 
-		// Conditions Conditions
 		// Execution Execution
 		// Restriction Restriction
 	}
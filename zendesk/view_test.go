@@ -19,6 +19,10 @@ func TestGetView(t *testing.T) {
 	if view.ID != expectedID {
 		t.Fatalf("Returned view does not have the expected ID %d. View ID is %d", expectedID, view.ID)
 	}
+
+	if len(view.Conditions.All) != 2 || view.Conditions.All[0].Field != "status" {
+		t.Fatalf("Returned view does not have the expected conditions %+v", view.Conditions)
+	}
 }
 
 func TestGetViews(t *testing.T) {
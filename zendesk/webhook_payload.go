@@ -0,0 +1,349 @@
+package zendesk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// webhookPlaceholderPattern matches the name of a placeholder like ticket.id or ticket.ticket_field_123
+var webhookPlaceholderPattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+var (
+	webhookTimeType   = reflect.TypeOf(time.Time{})
+	webhookNumberType = reflect.TypeOf(json.Number(""))
+)
+
+// webhookPayloadField is a field of a struct which is rendered in a webhook payload
+type webhookPayloadField struct {
+	index       []int
+	name        string
+	placeholder string
+	typ         reflect.Type
+}
+
+// WebhookPayloadTemplate builds the JSON body of a notification_webhook action from
+// the struct type of v. The JSON key of each field is taken from its json tag and the
+// placeholder from its zendesk tag, e.g.
+//
+//	type TicketPayload struct {
+//		ID      int64    `json:"id" zendesk:"ticket.id"`
+//		Title   string   `json:"title" zendesk:"ticket.title"`
+//		Tags    []string `json:"tags" zendesk:"ticket.tags"`
+//		Requester struct {
+//			Email string `json:"email" zendesk:"ticket.requester.email"`
+//		} `json:"requester"`
+//	}
+//
+// Every placeholder is rendered with the json filter, so the values are escaped and
+// the body is valid JSON whatever the ticket contains. Nested structs become nested
+// objects and fields tagged with zendesk:"-" are skipped.
+// Use DecodeWebhookPayload to parse the payload into the same struct.
+//
+// ref: https://support.zendesk.com/hc/en-us/articles/4408883292186-Creating-webhooks-to-interact-with-third-party-systems
+func WebhookPayloadTemplate(v interface{}) (string, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return "", errors.New("zendesk: webhook payload must be a struct")
+	}
+
+	var buf bytes.Buffer
+	if err := writeWebhookTemplate(&buf, t, ""); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// NewNotificationWebhookAction returns a trigger action which notifies the webhook
+// with the payload template built from v by WebhookPayloadTemplate
+//
+// ref: https://developer.zendesk.com/api-reference/ticketing/business-rules/actions/#notification_webhook
+func NewNotificationWebhookAction(webhookID string, v interface{}) (TriggerAction, error) {
+	body, err := WebhookPayloadTemplate(v)
+	if err != nil {
+		return TriggerAction{}, err
+	}
+
+	return TriggerAction{
+		Field: ActionFieldText(ActionFieldNotificationWebhook),
+		Value: []string{webhookID, body},
+	}, nil
+}
+
+// DecodeWebhookPayload parses the body of a webhook request sent with the template built by
+// WebhookPayloadTemplate into v, which must be a pointer to the same struct.
+// Placeholders are rendered as strings, so they are converted to the type of each field.
+// Empty values leave the fields as zero values and space separated tags are split into []string.
+func DecodeWebhookPayload(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("zendesk: webhook payload must be decoded into a pointer to struct")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var raw map[string]interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	return decodeWebhookObject(raw, rv.Elem())
+}
+
+func writeWebhookTemplate(buf *bytes.Buffer, t reflect.Type, indent string) error {
+	fields, err := webhookPayloadFields(t)
+	if err != nil {
+		return err
+	}
+
+	buf.WriteString("{")
+	for i, f := range fields {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		key, _ := json.Marshal(f.name)
+		buf.WriteString("\n" + indent + "  ")
+		buf.Write(key)
+		buf.WriteString(": ")
+
+		if f.placeholder == "" {
+			if err := writeWebhookTemplate(buf, f.typ, indent+"  "); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(buf, "{{%s | json}}", f.placeholder)
+	}
+	if len(fields) > 0 {
+		buf.WriteString("\n" + indent)
+	}
+	buf.WriteString("}")
+	return nil
+}
+
+// webhookPayloadFields returns the fields of t in a webhook payload.
+// Embedded structs without json tag are flattened like encoding/json does.
+func webhookPayloadFields(t reflect.Type) ([]webhookPayloadField, error) {
+	var fields []webhookPayloadField
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		placeholder := f.Tag.Get("zendesk")
+		jsonTag := f.Tag.Get("json")
+		if placeholder == "-" || jsonTag == "-" {
+			continue
+		}
+
+		if f.Anonymous && jsonTag == "" && placeholder == "" && f.Type.Kind() == reflect.Struct {
+			embedded, err := webhookPayloadFields(f.Type)
+			if err != nil {
+				return nil, err
+			}
+			for _, e := range embedded {
+				e.index = append([]int{i}, e.index...)
+				fields = append(fields, e)
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name := strings.Split(jsonTag, ",")[0]
+		if name == "" {
+			name = f.Name
+		}
+
+		typ := f.Type
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		switch {
+		case placeholder == "" && typ.Kind() == reflect.Struct && typ != webhookTimeType:
+		case placeholder == "":
+			return nil, fmt.Errorf("zendesk: webhook payload field %s has no placeholder", f.Name)
+		case !webhookPlaceholderPattern.MatchString(placeholder):
+			return nil, fmt.Errorf("zendesk: invalid placeholder %q of webhook payload field %s", placeholder, f.Name)
+		case !webhookPayloadTypeSupported(typ):
+			return nil, fmt.Errorf("zendesk: unsupported type %s of webhook payload field %s", f.Type, f.Name)
+		}
+
+		fields = append(fields, webhookPayloadField{
+			index:       []int{i},
+			name:        name,
+			placeholder: placeholder,
+			typ:         typ,
+		})
+	}
+	return fields, nil
+}
+
+func webhookPayloadTypeSupported(t reflect.Type) bool {
+	if t == webhookTimeType || t == webhookNumberType {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.String
+	}
+	return false
+}
+
+func decodeWebhookObject(raw map[string]interface{}, v reflect.Value) error {
+	fields, err := webhookPayloadFields(v.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		value, ok := raw[f.name]
+		if !ok {
+			continue
+		}
+		if err := decodeWebhookValue(value, v.FieldByIndex(f.index)); err != nil {
+			return fmt.Errorf("zendesk: failed to decode webhook payload field %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+func decodeWebhookValue(raw interface{}, v reflect.Value) error {
+	if v.Kind() == reflect.Ptr {
+		if raw == nil {
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeWebhookValue(raw, v.Elem())
+	}
+
+	if v.Type() == webhookNumberType {
+		s := strings.TrimSpace(webhookString(raw))
+		if s != "" {
+			if _, err := strconv.ParseFloat(s, 64); err != nil {
+				return err
+			}
+		}
+		v.SetString(s)
+		return nil
+	}
+
+	if v.Type() == webhookTimeType {
+		s := strings.TrimSpace(webhookString(raw))
+		if s == "" {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected object, but got %T", raw)
+		}
+		return decodeWebhookObject(m, v)
+	case reflect.String:
+		v.SetString(webhookString(raw))
+	case reflect.Bool:
+		if b, ok := raw.(bool); ok {
+			v.SetBool(b)
+			return nil
+		}
+		s := strings.TrimSpace(webhookString(raw))
+		if s == "" {
+			return nil
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s := strings.TrimSpace(webhookString(raw))
+		if s == "" {
+			return nil
+		}
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s := strings.TrimSpace(webhookString(raw))
+		if s == "" {
+			return nil
+		}
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		s := strings.TrimSpace(webhookString(raw))
+		if s == "" {
+			return nil
+		}
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		var list []string
+		switch t := raw.(type) {
+		case []interface{}:
+			for _, e := range t {
+				list = append(list, webhookString(e))
+			}
+		default:
+			// list placeholders like ticket.tags are rendered as space separated string
+			list = strings.Fields(webhookString(raw))
+		}
+		s := reflect.MakeSlice(v.Type(), len(list), len(list))
+		for i, e := range list {
+			s.Index(i).SetString(e)
+		}
+		v.Set(s)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// webhookString returns the rendered value of a placeholder as string
+func webhookString(raw interface{}) string {
+	switch t := raw.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case json.Number:
+		return t.String()
+	case bool:
+		return strconv.FormatBool(t)
+	}
+	return fmt.Sprint(raw)
+}
@@ -0,0 +1,156 @@
+package zendesk
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+type webhookTestMeta struct {
+	Brand string `json:"brand" zendesk:"ticket.brand.name"`
+}
+
+type webhookTestPayload struct {
+	webhookTestMeta
+	ID        int64     `json:"id" zendesk:"ticket.id"`
+	Title     string    `json:"title" zendesk:"ticket.title"`
+	Urgent    bool      `json:"urgent" zendesk:"ticket.ticket_field_360001"`
+	Tags      []string  `json:"tags" zendesk:"ticket.tags"`
+	UpdatedAt time.Time `json:"updated_at" zendesk:"ticket.updated_at_with_timestamp"`
+	Internal  string    `json:"-"`
+	Requester struct {
+		Email string `json:"email" zendesk:"ticket.requester.email"`
+	} `json:"requester"`
+	Assignee *struct {
+		ID int64 `json:"id" zendesk:"ticket.assignee.id"`
+	} `json:"assignee"`
+}
+
+func TestWebhookPayloadTemplate(t *testing.T) {
+	tmpl, err := WebhookPayloadTemplate(&webhookTestPayload{})
+	if err != nil {
+		t.Fatalf("Failed to build webhook payload template: %s", err)
+	}
+
+	expected := `{
+  "brand": {{ticket.brand.name | json}},
+  "id": {{ticket.id | json}},
+  "title": {{ticket.title | json}},
+  "urgent": {{ticket.ticket_field_360001 | json}},
+  "tags": {{ticket.tags | json}},
+  "updated_at": {{ticket.updated_at_with_timestamp | json}},
+  "requester": {
+    "email": {{ticket.requester.email | json}}
+  },
+  "assignee": {
+    "id": {{ticket.assignee.id | json}}
+  }
+}`
+	if tmpl != expected {
+		t.Fatalf("Unexpected webhook payload template:\n%s", tmpl)
+	}
+}
+
+func TestWebhookPayloadTemplateErrors(t *testing.T) {
+	if _, err := WebhookPayloadTemplate("ticket"); err == nil {
+		t.Fatal("Template from non-struct should fail")
+	}
+	if _, err := WebhookPayloadTemplate(struct {
+		ID int64 `json:"id"`
+	}{}); err == nil {
+		t.Fatal("Field without placeholder should fail")
+	}
+	if _, err := WebhookPayloadTemplate(struct {
+		ID int64 `json:"id" zendesk:"ticket.id}}{{x"`
+	}{}); err == nil {
+		t.Fatal("Invalid placeholder should fail")
+	}
+	if _, err := WebhookPayloadTemplate(struct {
+		Fields map[string]string `json:"fields" zendesk:"ticket.fields"`
+	}{}); err == nil {
+		t.Fatal("Unsupported field type should fail")
+	}
+}
+
+func TestNewNotificationWebhookAction(t *testing.T) {
+	action, err := NewNotificationWebhookAction("01GB0000000000000000000000", webhookTestPayload{})
+	if err != nil {
+		t.Fatalf("Failed to create notification webhook action: %s", err)
+	}
+
+	if action.Field != "notification_webhook" {
+		t.Fatalf("Unexpected action field %s", action.Field)
+	}
+	value, ok := action.Value.([]string)
+	if !ok || len(value) != 2 || value[0] != "01GB0000000000000000000000" || !strings.Contains(value[1], "ticket.id") {
+		t.Fatalf("Unexpected action value %v", action.Value)
+	}
+}
+
+func TestDecodeWebhookPayload(t *testing.T) {
+	// the rendered template where every placeholder is a JSON string
+	body := `{
+		"brand": "Example",
+		"id": "35436",
+		"title": "Help, \"my printer\" is on fire!",
+		"urgent": "true",
+		"tags": "printer fire",
+		"updated_at": "2024-01-02T03:04:05Z",
+		"requester": {"email": "jdoe@example.com"},
+		"assignee": {"id": ""}
+	}`
+
+	var payload webhookTestPayload
+	if err := DecodeWebhookPayload([]byte(body), &payload); err != nil {
+		t.Fatalf("Failed to decode webhook payload: %s", err)
+	}
+
+	if payload.Brand != "Example" || payload.ID != 35436 || payload.Title != `Help, "my printer" is on fire!` || !payload.Urgent {
+		t.Fatalf("Unexpected payload %+v", payload)
+	}
+	if len(payload.Tags) != 2 || payload.Tags[1] != "fire" {
+		t.Fatalf("Unexpected tags %v", payload.Tags)
+	}
+	if !payload.UpdatedAt.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Fatalf("Unexpected updated_at %s", payload.UpdatedAt)
+	}
+	if payload.Requester.Email != "jdoe@example.com" || payload.Assignee == nil || payload.Assignee.ID != 0 {
+		t.Fatalf("Unexpected nested objects %+v", payload)
+	}
+
+	// typed JSON values are also accepted
+	var typed webhookTestPayload
+	if err := DecodeWebhookPayload([]byte(`{"id": 1, "urgent": false, "tags": ["a"]}`), &typed); err != nil {
+		t.Fatalf("Failed to decode webhook payload: %s", err)
+	}
+	if typed.ID != 1 || typed.Urgent || len(typed.Tags) != 1 {
+		t.Fatalf("Unexpected payload %+v", typed)
+	}
+
+	if err := DecodeWebhookPayload([]byte(`{"id": "abc"}`), &typed); err == nil {
+		t.Fatal("Invalid number should fail")
+	}
+	if err := DecodeWebhookPayload([]byte(`{}`), typed); err == nil {
+		t.Fatal("Decoding into non-pointer should fail")
+	}
+}
+
+func TestNotificationWebhookActionJSON(t *testing.T) {
+	action, err := NewNotificationWebhookAction("01GB", webhookTestPayload{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(action)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded TriggerAction
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := decoded.Value.([]interface{}); !ok || len(value) != 2 {
+		t.Fatalf("Unexpected encoded action %s", b)
+	}
+}
@@ -31,6 +31,8 @@ type (
 		httpClient *http.Client
 		credential Credential
 		headers    map[string]string
+		scheduler  *scheduler
+		usage      *usageTracker
 	}
 
 	// BaseAPI encapsulates base methods for zendesk client
@@ -129,7 +131,7 @@ func (z *Client) get(ctx context.Context, path string) ([]byte, error) {
 
 	req = z.prepareRequest(ctx, req)
 
-	resp, err := z.httpClient.Do(req)
+	resp, err := z.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -163,7 +165,7 @@ func (z *Client) post(ctx context.Context, path string, data interface{}) ([]byt
 
 	req = z.prepareRequest(ctx, req)
 
-	resp, err := z.httpClient.Do(req)
+	resp, err := z.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +200,7 @@ func (z *Client) put(ctx context.Context, path string, data interface{}) ([]byte
 
 	req = z.prepareRequest(ctx, req)
 
-	resp, err := z.httpClient.Do(req)
+	resp, err := z.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -234,7 +236,7 @@ func (z *Client) patch(ctx context.Context, path string, data interface{}) ([]by
 
 	req = z.prepareRequest(ctx, req)
 
-	resp, err := z.httpClient.Do(req)
+	resp, err := z.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -265,7 +267,7 @@ func (z *Client) delete(ctx context.Context, path string) error {
 
 	req = z.prepareRequest(ctx, req)
 
-	resp, err := z.httpClient.Do(req)
+	resp, err := z.do(req)
 	if err != nil {
 		return err
 	}
@@ -286,10 +288,78 @@ func (z *Client) delete(ctx context.Context, path string) error {
 	return nil
 }
 
+// deleteWithBody sends a delete request and returns response body as []bytes.
+// It is used by the bulk delete endpoints which respond with a job status.
+func (z *Client) deleteWithBody(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodDelete, z.baseURL.String()+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req = z.prepareRequest(ctx, req)
+
+	resp, err := z.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, Error{
+			body: body,
+			resp: resp,
+		}
+	}
+
+	return body, nil
+}
+
+// do sends the request. It waits for the quota of the caller and the scheduler
+// if they are set, and counts the request when usage tracking is enabled
+func (z *Client) do(req *http.Request) (*http.Response, error) {
+	if z.usage != nil {
+		caller := CallerFromContext(req.Context())
+		if err := z.usage.admit(req.Context(), caller); err != nil {
+			return nil, err
+		}
+
+		resp, err := z.send(req)
+		z.usage.record(caller, strings.TrimPrefix(req.URL.Path, z.baseURL.Path), resp)
+		return resp, err
+	}
+
+	return z.send(req)
+}
+
+// send sends the request after waiting for the scheduler if it is set
+func (z *Client) send(req *http.Request) (*http.Response, error) {
+	if z.scheduler == nil {
+		return z.httpClient.Do(req)
+	}
+
+	if err := z.scheduler.acquire(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := z.httpClient.Do(req)
+	if err == nil {
+		z.scheduler.observe(resp)
+	}
+	return resp, err
+}
+
 // prepare request sets common request variables such as authn and user agent
 func (z *Client) prepareRequest(ctx context.Context, req *http.Request) *http.Request {
 	out := req.WithContext(ctx)
 	z.includeHeaders(out)
+	if locale := LocaleFromContext(ctx); locale != "" {
+		out.Header.Set("Accept-Language", locale)
+	}
 	if z.credential != nil {
 		if z.credential.Bearer() {
 			out.Header.Add("Authorization", "Bearer "+z.credential.Secret())